@@ -0,0 +1,173 @@
+// Command i18n exports the translatable texts of BonusPerMe as PO or XLIFF
+// files, imports the translated files back into data/i18n and
+// data/catalog, and checks that every language is in parity with Italian.
+//
+//	go run ./cmd/i18n export -lang all -format po -out build/i18n
+//	go run ./cmd/i18n import build/i18n/bonusperme-en.po
+//	go run ./cmd/i18n parity
+package main
+
+import (
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/translate"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var d translate.Dirs
+	var static string
+	flag.StringVar(&d.I18n, "i18n", "data/i18n", "UI catalog directory")
+	flag.StringVar(&d.Catalog, "catalog", "data/catalog", "bonus catalog directory")
+	flag.StringVar(&static, "static", "static", "directory of the pages that use the UI keys")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "export":
+		err = export(d, args)
+	case "import":
+		err = importFiles(d, args)
+	case "parity":
+		err = parity(d, static)
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "i18n:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: i18n [flags] <command>
+
+  export [-lang en|all] [-format po|xliff] [-out dir]
+        write bonusperme-<lang>.po or .xlf for each language
+  import file...
+        write translated PO/XLIFF files back into the data files
+  parity
+        list keys missing, extra or stale in any language
+
+flags:
+`)
+	flag.PrintDefaults()
+}
+
+func export(d translate.Dirs, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	lang := fs.String("lang", "all", "target language, or all")
+	format := fs.String("format", "po", "po or xliff")
+	out := fs.String("out", ".", "output directory")
+	fs.Parse(args)
+
+	var write func(io.Writer, string, []translate.Unit) error
+	var ext string
+	switch *format {
+	case "po":
+		write, ext = translate.WritePO, ".po"
+	case "xliff":
+		write, ext = translate.WriteXLIFF, ".xlf"
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	langs := []string{*lang}
+	if *lang == "all" {
+		langs = nil
+		for _, l := range i18n.Languages {
+			if l != i18n.Default {
+				langs = append(langs, l)
+			}
+		}
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	for _, l := range langs {
+		units, err := translate.Units(d, l)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(*out, "bonusperme-"+l+ext)
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = write(f, l, units)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d units\n", path, len(units))
+	}
+	return nil
+}
+
+func importFiles(d translate.Dirs, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("import: no files")
+	}
+	rejected := 0
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		var lang string
+		var units []translate.Unit
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".po":
+			lang, units, err = translate.ReadPO(f)
+		case ".xlf", ".xliff":
+			lang, units, err = translate.ReadXLIFF(f)
+		default:
+			err = fmt.Errorf("unknown format (expected .po, .xlf or .xliff)")
+		}
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		res, err := translate.Import(d, lang, units)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Printf("%s (%s): %d applied, %d unchanged, %d skipped\n", path, lang, res.Applied, res.Unchanged, res.Skipped)
+		for _, k := range res.Outdated {
+			fmt.Printf("  outdated: %s\n", k)
+		}
+		for _, r := range res.Rejected {
+			fmt.Printf("  rejected: %s\n", r)
+		}
+		rejected += len(res.Rejected)
+	}
+	if rejected > 0 {
+		return fmt.Errorf("%d units rejected", rejected)
+	}
+	return nil
+}
+
+func parity(d translate.Dirs, static string) error {
+	issues, err := translate.Parity(d, static)
+	if err != nil {
+		return err
+	}
+	for _, is := range issues {
+		fmt.Printf("%s\t%s\t%s\n", is.Lingua, is.Problema, is.Chiave)
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%d keys out of parity", len(issues))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Command istat regenerates internal/istat/comuni.csv from the ISTAT list
+// of the comuni (Elenco-comuni-italiani.csv, published at istat.it) and a
+// list of postal codes with the columns codice and cap.
+//
+//	go run ./cmd/istat -cap cap.csv Elenco-comuni-italiani.csv
+package main
+
+import (
+	"bonusperme/internal/istat"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	var capFile, out string
+	flag.StringVar(&capFile, "cap", "", "CAP list (codice;cap), optional")
+	flag.StringVar(&out, "out", "internal/istat/comuni.csv", "file to write")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: istat [-cap cap.csv] [-out file] Elenco-comuni-italiani.csv")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), capFile, out); err != nil {
+		fmt.Fprintln(os.Stderr, "istat:", err)
+		os.Exit(1)
+	}
+}
+
+func run(elencoFile, capFile, out string) error {
+	elenco, err := os.Open(elencoFile)
+	if err != nil {
+		return err
+	}
+	defer elenco.Close()
+	var cap io.Reader
+	if capFile != "" {
+		f, err := os.Open(capFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		cap = f
+	}
+	var buf bytes.Buffer
+	if err := istat.Importa(elenco, cap, &buf); err != nil {
+		return err
+	}
+	return os.WriteFile(out, buf.Bytes(), 0o644)
+}
@@ -7,14 +7,15 @@ require golang.org/x/net v0.49.0
 require github.com/ledongthuc/pdf v0.0.0-20250511090121-5959a4027728
 
 require (
+	github.com/boombuler/barcode v1.0.1-0.20190219062509-6c824513bacc
 	github.com/getsentry/sentry-go v0.42.0
 	github.com/joho/godotenv v1.5.1
 	github.com/jung-kurt/gofpdf v1.16.2
+	github.com/yuin/goldmark v1.7.16
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/yuin/goldmark v1.7.16 // indirect
 	golang.org/x/sys v0.40.0 // indirect
 	golang.org/x/text v0.33.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,230 @@
+// Package calc implements the calculators behind the bonus estimates
+// (Assegno Unico, ISEE) with their legal parameters kept as data.
+package calc
+
+import (
+	"bonusperme/internal/models"
+	"math"
+	"sort"
+)
+
+// Fascia is an amount that decreases linearly between the two ISEE
+// thresholds of the year: Max up to ISEEMin, Min from ISEEMax (and when
+// no ISEE is presented).
+type Fascia struct {
+	Max float64 `json:"max"`
+	Min float64 `json:"min"`
+}
+
+// ParametriAU are the Assegno Unico amounts for one year, as published
+// in the annual INPS circular (rivalutazione ISTAT).
+type ParametriAU struct {
+	Anno        int     `json:"anno"`
+	Fonte       string  `json:"fonte"`
+	Provvisorio bool    `json:"provvisorio,omitempty"`
+	ISEEMin     float64 `json:"isee_min"`
+	ISEEMax     float64 `json:"isee_max"`
+
+	Minorenne   Fascia `json:"minorenne"`   // per figlio minorenne
+	Maggiorenne Fascia `json:"maggiorenne"` // per figlio 18-21
+	TerzoFiglio Fascia `json:"terzo_figlio"`
+	Lavoratori  Fascia `json:"lavoratori"` // per figlio minorenne, entrambi i genitori lavoratori
+
+	MaggUnder1    float64            `json:"magg_under1"`     // quota della base per figli sotto 1 anno
+	MaggUno3      float64            `json:"magg_1_3"`        // quota della base per figli 1-3 anni (nuclei 3+ figli)
+	Forfait4Figli float64            `json:"forfait_4_figli"` // al mese per nucleo con 4+ figli
+	Disabilita    map[string]float64 `json:"disabilita"`      // per figlio, per grado
+	MadreUnder21  float64            `json:"madre_under21"`   // per figlio
+}
+
+// TabelleAU holds the parameters by year. 2027 repeats the 2026 amounts
+// until the INPS circular with the new rivalutazione is published.
+var TabelleAU = map[int]ParametriAU{
+	2025: {
+		Anno:          2025,
+		Fonte:         "Circolare INPS n. 33 del 7 febbraio 2025",
+		ISEEMin:       17227.33,
+		ISEEMax:       45939.56,
+		Minorenne:     Fascia{Max: 201.00, Min: 57.50},
+		Maggiorenne:   Fascia{Max: 97.70, Min: 28.70},
+		TerzoFiglio:   Fascia{Max: 97.70, Min: 17.20},
+		Lavoratori:    Fascia{Max: 34.40, Min: 0},
+		MaggUnder1:    0.50,
+		MaggUno3:      0.50,
+		Forfait4Figli: 150,
+		Disabilita:    map[string]float64{"non_autosufficienza": 120.60, "grave": 109.10, "media": 97.70},
+		MadreUnder21:  23.00,
+	},
+	2026: {
+		Anno:          2026,
+		Fonte:         "Circolare INPS n. 7 del 30 gennaio 2026",
+		ISEEMin:       17468.51,
+		ISEEMax:       46582.71,
+		Minorenne:     Fascia{Max: 203.80, Min: 58.30},
+		Maggiorenne:   Fascia{Max: 99.10, Min: 29.10},
+		TerzoFiglio:   Fascia{Max: 99.10, Min: 17.40},
+		Lavoratori:    Fascia{Max: 34.90, Min: 0},
+		MaggUnder1:    0.50,
+		MaggUno3:      0.50,
+		Forfait4Figli: 150,
+		Disabilita:    map[string]float64{"non_autosufficienza": 122.30, "grave": 110.60, "media": 99.10},
+		MadreUnder21:  23.30,
+	},
+	2027: {
+		Anno:          2027,
+		Fonte:         "Importi 2026, in attesa della circolare INPS 2027",
+		Provvisorio:   true,
+		ISEEMin:       17468.51,
+		ISEEMax:       46582.71,
+		Minorenne:     Fascia{Max: 203.80, Min: 58.30},
+		Maggiorenne:   Fascia{Max: 99.10, Min: 29.10},
+		TerzoFiglio:   Fascia{Max: 99.10, Min: 17.40},
+		Lavoratori:    Fascia{Max: 34.90, Min: 0},
+		MaggUnder1:    0.50,
+		MaggUno3:      0.50,
+		Forfait4Figli: 150,
+		Disabilita:    map[string]float64{"non_autosufficienza": 122.30, "grave": 110.60, "media": 99.10},
+		MadreUnder21:  23.30,
+	},
+}
+
+// ParametriAnno returns the table for the year, or the closest available
+// year when there is none (the latest one for future years).
+func ParametriAnno(anno int) ParametriAU {
+	if p, ok := TabelleAU[anno]; ok {
+		return p
+	}
+	anni := make([]int, 0, len(TabelleAU))
+	for a := range TabelleAU {
+		anni = append(anni, a)
+	}
+	sort.Ints(anni)
+	best := anni[0]
+	for _, a := range anni {
+		if a <= anno {
+			best = a
+		}
+	}
+	return TabelleAU[best]
+}
+
+// Importo returns the monthly amount of the band for an ISEE value.
+// ISEE 0 means not presented and gets the minimum.
+func (p ParametriAU) Importo(f Fascia, isee float64) float64 {
+	switch {
+	case isee > 0 && isee <= p.ISEEMin:
+		return f.Max
+	case isee > p.ISEEMin && isee <= p.ISEEMax:
+		return f.Max - (isee-p.ISEEMin)/(p.ISEEMax-p.ISEEMin)*(f.Max-f.Min)
+	default:
+		return f.Min
+	}
+}
+
+// VoceAU is one line of the breakdown: Quantita times ImportoUnitario
+// gives Mensile.
+type VoceAU struct {
+	Codice          string  `json:"codice"`
+	Descrizione     string  `json:"descrizione"`
+	Quantita        int     `json:"quantita"`
+	ImportoUnitario float64 `json:"importo_unitario"`
+	Mensile         float64 `json:"mensile"`
+}
+
+// RisultatoAU is the itemised Assegno Unico for a household.
+type RisultatoAU struct {
+	Anno        int      `json:"anno"`
+	Fonte       string   `json:"fonte"`
+	Provvisorio bool     `json:"provvisorio,omitempty"`
+	ISEE        float64  `json:"isee"`
+	FasciaISEE  string   `json:"fascia_isee"`
+	Voci        []VoceAU `json:"voci"`
+	Mensile     float64  `json:"mensile"`
+	Annuo       float64  `json:"annuo"`
+}
+
+// AssegnoUnico computes the monthly Assegno Unico with every maggiorazione
+// itemised, using the parameters of the given year.
+func AssegnoUnico(profile models.UserProfile, anno int) RisultatoAU {
+	p := ParametriAnno(anno)
+	isee := profile.ISEE
+	res := RisultatoAU{Anno: p.Anno, Fonte: p.Fonte, Provvisorio: p.Provvisorio, ISEE: isee}
+
+	switch {
+	case isee <= 0:
+		res.FasciaISEE = "non_presentato"
+	case isee <= p.ISEEMin:
+		res.FasciaISEE = "massima"
+	case isee <= p.ISEEMax:
+		res.FasciaISEE = "intermedia"
+	default:
+		res.FasciaISEE = "minima"
+	}
+
+	add := func(codice, descr string, qta int, unit float64) {
+		if qta <= 0 || unit <= 0 {
+			return
+		}
+		unit = round2(unit)
+		res.Voci = append(res.Voci, VoceAU{
+			Codice: codice, Descrizione: descr, Quantita: qta,
+			ImportoUnitario: unit, Mensile: round2(unit * float64(qta)),
+		})
+	}
+
+	// Number of minors: if FigliMinorenni is set use it, otherwise assume all children are minors
+	// (the household members, when listed, give the exact counts)
+	figli := profile.NumeroFigli
+	minorenni := profile.FigliMinorenni
+	maggiorenni := profile.FigliMaggiorenni
+	if minorenni == 0 && maggiorenni == 0 && figli > 0 && len(profile.Nucleo) == 0 {
+		minorenni = figli
+	}
+
+	baseMin := p.Importo(p.Minorenne, isee)
+	add("base_minorenni", "Quota base per figlio minorenne", minorenni, baseMin)
+	add("base_maggiorenni", "Quota base per figlio 18-21 anni", maggiorenni, p.Importo(p.Maggiorenne, isee))
+
+	// Figli sotto 1 anno: +50% della quota base
+	add("magg_under1", "Maggiorazione figli sotto 1 anno", profile.FigliUnder1, baseMin*p.MaggUnder1)
+
+	// Figli 1-3 anni in nuclei con 3+ figli e ISEE entro la soglia massima
+	if figli >= 3 && (isee == 0 || isee <= p.ISEEMax) {
+		figli1a3 := profile.FigliUnder3 - profile.FigliUnder1
+		add("magg_1_3", "Maggiorazione figli 1-3 anni (nuclei con 3 o piu figli)", figli1a3, baseMin*p.MaggUno3)
+	}
+
+	// Dal terzo figlio in poi
+	if figli >= 3 {
+		add("magg_terzo_figlio", "Maggiorazione dal terzo figlio", figli-2, p.Importo(p.TerzoFiglio, isee))
+	}
+
+	// Forfait nuclei con 4+ figli
+	if figli >= 4 {
+		add("forfait_4_figli", "Maggiorazione forfettaria nuclei con 4 o piu figli", 1, p.Forfait4Figli)
+	}
+
+	if profile.EntrambiGenitoriLavoratori {
+		add("magg_lavoratori", "Maggiorazione entrambi i genitori lavoratori", minorenni, p.Importo(p.Lavoratori, isee))
+	}
+
+	if profile.DisabilitaFigli != "" {
+		add("magg_disabilita", "Maggiorazione figli con disabilita ("+profile.DisabilitaFigli+")", profile.FigliDisabili, p.Disabilita[profile.DisabilitaFigli])
+	}
+
+	if profile.MadreUnder21 {
+		add("magg_madre_under21", "Maggiorazione madre under 21", figli, p.MadreUnder21)
+	}
+
+	total := 0.0
+	for _, v := range res.Voci {
+		total += v.Mensile
+	}
+	res.Mensile = round2(total)
+	res.Annuo = round2(res.Mensile * 12)
+	return res
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
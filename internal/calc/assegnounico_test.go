@@ -0,0 +1,78 @@
+package calc
+
+import (
+	"bonusperme/internal/models"
+	"math"
+	"testing"
+)
+
+func TestParametriAnno(t *testing.T) {
+	for _, tc := range []struct{ anno, want int }{
+		{2025, 2025}, {2026, 2026}, {2024, 2025}, {2030, 2027},
+	} {
+		if got := ParametriAnno(tc.anno).Anno; got != tc.want {
+			t.Errorf("ParametriAnno(%d) = %d, want %d", tc.anno, got, tc.want)
+		}
+	}
+}
+
+func TestImporto(t *testing.T) {
+	p := TabelleAU[2026]
+	for _, tc := range []struct {
+		isee, want float64
+	}{
+		{0, 58.30},
+		{10000, 203.80},
+		{p.ISEEMin, 203.80},
+		{(p.ISEEMin + p.ISEEMax) / 2, 131.05},
+		{p.ISEEMax, 58.30},
+		{60000, 58.30},
+	} {
+		if got := p.Importo(p.Minorenne, tc.isee); math.Abs(got-tc.want) > 0.005 {
+			t.Errorf("Importo(%.2f) = %.4f, want %.2f", tc.isee, got, tc.want)
+		}
+	}
+}
+
+func TestAssegnoUnico(t *testing.T) {
+	p := models.UserProfile{ISEE: 15000, NumeroFigli: 3, FigliMinorenni: 3, FigliUnder3: 2, FigliUnder1: 1,
+		EntrambiGenitoriLavoratori: true}
+	res := AssegnoUnico(p, 2026)
+	want := map[string]float64{
+		"base_minorenni":    611.40,
+		"magg_under1":       101.90,
+		"magg_1_3":          101.90,
+		"magg_terzo_figlio": 99.10,
+		"magg_lavoratori":   104.70,
+	}
+	if len(res.Voci) != len(want) {
+		t.Errorf("voci: %+v", res.Voci)
+	}
+	for _, v := range res.Voci {
+		if v.Mensile != want[v.Codice] {
+			t.Errorf("%s: %.2f, want %.2f", v.Codice, v.Mensile, want[v.Codice])
+		}
+	}
+	if res.FasciaISEE != "massima" || res.Mensile != 1019 || res.Annuo != 12228 || res.Fonte != TabelleAU[2026].Fonte {
+		t.Errorf("fascia %s, mensile %.2f, annuo %.2f", res.FasciaISEE, res.Mensile, res.Annuo)
+	}
+
+	// Without ISEE every child is counted as a minor at the minimum amount
+	res = AssegnoUnico(models.UserProfile{NumeroFigli: 2}, 2026)
+	if res.FasciaISEE != "non_presentato" || res.Mensile != 116.60 {
+		t.Errorf("no ISEE: fascia %s, mensile %.2f", res.FasciaISEE, res.Mensile)
+	}
+
+	// Listed members give the exact counts: no minors, no fallback
+	p = models.UserProfile{NumeroFigli: 1, Nucleo: []models.Componente{{Relazione: "figlio", DataNascita: "2000-01-01"}}}
+	if res := AssegnoUnico(p, 2026); res.Mensile != 0 {
+		t.Errorf("adult child counted: %+v", res.Voci)
+	}
+
+	// Four children: forfait, disability and young mother maggiorazioni
+	p = models.UserProfile{ISEE: 50000, NumeroFigli: 4, FigliMinorenni: 4, FigliDisabili: 1, DisabilitaFigli: "grave", MadreUnder21: true}
+	res = AssegnoUnico(p, 2026)
+	if res.FasciaISEE != "minima" || res.Mensile != round2(4*58.30+2*17.40+150+110.60+4*23.30) {
+		t.Errorf("four children: %.2f %+v", res.Mensile, res.Voci)
+	}
+}
@@ -0,0 +1,40 @@
+package calc
+
+import "math"
+
+// Parametri detrazioni IRPEF (TUIR artt. 15 e 16-bis, L. 207/2024).
+const (
+	SpeseMedicheAliquota   = 0.19
+	SpeseMedicheFranchigia = 129.11
+
+	InteressiMutuoAliquota = 0.19
+	InteressiMutuoMax      = 4000 // interessi detraibili all'anno
+
+	RistrutturazioneSpesaMax          = 96000 // per unita immobiliare
+	RistrutturazioneAliquotaPrimaCasa = 0.50
+	RistrutturazioneAliquota          = 0.36
+	RistrutturazioneRate              = 10
+)
+
+// DetrazioneSpeseMediche returns the yearly deduction for the medical
+// expenses: 19% of the part above the franchigia.
+func DetrazioneSpeseMediche(spese float64) float64 {
+	return round2(math.Max(0, spese-SpeseMedicheFranchigia) * SpeseMedicheAliquota)
+}
+
+// DetrazioneInteressiMutuo returns the yearly deduction for the interest
+// paid on the mortgage for the main home.
+func DetrazioneInteressiMutuo(interessi float64) float64 {
+	return round2(math.Min(math.Max(0, interessi), InteressiMutuoMax) * InteressiMutuoAliquota)
+}
+
+// RataRistrutturazione returns the yearly instalment of the renovation
+// deduction: 50% on the main home (36% otherwise) of the expense up to the
+// cap, split over ten years.
+func RataRistrutturazione(spesa float64, primaCasa bool) float64 {
+	aliquota := RistrutturazioneAliquota
+	if primaCasa {
+		aliquota = RistrutturazioneAliquotaPrimaCasa
+	}
+	return round2(math.Min(math.Max(0, spesa), RistrutturazioneSpesaMax) * aliquota / RistrutturazioneRate)
+}
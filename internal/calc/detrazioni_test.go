@@ -0,0 +1,22 @@
+package calc
+
+import "testing"
+
+func TestDetrazioni(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"spese mediche", DetrazioneSpeseMediche(1000), 165.47},
+		{"spese sotto franchigia", DetrazioneSpeseMediche(100), 0},
+		{"interessi mutuo", DetrazioneInteressiMutuo(3000), 570},
+		{"interessi oltre il tetto", DetrazioneInteressiMutuo(5000), 760},
+		{"ristrutturazione prima casa", RataRistrutturazione(100000, true), 4800},
+		{"ristrutturazione seconda casa", RataRistrutturazione(10000, false), 360},
+		{"spesa negativa", RataRistrutturazione(-1, true), 0},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s: %.2f, want %.2f", tc.name, tc.got, tc.want)
+		}
+	}
+}
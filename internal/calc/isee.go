@@ -0,0 +1,195 @@
+package calc
+
+import "math"
+
+// Parametri ISEE (DPCM 159/2013 e successive modifiche).
+const (
+	iseeQuotaISP = 0.20 // quota del patrimonio (ISP) che entra nell'ISE
+
+	iseeDedLavoroQuota   = 0.20 // deduzione redditi da lavoro dipendente
+	iseeDedLavoroMax     = 3000
+	iseeDedPensioneMax   = 1000 // stessa quota, tetto per le pensioni
+	iseeAffittoMax       = 7000 // canone di locazione deducibile
+	iseeAffittoPerFiglio = 500  // per ogni figlio oltre il secondo
+
+	iseeFranchigiaCasa          = 52500 // casa di abitazione
+	iseeFranchigiaCasaPerFiglio = 2500  // per ogni figlio oltre il secondo
+	iseeQuotaCasaEccedente      = 2.0 / 3.0
+
+	iseeFranchigiaMob          = 6000
+	iseeFranchigiaMobPerComp   = 2000 // per ogni componente oltre il primo
+	iseeFranchigiaMobMax       = 10000
+	iseeFranchigiaMobPerFiglio = 1000  // per ogni figlio oltre il secondo
+	iseeEsclusioneTitoliStato  = 50000 // titoli di Stato esclusi (L. 213/2023)
+)
+
+// scalaBase is the equivalence scale by number of components; every
+// component beyond the fifth adds 0.35.
+var scalaBase = []float64{0, 1.00, 1.57, 2.04, 2.46, 2.85}
+
+// ComponenteISEE is one member of the household with the incomes that
+// enter the ISR.
+type ComponenteISEE struct {
+	RedditoDipendente float64 `json:"reddito_dipendente"`
+	RedditoPensione   float64 `json:"reddito_pensione"`
+	RedditoAltro      float64 `json:"reddito_altro"` // autonomo, esenti, altri redditi
+	Disabile          bool    `json:"disabile"`
+}
+
+// ImmobileISEE is a property owned by the household, valued as for IMU.
+type ImmobileISEE struct {
+	ValoreIMU      float64 `json:"valore_imu"`
+	MutuoResiduo   float64 `json:"mutuo_residuo"`
+	CasaAbitazione bool    `json:"casa_abitazione"`
+}
+
+// NucleoISEE are the DSU components the estimate is built from.
+type NucleoISEE struct {
+	Componenti     []ComponenteISEE `json:"componenti"`
+	NumeroFigli    int              `json:"numero_figli"`
+	FigliMinorenni int              `json:"figli_minorenni"`
+	FigliUnder3    int              `json:"figli_under3"`
+	// GenitoriLavoratori: entrambi i genitori (o l'unico presente) hanno
+	// lavorato almeno sei mesi nell'anno di riferimento.
+	GenitoriLavoratori  bool           `json:"genitori_lavoratori"`
+	CanoneAffitto       float64        `json:"canone_affitto"`
+	Immobili            []ImmobileISEE `json:"immobili"`
+	PatrimonioMobiliare float64        `json:"patrimonio_mobiliare"`
+	TitoliStato         float64        `json:"titoli_stato"`
+}
+
+// VoceISEE is one line of the ISR/ISP computation; negative amounts are
+// deductions and franchigie.
+type VoceISEE struct {
+	Codice      string  `json:"codice"`
+	Descrizione string  `json:"descrizione"`
+	Importo     float64 `json:"importo"`
+}
+
+// StimaISEE is the estimated ISEE with its breakdown.
+type StimaISEE struct {
+	ISEE             float64    `json:"isee"`
+	ISE              float64    `json:"ise"`
+	ISR              float64    `json:"isr"`
+	ISP              float64    `json:"isp"`
+	ScalaEquivalenza float64    `json:"scala_equivalenza"`
+	VociISR          []VoceISEE `json:"voci_isr"`
+	VociISP          []VoceISEE `json:"voci_isp"`
+	VociScala        []VoceISEE `json:"voci_scala"`
+}
+
+// ScalaEquivalenza returns the equivalence scale of the household with
+// its maggiorazioni itemised.
+func ScalaEquivalenza(n NucleoISEE) (float64, []VoceISEE) {
+	comp := len(n.Componenti)
+	if comp == 0 {
+		return 0, nil
+	}
+	var voci []VoceISEE
+	base := 0.0
+	if comp < len(scalaBase) {
+		base = scalaBase[comp]
+	} else {
+		base = scalaBase[len(scalaBase)-1] + 0.35*float64(comp-len(scalaBase)+1)
+	}
+	voci = append(voci, VoceISEE{"base", "Parametro base per numero di componenti", round2(base)})
+	scala := base
+
+	var figli float64
+	switch {
+	case n.NumeroFigli >= 5:
+		figli = 0.5
+	case n.NumeroFigli == 4:
+		figli = 0.35
+	case n.NumeroFigli == 3:
+		figli = 0.2
+	}
+	if figli > 0 {
+		voci = append(voci, VoceISEE{"figli", "Maggiorazione nuclei con tre o piu figli", figli})
+		scala += figli
+	}
+
+	if n.FigliMinorenni > 0 && n.GenitoriLavoratori {
+		m := 0.2
+		if n.FigliUnder3 > 0 {
+			m = 0.3
+		}
+		voci = append(voci, VoceISEE{"genitori_lavoratori", "Maggiorazione figli minorenni e genitori lavoratori", m})
+		scala += m
+	}
+
+	for _, c := range n.Componenti {
+		if c.Disabile {
+			voci = append(voci, VoceISEE{"disabilita", "Maggiorazione componente con disabilita", 0.5})
+			scala += 0.5
+		}
+	}
+	return round2(scala), voci
+}
+
+// StimaISEEDaNucleo estimates the ISEE from the DSU components:
+// ISE = ISR + 20% ISP, ISEE = ISE / scala di equivalenza. It is an
+// estimate: figurative returns on assets and some deductions (spese
+// sanitarie per disabili, assegni al coniuge) are not considered.
+func StimaISEEDaNucleo(n NucleoISEE) StimaISEE {
+	var s StimaISEE
+	figliOltre2 := n.NumeroFigli - 2
+	if figliOltre2 < 0 {
+		figliOltre2 = 0
+	}
+
+	// --- ISR: redditi meno deduzioni ---
+	redditi, dedLavoro := 0.0, 0.0
+	for _, c := range n.Componenti {
+		redditi += c.RedditoDipendente + c.RedditoPensione + c.RedditoAltro
+		dedLavoro += math.Min(c.RedditoDipendente*iseeDedLavoroQuota, iseeDedLavoroMax)
+		dedLavoro += math.Min(c.RedditoPensione*iseeDedLavoroQuota, iseeDedPensioneMax)
+	}
+	dedAffitto := math.Min(n.CanoneAffitto, iseeAffittoMax+iseeAffittoPerFiglio*float64(figliOltre2))
+	s.VociISR = []VoceISEE{
+		{"redditi", "Redditi dei componenti", round2(redditi)},
+		{"deduzione_lavoro", "Deduzione 20% redditi da lavoro dipendente e pensione", deduzione(dedLavoro)},
+	}
+	if dedAffitto > 0 {
+		s.VociISR = append(s.VociISR, VoceISEE{"deduzione_affitto", "Canone di locazione", deduzione(dedAffitto)})
+	}
+	s.ISR = round2(math.Max(0, redditi-dedLavoro-dedAffitto))
+
+	// --- ISP: patrimonio immobiliare e mobiliare al netto delle franchigie ---
+	immobiliare := 0.0
+	franchigiaCasa := iseeFranchigiaCasa + iseeFranchigiaCasaPerFiglio*float64(figliOltre2)
+	for _, im := range n.Immobili {
+		v := math.Max(0, im.ValoreIMU-im.MutuoResiduo)
+		if im.CasaAbitazione {
+			v = math.Max(0, v-franchigiaCasa) * iseeQuotaCasaEccedente
+		}
+		immobiliare += v
+	}
+	s.VociISP = append(s.VociISP, VoceISEE{"immobiliare", "Patrimonio immobiliare (al netto di mutui e franchigia casa)", round2(immobiliare)})
+
+	franchigiaMob := math.Min(iseeFranchigiaMob+iseeFranchigiaMobPerComp*float64(max(len(n.Componenti)-1, 0)), iseeFranchigiaMobMax)
+	franchigiaMob += iseeFranchigiaMobPerFiglio * float64(figliOltre2)
+	titoli := math.Max(0, n.TitoliStato-iseeEsclusioneTitoliStato)
+	mobiliareLordo := n.PatrimonioMobiliare + titoli
+	mobiliare := math.Max(0, mobiliareLordo-franchigiaMob)
+	s.VociISP = append(s.VociISP,
+		VoceISEE{"mobiliare", "Patrimonio mobiliare (titoli di Stato oltre 50.000 inclusi)", round2(mobiliareLordo)},
+		VoceISEE{"franchigia_mobiliare", "Franchigia patrimonio mobiliare", deduzione(math.Min(franchigiaMob, mobiliareLordo))},
+	)
+	s.ISP = round2(immobiliare + mobiliare)
+
+	s.ISE = round2(s.ISR + iseeQuotaISP*s.ISP)
+	s.ScalaEquivalenza, s.VociScala = ScalaEquivalenza(n)
+	if s.ScalaEquivalenza > 0 {
+		s.ISEE = round2(s.ISE / s.ScalaEquivalenza)
+	}
+	return s
+}
+
+// deduzione formats a deducted amount as a negative line item (never -0).
+func deduzione(v float64) float64 {
+	if v == 0 {
+		return 0
+	}
+	return -round2(v)
+}
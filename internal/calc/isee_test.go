@@ -0,0 +1,53 @@
+package calc
+
+import "testing"
+
+func TestScalaEquivalenza(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		n    NucleoISEE
+		want float64
+	}{
+		{"vuoto", NucleoISEE{}, 0},
+		{"single", NucleoISEE{Componenti: make([]ComponenteISEE, 1)}, 1},
+		{"tre figli", NucleoISEE{Componenti: make([]ComponenteISEE, 5), NumeroFigli: 3}, 3.05},
+		{"genitori lavoratori", NucleoISEE{Componenti: make([]ComponenteISEE, 3), FigliMinorenni: 1, GenitoriLavoratori: true}, 2.24},
+		{"under 3", NucleoISEE{Componenti: make([]ComponenteISEE, 3), FigliMinorenni: 1, FigliUnder3: 1, GenitoriLavoratori: true}, 2.34},
+		{"sette con disabile", NucleoISEE{Componenti: []ComponenteISEE{{Disabile: true}, {}, {}, {}, {}, {}, {}}, NumeroFigli: 5}, 4.55},
+	} {
+		if got, _ := ScalaEquivalenza(tc.n); got != tc.want {
+			t.Errorf("%s: scala %.2f, want %.2f", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestStimaISEEDaNucleo(t *testing.T) {
+	s := StimaISEEDaNucleo(NucleoISEE{Componenti: []ComponenteISEE{{RedditoDipendente: 20000}}})
+	if s.ISR != 17000 || s.ISP != 0 || s.ISEE != 17000 {
+		t.Errorf("single: ISR %.2f ISP %.2f ISEE %.2f", s.ISR, s.ISP, s.ISEE)
+	}
+
+	n := NucleoISEE{
+		Componenti:          []ComponenteISEE{{RedditoDipendente: 30000}, {RedditoDipendente: 10000}, {}, {}},
+		NumeroFigli:         2,
+		FigliMinorenni:      2,
+		FigliUnder3:         1,
+		GenitoriLavoratori:  true,
+		CanoneAffitto:       6000,
+		Immobili:            []ImmobileISEE{{ValoreIMU: 100000, MutuoResiduo: 20000, CasaAbitazione: true}},
+		PatrimonioMobiliare: 15000,
+		TitoliStato:         60000,
+	}
+	s = StimaISEEDaNucleo(n)
+	// ISR: 40000 - 5000 lavoro - 6000 affitto; ISP: 2/3 of the home above
+	// the franchigia plus the assets above the 10000 franchigia, with the
+	// titoli di Stato over 50000
+	if s.ISR != 29000 || s.ISP != 33333.33 || s.ISE != 35666.67 || s.ScalaEquivalenza != 2.76 || s.ISEE != 12922.71 {
+		t.Errorf("famiglia: ISR %.2f ISP %.2f ISE %.2f scala %.2f ISEE %.2f", s.ISR, s.ISP, s.ISE, s.ScalaEquivalenza, s.ISEE)
+	}
+	for _, v := range append(s.VociISR, s.VociISP...) {
+		if v.Codice == "deduzione_lavoro" && v.Importo != -5000 || v.Codice == "franchigia_mobiliare" && v.Importo != -10000 {
+			t.Errorf("%s: %.2f", v.Codice, v.Importo)
+		}
+	}
+}
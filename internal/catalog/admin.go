@@ -0,0 +1,114 @@
+package catalog
+
+import (
+	"bonusperme/internal/config"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// AdminStatusHandler serves GET /api/admin/catalog.
+// Returns version and size of the catalog currently in service.
+func AdminStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !checkAdminKey(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	writeStatus(w, http.StatusOK, Current(), nil)
+}
+
+// AdminReloadHandler serves POST /api/admin/catalog/reload.
+// Re-reads the catalog files; on validation errors the current catalog
+// stays in service and the errors are returned with status 422.
+func AdminReloadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !checkAdminKey(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	snap, err := Reload()
+	if err != nil {
+		writeStatus(w, http.StatusUnprocessableEntity, Current(), err)
+		return
+	}
+	writeStatus(w, http.StatusOK, snap, nil)
+}
+
+// AdminTranslationsHandler serves GET /api/admin/catalog/traduzioni.
+// Reports, per language, the bonuses without a translation and the
+// translated ones with fields still in Italian.
+func AdminTranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !checkAdminKey(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	snap := Current()
+	if snap == nil {
+		http.Error(w, "Catalog not loaded", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"version": snap.Version,
+		"lingue":  snap.TranslationCoverage(),
+	})
+}
+
+func writeStatus(w http.ResponseWriter, code int, snap *Snapshot, err error) {
+	resp := map[string]interface{}{"loaded": snap != nil}
+	if snap != nil {
+		resp["version"] = snap.Version
+		resp["loaded_at"] = snap.LoadedAt
+		resp["files"] = snap.Files
+		resp["national"] = len(snap.National)
+		resp["regional"] = len(snap.Regional)
+		resp["local"] = len(snap.Local)
+	}
+	if err != nil {
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			msgs := make([]string, len(verr.Errors))
+			for i, e := range verr.Errors {
+				msgs[i] = e.Error()
+			}
+			resp["errors"] = msgs
+		} else {
+			resp["errors"] = []string{err.Error()}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func checkAdminKey(r *http.Request) bool {
+	key := config.Cfg.AdminAPIKey
+	if key == "" {
+		return true // no key configured = open access (dev mode)
+	}
+	// Check query param
+	if r.URL.Query().Get("key") == key {
+		return true
+	}
+	// Check header
+	if r.Header.Get("X-Admin-Key") == key {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,353 @@
+// Package catalog loads the bonus catalog from versioned data files
+// (data/catalog by default) and serves it as an immutable snapshot.
+//
+// Layout:
+//
+//	data/catalog/nazionali/<id>.yaml      one national bonus per file
+//	data/catalog/regionali/<regione>.yaml all bonuses of one region
+//	data/catalog/comunali/<comune>.yaml   provincial and municipal bonuses
+//	data/catalog/traduzioni/<lang>/<id>.yaml translated text of one bonus
+//
+// Every file is validated against the catalog schema before the snapshot
+// is swapped in; a file that fails validation leaves the previous catalog
+// in service.
+package catalog
+
+import (
+	"bonusperme/internal/logger"
+	"bonusperme/internal/models"
+	sentryutil "bonusperme/internal/sentry"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the catalog file format understood by this build.
+const SchemaVersion = 1
+
+const (
+	nationalDir = "nazionali"
+	regionalDir = "regionali"
+	localDir    = "comunali"
+)
+
+// File is the on-disk envelope of a catalog file.
+type File struct {
+	Schema int            `json:"schema"`
+	Bonus  []models.Bonus `json:"bonus"`
+}
+
+// Snapshot is one immutable, validated version of the catalog.
+type Snapshot struct {
+	Version  string    `json:"version"`
+	LoadedAt time.Time `json:"loaded_at"`
+	Dir      string    `json:"dir"`
+	Files    int       `json:"files"`
+
+	National []models.Bonus `json:"-"`
+	Regional []models.Bonus `json:"-"`
+	Local    []models.Bonus `json:"-"` // provincial and municipal
+
+	byID map[string]models.Bonus
+}
+
+var (
+	current atomic.Pointer[Snapshot]
+
+	// reloadMu serialises Load/Reload so two concurrent reloads cannot
+	// interleave their swaps.
+	reloadMu sync.Mutex
+	dir      string
+	stamp    string
+)
+
+// OnReload is called after a new snapshot has been swapped in.
+// Set from main.go, before Watch, to refresh caches built on top of the
+// catalog.
+var OnReload func(*Snapshot)
+
+// Load reads and validates every catalog file under root and, if the
+// whole catalog is valid, atomically replaces the served snapshot.
+func Load(root string) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	return load(root)
+}
+
+// Reload re-reads the directory passed to the last successful Load.
+func Reload() (*Snapshot, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	if dir == "" {
+		return nil, errors.New("catalog: not loaded")
+	}
+	if err := load(dir); err != nil {
+		return nil, err
+	}
+	return current.Load(), nil
+}
+
+func load(root string) error {
+	snap, err := readDir(root)
+	if err != nil {
+		return err
+	}
+	current.Store(snap)
+	dir = root
+	stamp, _ = fingerprint(root)
+
+	logger.Info("catalog: loaded", map[string]interface{}{
+		"version":  snap.Version,
+		"files":    snap.Files,
+		"national": len(snap.National),
+		"regional": len(snap.Regional),
+		"local":    len(snap.Local),
+	})
+
+	if OnReload != nil {
+		OnReload(snap)
+	}
+	return nil
+}
+
+// Current returns the snapshot in service, or nil before the first Load.
+func Current() *Snapshot {
+	return current.Load()
+}
+
+// National returns a copy of the national bonuses in the current snapshot.
+func National() []models.Bonus {
+	s := current.Load()
+	if s == nil {
+		return nil
+	}
+	out := make([]models.Bonus, len(s.National))
+	copy(out, s.National)
+	return out
+}
+
+// Regional returns a copy of the regional bonuses in the current snapshot.
+func Regional() []models.Bonus {
+	s := current.Load()
+	if s == nil {
+		return nil
+	}
+	out := make([]models.Bonus, len(s.Regional))
+	copy(out, s.Regional)
+	return out
+}
+
+// Local returns a copy of the provincial and municipal bonuses in the
+// current snapshot.
+func Local() []models.Bonus {
+	s := current.Load()
+	if s == nil {
+		return nil
+	}
+	out := make([]models.Bonus, len(s.Local))
+	copy(out, s.Local)
+	return out
+}
+
+// Bonuses returns every bonus of the snapshot, national first, then
+// regional and local.
+func (s *Snapshot) Bonuses() []models.Bonus {
+	all := make([]models.Bonus, 0, len(s.National)+len(s.Regional)+len(s.Local))
+	all = append(all, s.National...)
+	all = append(all, s.Regional...)
+	return append(all, s.Local...)
+}
+
+// Lookup returns the catalog entry with the given ID.
+func Lookup(id string) (models.Bonus, bool) {
+	s := current.Load()
+	if s == nil {
+		return models.Bonus{}, false
+	}
+	b, ok := s.byID[id]
+	return b, ok
+}
+
+// Watch polls the catalog directory and reloads it whenever a file is
+// added, removed or modified. An invalid edit is logged and reported to
+// Sentry; the previous snapshot stays in service until the files are fixed.
+func Watch(interval time.Duration) {
+	if interval <= 0 {
+		logger.Info("catalog: file watch disabled", nil)
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			reloadMu.Lock()
+			root, last := dir, stamp
+			reloadMu.Unlock()
+			if root == "" {
+				continue
+			}
+			fp, err := fingerprint(root)
+			if err != nil || fp == last {
+				continue
+			}
+			if _, err := Reload(); err != nil {
+				logger.Error("catalog: reload rejected", map[string]interface{}{"error": err.Error()})
+				sentryutil.CaptureError(err, map[string]string{"component": "catalog", "phase": "watch"})
+				// Remember the broken state so the same error isn't reported every tick.
+				reloadMu.Lock()
+				stamp = fp
+				reloadMu.Unlock()
+			}
+		}
+	}()
+}
+
+// Read parses and validates the catalog under root without putting it in
+// service, for tools that work on the files.
+func Read(root string) (*Snapshot, error) {
+	return readDir(root)
+}
+
+// readDir parses, validates and assembles a snapshot without touching
+// the one in service.
+func readDir(root string) (*Snapshot, error) {
+	snap := &Snapshot{Dir: root, LoadedAt: time.Now()}
+	hash := sha256.New()
+	var errs []error
+	seen := make(map[string]string)
+
+	for _, sub := range []string{nationalDir, regionalDir, localDir} {
+		paths, err := listFiles(filepath.Join(root, sub))
+		if err != nil {
+			return nil, fmt.Errorf("catalog: %w", err)
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("catalog: %w", err)
+			}
+			hash.Write([]byte(path))
+			hash.Write(data)
+			snap.Files++
+
+			rel, _ := filepath.Rel(root, path)
+			f, err := decodeFile(path, data)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", rel, err))
+				continue
+			}
+			errs = append(errs, validateFile(rel, f, sub, seen)...)
+			switch sub {
+			case regionalDir:
+				snap.Regional = append(snap.Regional, f.Bonus...)
+			case localDir:
+				snap.Local = append(snap.Local, f.Bonus...)
+			default:
+				snap.National = append(snap.National, f.Bonus...)
+			}
+		}
+	}
+	errs = append(errs, validateRelazioni(snap.Bonuses(), seen)...)
+	errs = append(errs, readTranslations(root, snap, hash)...)
+
+	if snap.Files == 0 {
+		return nil, fmt.Errorf("catalog: no data files in %s", root)
+	}
+	if len(errs) > 0 {
+		return nil, &ValidationError{Errors: errs}
+	}
+	snap.Version = hex.EncodeToString(hash.Sum(nil))[:12]
+	snap.byID = make(map[string]models.Bonus)
+	for _, b := range snap.Bonuses() {
+		snap.byID[b.ID] = b
+	}
+	return snap, nil
+}
+
+// decodeFile accepts YAML or JSON. YAML is converted to JSON first so that
+// both formats share the json tags of models.Bonus and unknown keys are
+// rejected the same way.
+func decodeFile(path string, data []byte) (File, error) {
+	var f File
+	err := decode(path, data, &f)
+	return f, err
+}
+
+func decode(path string, data []byte, v interface{}) error {
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		var err error
+		if data, err = json.Marshal(raw); err != nil {
+			return err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+func listFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		switch filepath.Ext(name) {
+		case ".yaml", ".yml", ".json":
+			out = append(out, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// fingerprint summarises names, sizes and mtimes of the catalog files,
+// cheap enough to poll without reading file contents.
+func fingerprint(root string) (string, error) {
+	langs, err := translationLangs(root)
+	if err != nil {
+		return "", err
+	}
+	subs := []string{nationalDir, regionalDir, localDir}
+	for _, lang := range langs {
+		subs = append(subs, filepath.Join(translationsDir, lang))
+	}
+	var b strings.Builder
+	for _, sub := range subs {
+		paths, err := listFiles(filepath.Join(root, sub))
+		if err != nil {
+			return "", err
+		}
+		for _, p := range paths {
+			info, err := os.Stat(p)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "%s|%d|%d\n", p, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,124 @@
+package catalog_test
+
+import (
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/i18n"
+	_ "bonusperme/internal/matcher" // registers the valore formulas
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	if err := catalog.Load("../../data/catalog"); err != nil {
+		panic(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	snap := catalog.Current()
+	if snap == nil || len(snap.Version) != 12 {
+		t.Fatalf("snapshot not in service: %+v", snap)
+	}
+	if len(catalog.National()) == 0 || len(catalog.Regional()) == 0 || len(catalog.Local()) == 0 {
+		t.Errorf("empty sections: %d national, %d regional, %d local", len(catalog.National()), len(catalog.Regional()), len(catalog.Local()))
+	}
+	b, ok := catalog.Lookup("bonus-nido")
+	if !ok || b.Categoria != "famiglia" || len(b.Requisiti) == 0 {
+		t.Errorf("Lookup(bonus-nido) = %+v, %v", b, ok)
+	}
+	if _, ok := b.Traduzioni["en"]; !ok {
+		t.Errorf("bonus-nido has no English translation")
+	}
+	if _, ok := catalog.Lookup("bonus-inesistente"); ok {
+		t.Errorf("Lookup of an unknown id succeeded")
+	}
+}
+
+const bonusValido = `
+  - id: %s
+    nome: Bonus di prova
+    categoria: famiglia
+    descrizione: Bonus usato nei test.
+    importo: €100
+    scadenza: 31 dicembre 2026
+    valore: {tipo: trasferimento, periodicita: una_tantum, atteso: 100}
+    ente: INPS
+    link_ufficiale: https://www.inps.it/
+    requisiti: [Figli]
+    come_richiederlo: [Portale INPS]
+    idoneita:
+      requisiti:
+        - {campo: numero_figli, min: 1}
+      punteggi:
+        - {punteggio: 80}
+`
+
+func TestRead_Validation(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, body string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("nazionali/a.yaml", "schema: 1\nbonus:"+strings.ReplaceAll(bonusValido, "%s", "bonus-prova"))
+	if snap, err := catalog.Read(root); err != nil || len(snap.National) != 1 {
+		t.Fatalf("valid catalog: %v", err)
+	}
+
+	write("nazionali/b.yaml", "schema: 1\nbonus:"+strings.ReplaceAll(bonusValido, "%s", "bonus-prova"))
+	write("nazionali/c.yaml", "schema: 2\nbonus: []\n")
+	write("regionali/d.yaml", "schema: 1\nbonus:"+strings.ReplaceAll(bonusValido, "%s", "Bonus_Regione"))
+	write("nazionali/e.json", `{"schema": 1, "bonus": [{"id": "x", "sconosciuto": true}]}`)
+	_, err := catalog.Read(root)
+	var verr *catalog.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("want ValidationError, got %v", err)
+	}
+	for _, want := range []string{
+		"duplicate id (already defined in nazionali/a.yaml)",
+		"unsupported schema 2",
+		`invalid id "Bonus_Regione"`,
+		"regioni is required for regional bonuses",
+		`unknown field "sconosciuto"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("errors missing %q:\n%s", want, err)
+		}
+	}
+	if catalog.Current().Dir != "../../data/catalog" {
+		t.Errorf("Read replaced the catalog in service")
+	}
+}
+
+func TestAdminTranslationsHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/catalog/traduzioni", nil)
+	w := httptest.NewRecorder()
+	catalog.AdminTranslationsHandler(w, req)
+	var cov struct {
+		Lingue []catalog.Coverage `json:"lingue"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &cov)
+	if len(cov.Lingue) != len(i18n.Languages)-1 {
+		t.Fatalf("expected coverage for %d languages, got %d", len(i18n.Languages)-1, len(cov.Lingue))
+	}
+	for _, c := range cov.Lingue {
+		if c.Tradotti+len(c.Mancanti) != c.Totale {
+			t.Errorf("%s: %d translated + %d missing != %d", c.Lingua, c.Tradotti, len(c.Mancanti), c.Totale)
+		}
+		for _, id := range c.Mancanti {
+			if id == "assegno-unico" {
+				t.Errorf("%s: assegno-unico is translated in every language", c.Lingua)
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package catalog
+
+import (
+	"bonusperme/internal/deadline"
+	"bonusperme/internal/eligibility"
+	"bonusperme/internal/istat"
+	"bonusperme/internal/models"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ValidationError collects every schema violation found in one load, so
+// an editor sees all problems at once instead of fixing them one by one.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("catalog: %d validation errors:\n  %s", len(e.Errors), strings.Join(msgs, "\n  "))
+}
+
+var idRe = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidCategorie lists the categories the frontend knows how to render.
+var ValidCategorie = map[string]bool{
+	"famiglia": true, "casa": true, "istruzione": true, "trasporti": true,
+	"salute": true, "lavoro": true, "sostegno": true, "spesa": true,
+	"utenze": true, "altro": true,
+}
+
+var validStato = map[string]bool{
+	"attivo": true, "scaduto": true, "in_scadenza": true, "sospeso": true,
+}
+
+// validateFile checks one decoded file of the directory sub against the
+// schema. seen maps bonus IDs to the file that declared them and is shared
+// across the load to catch duplicates between files.
+func validateFile(rel string, f File, sub string, seen map[string]string) []error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]interface{}{rel}, args...)...))
+	}
+
+	if f.Schema != SchemaVersion {
+		fail("unsupported schema %d (expected %d)", f.Schema, SchemaVersion)
+		return errs
+	}
+	if len(f.Bonus) == 0 {
+		fail("no bonus in file")
+	}
+
+	for i, b := range f.Bonus {
+		where := fmt.Sprintf("bonus[%d]", i)
+		if b.ID != "" {
+			where = b.ID
+		}
+		for _, msg := range validateBonus(b, sub) {
+			fail("%s: %s", where, msg)
+		}
+		if b.ID == "" {
+			continue
+		}
+		if prev, dup := seen[b.ID]; dup {
+			fail("%s: duplicate id (already defined in %s)", b.ID, prev)
+		}
+		seen[b.ID] = rel
+	}
+	return errs
+}
+
+func validateBonus(b models.Bonus, sub string) []string {
+	var msgs []string
+
+	switch {
+	case b.ID == "":
+		msgs = append(msgs, "id is required")
+	case !idRe.MatchString(b.ID):
+		msgs = append(msgs, fmt.Sprintf("invalid id %q (lowercase letters, digits and dashes only)", b.ID))
+	}
+
+	required := map[string]string{
+		"nome":           b.Nome,
+		"categoria":      b.Categoria,
+		"descrizione":    b.Descrizione,
+		"importo":        b.Importo,
+		"scadenza":       b.Scadenza,
+		"ente":           b.Ente,
+		"link_ufficiale": b.LinkUfficiale,
+	}
+	for _, field := range []string{"nome", "categoria", "descrizione", "importo", "scadenza", "ente", "link_ufficiale"} {
+		if strings.TrimSpace(required[field]) == "" {
+			msgs = append(msgs, field+" is required")
+		}
+	}
+	if b.Categoria != "" && !ValidCategorie[b.Categoria] {
+		msgs = append(msgs, fmt.Sprintf("unknown categoria %q", b.Categoria))
+	}
+	if b.Stato != "" && !validStato[b.Stato] {
+		msgs = append(msgs, fmt.Sprintf("unknown stato %q", b.Stato))
+	}
+	if len(b.Requisiti) == 0 {
+		msgs = append(msgs, "requisiti is required")
+	}
+	if len(b.ComeRichiederlo) == 0 {
+		msgs = append(msgs, "come_richiederlo is required")
+	}
+	for _, link := range [][2]string{
+		{"link_ufficiale", b.LinkUfficiale},
+		{"link_ricerca", b.LinkRicerca},
+		{"fonte_url", b.FonteURL},
+	} {
+		if link[1] != "" && !isHTTPURL(link[1]) {
+			msgs = append(msgs, fmt.Sprintf("%s %q is not an http(s) URL", link[0], link[1]))
+		}
+	}
+	for i, q := range b.FAQ {
+		if strings.TrimSpace(q.Domanda) == "" || strings.TrimSpace(q.Risposta) == "" {
+			msgs = append(msgs, fmt.Sprintf("faq[%d]: domanda and risposta are required", i))
+		}
+	}
+	if b.SogliaISEE < 0 {
+		msgs = append(msgs, "soglia_isee must not be negative")
+	}
+
+	for _, msg := range eligibility.Validate(b.Idoneita) {
+		msgs = append(msgs, "idoneita: "+msg)
+	}
+	for _, msg := range eligibility.ValidateValore(b.Valore) {
+		msgs = append(msgs, "valore: "+msg)
+	}
+	for _, msg := range deadline.Valida(b.Termine) {
+		msgs = append(msgs, "termine: "+msg)
+	}
+
+	switch {
+	case sub == regionalDir && len(b.RegioniApplicabili) == 0:
+		msgs = append(msgs, "regioni is required for regional bonuses")
+	case sub == nationalDir && len(b.RegioniApplicabili) > 0:
+		msgs = append(msgs, "regioni is not allowed on national bonuses (move the file to regionali/)")
+	case sub == localDir && len(b.RegioniApplicabili) > 0:
+		msgs = append(msgs, "regioni is not allowed on local bonuses (the comuni and province imply it)")
+	case sub == localDir && len(b.Comuni) == 0 && len(b.Province) == 0:
+		msgs = append(msgs, "comuni or province is required for local bonuses")
+	}
+	if sub != localDir && (len(b.Comuni) > 0 || len(b.Province) > 0) {
+		msgs = append(msgs, "comuni and province are only allowed on local bonuses (move the file to comunali/)")
+	}
+	for _, r := range b.RegioniApplicabili {
+		switch reg, ok := istat.LookupRegione(r); {
+		case !ok:
+			msgs = append(msgs, fmt.Sprintf("unknown regione %q (expected an ISTAT code, e.g. 12 or 022)", r))
+		case reg.Codice != r:
+			msgs = append(msgs, fmt.Sprintf("regione %q must be written as its ISTAT code %q", r, reg.Codice))
+		}
+	}
+	for _, c := range b.Comuni {
+		if _, ok := istat.Lookup(c); !ok {
+			msgs = append(msgs, fmt.Sprintf("unknown comune %q (expected an ISTAT code, e.g. 058091)", c))
+		}
+	}
+	for _, p := range b.Province {
+		if !istat.HasProvincia(p) {
+			msgs = append(msgs, fmt.Sprintf("unknown provincia %q (expected a sigla, e.g. RM)", p))
+		}
+	}
+
+	for _, r := range b.Incompatibili {
+		if strings.TrimSpace(r.Bonus) == "" || strings.TrimSpace(r.Motivo) == "" {
+			msgs = append(msgs, "incompatibile: bonus and motivo are required")
+		}
+	}
+	for _, r := range b.Cumulabili {
+		if strings.TrimSpace(r.Bonus) == "" {
+			msgs = append(msgs, "cumulabile: bonus is required")
+		}
+	}
+
+	// Fields computed at match time or by the verification pipeline must
+	// not be authored in the catalog.
+	if b.Compatibilita != 0 || b.ImportoReale != "" || b.StatoValidita != "" ||
+		b.ConfidenceScore != 0 || b.LinkVerificato || !b.ScadenzaDomanda.IsZero() ||
+		b.Finestra != nil || len(b.Spiegazione) > 0 || b.ValoreStimato != nil || b.Lingua != "" {
+		msgs = append(msgs, "sets runtime-only fields (compatibilita, importo_reale, stato_validita, ...)")
+	}
+	if len(b.Traduzioni) > 0 {
+		msgs = append(msgs, "traduzioni is not allowed here (use traduzioni/<lang>/<id>.yaml)")
+	}
+	return msgs
+}
+
+// validateRelazioni checks the incompatibile and cumulabile lists once
+// every file has been read: each must name another bonus of the catalog,
+// and a pair cannot be declared both incompatible and cumulable, on either
+// side. seen maps bonus IDs to their file.
+func validateRelazioni(bonuses []models.Bonus, seen map[string]string) []error {
+	var errs []error
+	dichiarate := make(map[[2]string]string)
+	for _, b := range bonuses {
+		for _, rel := range []struct {
+			campo string
+			list  []models.Relazione
+		}{{"incompatibile", b.Incompatibili}, {"cumulabile", b.Cumulabili}} {
+			for _, r := range rel.list {
+				fail := func(format string, args ...interface{}) {
+					errs = append(errs, fmt.Errorf("%s: %s: %s: "+format, append([]interface{}{seen[b.ID], b.ID, rel.campo}, args...)...))
+				}
+				switch {
+				case r.Bonus == "":
+					continue
+				case r.Bonus == b.ID:
+					fail("a bonus cannot refer to itself")
+				case seen[r.Bonus] == "":
+					fail("unknown bonus %q", r.Bonus)
+				}
+				coppia := [2]string{b.ID, r.Bonus}
+				if coppia[0] > coppia[1] {
+					coppia[0], coppia[1] = coppia[1], coppia[0]
+				}
+				if prev, ok := dichiarate[coppia]; ok && prev != rel.campo {
+					fail("%s is declared both incompatibile and cumulabile", r.Bonus)
+				}
+				dichiarate[coppia] = rel.campo
+			}
+		}
+	}
+	return errs
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,276 @@
+package catalog
+
+import (
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/models"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const translationsDir = "traduzioni"
+
+// TranslationFile is the on-disk envelope of a bonus translation: the
+// translated fields sit next to the schema version and the bonus ID.
+// Origine is written by the translation import: it maps each field
+// ("descrizione", "requisiti.0", "faq.1.risposta") to the i18n.Fingerprint
+// of the Italian text it was translated from.
+type TranslationFile struct {
+	Schema int    `json:"schema"`
+	Bonus  string `json:"bonus"`
+	models.BonusTrad
+	Origine map[string]string `json:"origine,omitempty"`
+}
+
+// TranslationPath returns the file of the lang translation of a bonus.
+func TranslationPath(root, lang, id string) string {
+	return filepath.Join(root, translationsDir, lang, id+".yaml")
+}
+
+// ReadTranslation reads the lang translation of a bonus. The error
+// satisfies os.IsNotExist when the bonus is not translated.
+func ReadTranslation(root, lang, id string) (TranslationFile, error) {
+	path := TranslationPath(root, lang, id)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return TranslationFile{}, err
+	}
+	var t TranslationFile
+	if err := decode(path, data, &t); err != nil {
+		return TranslationFile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// translationYAML fixes the key order of written translation files.
+type translationYAML struct {
+	Schema          int               `yaml:"schema"`
+	Bonus           string            `yaml:"bonus"`
+	Nome            string            `yaml:"nome,omitempty"`
+	Descrizione     string            `yaml:"descrizione"`
+	Importo         string            `yaml:"importo,omitempty"`
+	Scadenza        string            `yaml:"scadenza,omitempty"`
+	Requisiti       []string          `yaml:"requisiti,omitempty"`
+	ComeRichiederlo []string          `yaml:"come_richiederlo,omitempty"`
+	Documenti       []string          `yaml:"documenti,omitempty"`
+	FAQ             []faqYAML         `yaml:"faq,omitempty"`
+	Motivi          map[string]string `yaml:"motivi,omitempty"`
+	Origine         map[string]string `yaml:"origine,omitempty"`
+}
+
+type faqYAML struct {
+	Domanda  string `yaml:"domanda"`
+	Risposta string `yaml:"risposta"`
+}
+
+// WriteTranslation writes the lang translation of a bonus, headed by a
+// comment with the Italian title. The catalog watch picks it up like any
+// other edit.
+func WriteTranslation(root, lang, title string, t TranslationFile) error {
+	out := translationYAML{
+		Schema: t.Schema, Bonus: t.Bonus,
+		Nome: t.Nome, Descrizione: t.Descrizione, Importo: t.Importo, Scadenza: t.Scadenza,
+		Requisiti: t.Requisiti, ComeRichiederlo: t.ComeRichiederlo, Documenti: t.Documenti,
+		Motivi: t.Motivi, Origine: t.Origine,
+	}
+	for _, q := range t.FAQ {
+		out.FAQ = append(out.FAQ, faqYAML{q.Domanda, q.Risposta})
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s — %s\n", title, i18n.Names[lang])
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	enc.Close()
+	path := TranslationPath(root, lang, t.Bonus)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// translationLangs returns the language subdirectories of traduzioni/.
+func translationLangs(root string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, translationsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			out = append(out, e.Name())
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// readTranslations validates the translation files under root and attaches
+// them to the bonuses of snap. It runs after the bonus files, so a
+// translation can be checked against the Italian entry it translates.
+func readTranslations(root string, snap *Snapshot, hash io.Writer) []error {
+	langs, err := translationLangs(root)
+	if err != nil {
+		return []error{fmt.Errorf("%s: %w", translationsDir, err)}
+	}
+	byID := make(map[string]*models.Bonus)
+	for _, list := range [][]models.Bonus{snap.National, snap.Regional, snap.Local} {
+		for i := range list {
+			byID[list[i].ID] = &list[i]
+		}
+	}
+
+	var errs []error
+	for _, lang := range langs {
+		sub := filepath.Join(translationsDir, lang)
+		if !i18n.Supported(lang) || lang == i18n.Default {
+			errs = append(errs, fmt.Errorf("%s: unsupported language %q", sub, lang))
+			continue
+		}
+		paths, err := listFiles(filepath.Join(root, sub))
+		if err != nil {
+			return append(errs, fmt.Errorf("%s: %w", sub, err))
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return append(errs, fmt.Errorf("%s: %w", sub, err))
+			}
+			hash.Write([]byte(path))
+			hash.Write(data)
+			snap.Files++
+
+			rel, _ := filepath.Rel(root, path)
+			var t TranslationFile
+			if err := decode(path, data, &t); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", rel, err))
+				continue
+			}
+			b := byID[t.Bonus]
+			if msgs := validateTranslation(path, t, b); len(msgs) > 0 {
+				for _, msg := range msgs {
+					errs = append(errs, fmt.Errorf("%s: %s", rel, msg))
+				}
+				continue
+			}
+			if b.Traduzioni == nil {
+				b.Traduzioni = make(map[string]models.BonusTrad)
+			}
+			b.Traduzioni[lang] = t.BonusTrad
+		}
+	}
+	return errs
+}
+
+// validateTranslation checks a translation against the bonus it translates
+// (nil if the ID is unknown). FAQ are translated as a whole, in the order
+// of the Italian entries, so the counts must match.
+func validateTranslation(path string, t TranslationFile, b *models.Bonus) []string {
+	if t.Schema != SchemaVersion {
+		return []string{fmt.Sprintf("unsupported schema %d (expected %d)", t.Schema, SchemaVersion)}
+	}
+	var msgs []string
+	stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	switch {
+	case t.Bonus == "":
+		msgs = append(msgs, "bonus is required")
+	case t.Bonus != stem:
+		msgs = append(msgs, fmt.Sprintf("bonus %q does not match the file name", t.Bonus))
+	case b == nil:
+		msgs = append(msgs, fmt.Sprintf("unknown bonus %q", t.Bonus))
+	}
+	if strings.TrimSpace(t.Descrizione) == "" {
+		msgs = append(msgs, "descrizione is required")
+	}
+	for i, q := range t.FAQ {
+		if strings.TrimSpace(q.Domanda) == "" || strings.TrimSpace(q.Risposta) == "" {
+			msgs = append(msgs, fmt.Sprintf("faq[%d]: domanda and risposta are required", i))
+		}
+	}
+	if b != nil && len(t.FAQ) > 0 && len(t.FAQ) != len(b.FAQ) {
+		msgs = append(msgs, fmt.Sprintf("faq has %d entries, the Italian text has %d", len(t.FAQ), len(b.FAQ)))
+	}
+	if b != nil {
+		related := make(map[string]bool)
+		for _, r := range append(append([]models.Relazione{}, b.Incompatibili...), b.Cumulabili...) {
+			related[r.Bonus] = true
+		}
+		for id := range t.Motivi {
+			if !related[id] {
+				msgs = append(msgs, fmt.Sprintf("motivi: %q is not incompatibile or cumulabile with this bonus", id))
+			}
+		}
+	}
+	return msgs
+}
+
+// Coverage is the translation status of the catalog in one language.
+type Coverage struct {
+	Lingua   string `json:"lingua"`
+	Totale   int    `json:"totale"`
+	Tradotti int    `json:"tradotti"`
+	// Mancanti lists the bonuses with no translation at all.
+	Mancanti []string `json:"mancanti"`
+	// Parziali maps translated bonuses to the fields still in Italian.
+	Parziali map[string][]string `json:"parziali"`
+}
+
+// TranslationCoverage reports, for every supported language but Italian,
+// which bonuses of the snapshot are untranslated or partly translated.
+func (s *Snapshot) TranslationCoverage() []Coverage {
+	all := s.Bonuses()
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+	var out []Coverage
+	for _, lang := range i18n.Languages {
+		if lang == i18n.Default {
+			continue
+		}
+		c := Coverage{Lingua: lang, Totale: len(all), Mancanti: []string{}, Parziali: map[string][]string{}}
+		for _, b := range all {
+			t, ok := b.Traduzioni[lang]
+			if !ok {
+				c.Mancanti = append(c.Mancanti, b.ID)
+				continue
+			}
+			c.Tradotti++
+			if missing := missingFields(b, t); len(missing) > 0 {
+				c.Parziali[b.ID] = missing
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// missingFields lists the list fields the Italian entry has and the
+// translation leaves out. Name, amount and deadline are often fine in
+// Italian and are not reported.
+func missingFields(b models.Bonus, t models.BonusTrad) []string {
+	var out []string
+	for _, f := range []struct {
+		name      string
+		it, trans int
+	}{
+		{"requisiti", len(b.Requisiti), len(t.Requisiti)},
+		{"come_richiederlo", len(b.ComeRichiederlo), len(t.ComeRichiederlo)},
+		{"documenti", len(b.Documenti), len(t.Documenti)},
+		{"faq", len(b.FAQ), len(t.FAQ)},
+	} {
+		if f.it > 0 && f.trans == 0 {
+			out = append(out, f.name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+// Package clock is the time source of evaluations (matching, validity,
+// confidence scoring). It returns the system time unless a fixed instant
+// is set, so tests can move through deadlines and requests can be
+// evaluated as of another date.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock returns the current instant.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Fixed is a clock stopped at an instant.
+type Fixed time.Time
+
+func (f Fixed) Now() time.Time { return time.Time(f) }
+
+var (
+	mu      sync.RWMutex
+	current Clock = systemClock{}
+)
+
+// Now returns the current instant of the clock in use.
+func Now() time.Time {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current.Now()
+}
+
+// Set replaces the clock in use and returns a function restoring the
+// previous one (for tests: defer clock.Set(clock.Fixed(t))()).
+func Set(c Clock) func() {
+	mu.Lock()
+	prev := current
+	current = c
+	mu.Unlock()
+	return func() {
+		mu.Lock()
+		current = prev
+		mu.Unlock()
+	}
+}
+
+// ParseDate parses an evaluation date in the YYYY-MM-DD form, at noon UTC
+// so the whole day is before any deadline set at its end.
+func ParseDate(s string) (time.Time, error) {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return d.Add(12 * time.Hour), nil
+}
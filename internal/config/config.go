@@ -26,6 +26,13 @@ type Config struct {
 	// Analytics
 	GTMID string
 
+	// Bonus catalog
+	CatalogDir           string
+	CatalogWatchInterval time.Duration
+
+	// UI translations
+	I18nDir string
+
 	// Scraper
 	ScraperEnabled  bool
 	ScraperInterval time.Duration
@@ -98,6 +105,11 @@ func Load() {
 
 		GTMID: envOr("GTM_ID", ""),
 
+		CatalogDir:           envOr("CATALOG_DIR", "data/catalog"),
+		CatalogWatchInterval: envDuration("CATALOG_WATCH_INTERVAL", 30*time.Second),
+
+		I18nDir: envOr("I18N_DIR", "data/i18n"),
+
 		ScraperEnabled:  envBool("SCRAPER_ENABLED", true),
 		ScraperInterval: envDuration("SCRAPER_INTERVAL", 24*time.Hour),
 
@@ -0,0 +1,399 @@
+// Package deadline turns bonus deadlines into dates. It parses Italian
+// dates, derives a structured models.Termine from the Scadenza prose of
+// bonuses that don't declare one, and computes the application window of
+// a Termine for a profile at an evaluation date.
+package deadline
+
+import (
+	"bonusperme/internal/models"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var mesi = map[string]time.Month{
+	"gennaio": time.January, "febbraio": time.February, "marzo": time.March,
+	"aprile": time.April, "maggio": time.May, "giugno": time.June,
+	"luglio": time.July, "agosto": time.August, "settembre": time.September,
+	"ottobre": time.October, "novembre": time.November, "dicembre": time.December,
+}
+
+const mese = `(gennaio|febbraio|marzo|aprile|maggio|giugno|luglio|agosto|settembre|ottobre|novembre|dicembre)`
+
+var (
+	reData       = regexp.MustCompile(`(?i)\b(\d{1,2})(?:°|º)?\s+` + mese + `\s+(\d{4})\b`)
+	reDataNum    = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})/(\d{4})\b`)
+	reDataISO    = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	reGiornoMese = regexp.MustCompile(`(?i)\b(\d{1,2})\s+` + mese + `\b`)
+	reAnno       = regexp.MustCompile(`\b(20\d{2})\b`)
+	reMesi       = regexp.MustCompile(`(?i)\b` + mese + `\s*[-–]\s*` + mese + `\b`)
+	reGiorniDa   = regexp.MustCompile(`(?i)entro (\d+) giorni dall?a? ?nascita`)
+	reOra        = regexp.MustCompile(`\b([01]?\d|2[0-3])[:.]([0-5]\d)\b`)
+	reMeseGiorno = regexp.MustCompile(`^(\d{2})-(\d{2})$`)
+)
+
+// ParseData returns the first date found in an Italian text: "15 febbraio
+// 2026", "15/02/2026" or "2026-02-15".
+func ParseData(s string) (time.Time, bool) {
+	if t, _, ok := primaData(s); ok {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// primaData returns the first date in s and the text following it.
+func primaData(s string) (time.Time, string, bool) {
+	best, end := -1, 0
+	var found time.Time
+	try := func(re *regexp.Regexp, conv func(m []string) (time.Time, bool)) {
+		for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+			m := make([]string, len(loc)/2)
+			for i := range m {
+				m[i] = s[loc[2*i]:loc[2*i+1]]
+			}
+			if t, ok := conv(m); ok {
+				if best < 0 || loc[0] < best {
+					best, end, found = loc[0], loc[1], t
+				}
+				return
+			}
+		}
+	}
+	try(reData, func(m []string) (time.Time, bool) {
+		return data(atoi(m[3]), mesi[strings.ToLower(m[2])], atoi(m[1]))
+	})
+	try(reDataNum, func(m []string) (time.Time, bool) {
+		return data(atoi(m[3]), time.Month(atoi(m[2])), atoi(m[1]))
+	})
+	try(reDataISO, func(m []string) (time.Time, bool) {
+		return data(atoi(m[1]), time.Month(atoi(m[2])), atoi(m[3]))
+	})
+	if best < 0 {
+		return time.Time{}, "", false
+	}
+	return found, s[end:], true
+}
+
+// data builds a date, rejecting days that don't exist (31 febbraio).
+func data(y int, m time.Month, d int) (time.Time, bool) {
+	t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if y == 0 || t.Month() != m || t.Day() != d {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// Deriva builds the Termine described by a Scadenza text. Texts that name
+// no recognisable deadline are "permanente", as they have always been
+// treated.
+func Deriva(scadenza string) models.Termine {
+	lower := strings.ToLower(strings.TrimSpace(scadenza))
+	switch {
+	case containsAny(lower, "non prorogat", "non rinnovat", "fondi esauriti", "scadut"):
+		t := models.Termine{Tipo: models.TermineChiuso}
+		if d, ok := ParseData(lower); ok {
+			t.Chiusura = d.Format("2006-01-02")
+		}
+		return t
+	case reGiorniDa.MatchString(lower):
+		m := reGiorniDa.FindStringSubmatch(lower)
+		return models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: atoi(m[1])}
+	case strings.Contains(lower, "18 anni"):
+		if m := reGiornoMese.FindStringSubmatch(lower); m != nil {
+			t := models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta,
+				Chiusura: fmt.Sprintf("%02d-%02d", mesi[m[2]], atoi(m[1]))}
+			if strings.Contains(lower, "anno successivo") {
+				t.AnniDopo = 1
+			}
+			return t
+		}
+	case strings.Contains(lower, "click day"):
+		if d, resto, ok := primaData(lower); ok {
+			t := models.Termine{Tipo: models.TermineClickDay, Apertura: d.Format("2006-01-02")}
+			if m := reOra.FindStringSubmatch(resto); m != nil {
+				t.Ora = fmt.Sprintf("%02d:%s", atoi(m[1]), m[2])
+			}
+			return t
+		}
+	case containsAny(lower, "in vigore", "permanente", "erogazione automatica", "per arretrati"):
+		return models.Termine{Tipo: models.TerminePermanente}
+	case strings.Contains(lower, "esaurimento fondi"):
+		return models.Termine{Tipo: models.TermineEsaurimentoFondi}
+	case strings.Contains(lower, "bando"):
+		if m := reMesi.FindStringSubmatch(lower); m != nil {
+			da, a := mesi[m[1]], mesi[m[2]]
+			ultimo := time.Date(2001, a+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			return models.Termine{Tipo: models.TermineAnnuale,
+				Apertura: fmt.Sprintf("%02d-01", da), Chiusura: fmt.Sprintf("%02d-%02d", a, ultimo)}
+		}
+		return models.Termine{Tipo: models.TermineBandoAnnuale}
+	}
+
+	if d, resto, ok := primaData(lower); ok {
+		if d2, _, ok := primaData(resto); ok && d2.After(d) {
+			return models.Termine{Tipo: models.TermineFinestra,
+				Apertura: d.Format("2006-01-02"), Chiusura: d2.Format("2006-01-02")}
+		}
+		return models.Termine{Tipo: models.TermineDataFissa, Chiusura: d.Format("2006-01-02")}
+	}
+	// A bare year ("2024") closes at its end
+	if m := reAnno.FindStringSubmatch(lower); m != nil {
+		return models.Termine{Tipo: models.TermineDataFissa, Chiusura: m[1] + "-12-31"}
+	}
+	return models.Termine{Tipo: models.TerminePermanente}
+}
+
+// DelBonus returns the Termine declared by the bonus, or the one derived
+// from its Scadenza.
+func DelBonus(b models.Bonus) models.Termine {
+	if b.Termine != nil {
+		return *b.Termine
+	}
+	return Deriva(b.Scadenza)
+}
+
+// Calcola returns the application window of a Termine for the profile as
+// of asOf. Dates are compared by day: the closing day is still open.
+func Calcola(t models.Termine, p models.UserProfile, asOf time.Time) models.Finestra {
+	oggi := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	switch t.Tipo {
+	case models.TermineChiuso:
+		return models.Finestra{Stato: models.FinestraChiusa, Chiusura: t.Chiusura}
+	case models.TermineDataFissa, models.TermineFinestra, models.TermineClickDay:
+		ap, _ := giorno(t.Apertura)
+		ch, _ := giorno(t.Chiusura)
+		f := finestra(oggi, ap, ch)
+		f.Ora = t.Ora
+		return f
+	case models.TermineAnnuale:
+		return annuale(t, oggi)
+	case models.TermineRelativo:
+		return relativo(t, p, oggi)
+	}
+	return models.Finestra{Stato: models.FinestraAperta}
+}
+
+// finestra states whether oggi falls before, inside or after [ap, ch];
+// zero bounds are open.
+func finestra(oggi, ap, ch time.Time) models.Finestra {
+	f := models.Finestra{Stato: models.FinestraAperta}
+	if !ap.IsZero() {
+		f.Apertura = ap.Format("2006-01-02")
+	}
+	if !ch.IsZero() {
+		f.Chiusura = ch.Format("2006-01-02")
+	}
+	switch {
+	case !ch.IsZero() && oggi.After(ch):
+		f.Stato = models.FinestraChiusa
+	case !ap.IsZero() && oggi.Before(ap):
+		f.Stato = models.FinestraInApertura
+	}
+	return f
+}
+
+// annuale returns the yearly window containing oggi or, when between two
+// windows, the next one. Windows may span the new year (11-01 to 02-28).
+func annuale(t models.Termine, oggi time.Time) models.Finestra {
+	apMG, chMG := t.Apertura, t.Chiusura
+	if apMG == "" {
+		apMG = "01-01"
+	}
+	if chMG == "" {
+		chMG = "12-31"
+	}
+	y := oggi.Year()
+	ap, ch := nelAnno(y, apMG), nelAnno(y, chMG)
+	if ch.Before(ap) {
+		if oggi.After(ch) {
+			ch = nelAnno(y+1, chMG)
+		} else {
+			ap = nelAnno(y-1, apMG)
+		}
+	} else if oggi.After(ch) {
+		ap, ch = nelAnno(y+1, apMG), nelAnno(y+1, chMG)
+	}
+	f := finestra(oggi, ap, ch)
+	f.Ricorrente = true
+	return f
+}
+
+// relativo computes a deadline counted from a profile event.
+func relativo(t models.Termine, p models.UserProfile, oggi time.Time) models.Finestra {
+	var evento time.Time
+	esatto := false
+	switch t.Evento {
+	case models.EventoNascita:
+		d, ok := giorno(p.DataNascitaFiglio)
+		if !ok {
+			return models.Finestra{Stato: models.FinestraDaDefinire,
+				Nota: "finestra.serve_data_nascita"}
+		}
+		evento, esatto = d, true
+	case models.EventoMaggioreEta:
+		if p.Eta < 18 {
+			return models.Finestra{Stato: models.FinestraDaDefinire,
+				Nota: "finestra.serve_eta"}
+		}
+		// Only the year of the 18th birthday can be told from the age
+		evento = time.Date(oggi.Year()-(p.Eta-18), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return models.Finestra{Stato: models.FinestraDaDefinire}
+	}
+
+	var ch time.Time
+	if t.Chiusura != "" {
+		ch = nelAnno(evento.Year()+t.AnniDopo, t.Chiusura)
+	} else {
+		ch = evento.AddDate(0, 0, t.Giorni)
+	}
+	var ap time.Time
+	if esatto {
+		ap = evento
+	}
+	f := finestra(oggi, ap, ch)
+	if !esatto {
+		f.Nota = "finestra.stimata_eta"
+	}
+	return f
+}
+
+// giorno parses an AAAA-MM-GG date; the empty string is not a date.
+func giorno(s string) (time.Time, bool) {
+	t, err := time.Parse("2006-01-02", s)
+	return t, err == nil
+}
+
+// nelAnno returns the day MM-GG of year y; 29 February falls back to the
+// 28th in common years. Days that no year has (13-01, 04-31) are zero.
+func nelAnno(y int, mg string) time.Time {
+	m := reMeseGiorno.FindStringSubmatch(mg)
+	if m == nil {
+		return time.Time{}
+	}
+	mo, d := time.Month(atoi(m[1])), atoi(m[2])
+	if mo < time.January || mo > time.December || d < 1 || d > time.Date(2000, mo+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		return time.Time{}
+	}
+	if ultimo := time.Date(y, mo+1, 0, 0, 0, 0, 0, time.UTC).Day(); d > ultimo {
+		d = ultimo
+	}
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Apertura returns the instant a window opens (at Ora, if set), zero if
+// it has no opening date.
+func Apertura(f models.Finestra) time.Time {
+	t, ok := giorno(f.Apertura)
+	if !ok {
+		return time.Time{}
+	}
+	if m := reOra.FindStringSubmatch(f.Ora); m != nil {
+		t = t.Add(time.Duration(atoi(m[1]))*time.Hour + time.Duration(atoi(m[2]))*time.Minute)
+	}
+	return t
+}
+
+// Chiusura returns the last instant applications are accepted, zero if
+// the window has no closing date.
+func Chiusura(f models.Finestra) time.Time {
+	t, ok := giorno(f.Chiusura)
+	if !ok {
+		return time.Time{}
+	}
+	return t.Add(24*time.Hour - time.Second)
+}
+
+var tipiValidi = map[string]bool{
+	models.TerminePermanente: true, models.TermineEsaurimentoFondi: true,
+	models.TermineBandoAnnuale: true, models.TermineDataFissa: true,
+	models.TermineFinestra: true, models.TermineAnnuale: true,
+	models.TermineClickDay: true, models.TermineRelativo: true,
+	models.TermineChiuso: true,
+}
+
+// Valida checks a catalog Termine and returns one message per problem.
+func Valida(t *models.Termine) []string {
+	if t == nil {
+		return nil
+	}
+	var msgs []string
+	if !tipiValidi[t.Tipo] {
+		return []string{fmt.Sprintf("unknown tipo %q", t.Tipo)}
+	}
+	data := func(field, v string) {
+		if _, ok := giorno(v); v != "" && !ok {
+			msgs = append(msgs, fmt.Sprintf("%s %q is not a date (AAAA-MM-GG)", field, v))
+		}
+	}
+	meseGiorno := func(field, v string) {
+		if v != "" && nelAnno(2001, v).IsZero() {
+			msgs = append(msgs, fmt.Sprintf("%s %q is not a day of the year (MM-GG)", field, v))
+		}
+	}
+
+	switch t.Tipo {
+	case models.TermineDataFissa:
+		if t.Chiusura == "" {
+			msgs = append(msgs, "chiusura is required for data_fissa")
+		}
+		data("apertura", t.Apertura)
+		data("chiusura", t.Chiusura)
+	case models.TermineFinestra:
+		if t.Apertura == "" || t.Chiusura == "" {
+			msgs = append(msgs, "apertura and chiusura are required for finestra")
+		}
+		data("apertura", t.Apertura)
+		data("chiusura", t.Chiusura)
+		if ap, ok := giorno(t.Apertura); ok {
+			if ch, ok := giorno(t.Chiusura); ok && ch.Before(ap) {
+				msgs = append(msgs, "chiusura is before apertura")
+			}
+		}
+	case models.TermineClickDay:
+		if t.Apertura == "" {
+			msgs = append(msgs, "apertura is required for click_day")
+		}
+		data("apertura", t.Apertura)
+		data("chiusura", t.Chiusura)
+	case models.TermineAnnuale:
+		if t.Apertura == "" && t.Chiusura == "" {
+			msgs = append(msgs, "apertura or chiusura is required for annuale")
+		}
+		meseGiorno("apertura", t.Apertura)
+		meseGiorno("chiusura", t.Chiusura)
+	case models.TermineRelativo:
+		if t.Evento != models.EventoNascita && t.Evento != models.EventoMaggioreEta {
+			msgs = append(msgs, fmt.Sprintf("unknown evento %q", t.Evento))
+		}
+		if (t.Giorni > 0) == (t.Chiusura != "") {
+			msgs = append(msgs, "relativo needs either giorni or chiusura")
+		}
+		meseGiorno("chiusura", t.Chiusura)
+	case models.TermineChiuso:
+		data("chiusura", t.Chiusura)
+	}
+	if t.Ora != "" && !reOra.MatchString(t.Ora) {
+		msgs = append(msgs, fmt.Sprintf("ora %q is not HH:MM", t.Ora))
+	}
+	if t.Giorni < 0 || t.AnniDopo < 0 {
+		msgs = append(msgs, "giorni and anni_dopo must not be negative")
+	}
+	return msgs
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
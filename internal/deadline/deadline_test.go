@@ -0,0 +1,244 @@
+package deadline
+
+import (
+	"bonusperme/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t.Add(12 * time.Hour)
+}
+
+func TestParseData(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{"Entro il 15 febbraio 2026", "2026-02-15"},
+		{"dal 1° Marzo 2026", "2026-03-01"},
+		{"entro il 15/02/2026", "2026-02-15"},
+		{"scade 2026-02-15", "2026-02-15"},
+		{"dal 2026-03-01, poi dal 15 febbraio 2026", "2026-03-01"},
+		{"31 febbraio 2026 o 1 marzo 2026", "2026-03-01"},
+		{"29 febbraio 2024", "2024-02-29"},
+		{"29 febbraio 2026", ""},
+		{"nessuna data", ""},
+	} {
+		got, ok := ParseData(tc.in)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("ParseData(%q) = %s, want no date", tc.in, got.Format("2006-01-02"))
+			}
+			continue
+		}
+		if !ok || got.Format("2006-01-02") != tc.want {
+			t.Errorf("ParseData(%q) = %s %v, want %s", tc.in, got.Format("2006-01-02"), ok, tc.want)
+		}
+	}
+}
+
+func TestDeriva(t *testing.T) {
+	for _, tc := range []struct {
+		scadenza string
+		want     models.Termine
+	}{
+		{"Non prorogato dopo il 31 dicembre 2024", models.Termine{Tipo: models.TermineChiuso, Chiusura: "2024-12-31"}},
+		{"Fondi esauriti", models.Termine{Tipo: models.TermineChiuso}},
+		{"Entro 120 giorni dalla nascita", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 120}},
+		{"Entro il 30 giugno dell'anno successivo al compimento dei 18 anni",
+			models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta, Chiusura: "06-30", AnniDopo: 1}},
+		{"Click day 15 marzo 2026 ore 9.30", models.Termine{Tipo: models.TermineClickDay, Apertura: "2026-03-15", Ora: "09:30"}},
+		{"Click day 15 marzo 2026", models.Termine{Tipo: models.TermineClickDay, Apertura: "2026-03-15"}},
+		{"Sempre in vigore", models.Termine{Tipo: models.TerminePermanente}},
+		{"Fino a esaurimento fondi", models.Termine{Tipo: models.TermineEsaurimentoFondi}},
+		{"Bando regionale febbraio-aprile", models.Termine{Tipo: models.TermineAnnuale, Apertura: "02-01", Chiusura: "04-30"}},
+		{"Bando annuale del comune", models.Termine{Tipo: models.TermineBandoAnnuale}},
+		{"Dal 1 marzo 2026 al 30 aprile 2026", models.Termine{Tipo: models.TermineFinestra, Apertura: "2026-03-01", Chiusura: "2026-04-30"}},
+		{"Entro il 15/02/2026", models.Termine{Tipo: models.TermineDataFissa, Chiusura: "2026-02-15"}},
+		{"Spese sostenute nel 2025", models.Termine{Tipo: models.TermineDataFissa, Chiusura: "2025-12-31"}},
+		{"Da definire", models.Termine{Tipo: models.TerminePermanente}},
+		{"", models.Termine{Tipo: models.TerminePermanente}},
+	} {
+		if got := Deriva(tc.scadenza); got != tc.want {
+			t.Errorf("Deriva(%q) = %+v, want %+v", tc.scadenza, got, tc.want)
+		}
+	}
+}
+
+func TestDelBonus(t *testing.T) {
+	dichiarato := models.Termine{Tipo: models.TermineAnnuale, Apertura: "09-01"}
+	if got := DelBonus(models.Bonus{Termine: &dichiarato, Scadenza: "Sempre in vigore"}); got != dichiarato {
+		t.Errorf("declared termine ignored: %+v", got)
+	}
+	if got := DelBonus(models.Bonus{Scadenza: "Fino a esaurimento fondi"}); got.Tipo != models.TermineEsaurimentoFondi {
+		t.Errorf("termine not derived from scadenza: %+v", got)
+	}
+}
+
+func TestCalcola(t *testing.T) {
+	nascita := func(d string) models.UserProfile { return models.UserProfile{DataNascitaFiglio: d} }
+	for _, tc := range []struct {
+		nome    string
+		termine models.Termine
+		profilo models.UserProfile
+		oggi    string
+		want    models.Finestra
+	}{
+		{"permanente", models.Termine{Tipo: models.TerminePermanente}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta}},
+		{"esaurimento fondi", models.Termine{Tipo: models.TermineEsaurimentoFondi}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta}},
+		{"chiuso", models.Termine{Tipo: models.TermineChiuso, Chiusura: "2024-12-31"}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraChiusa, Chiusura: "2024-12-31"}},
+
+		// Fixed dates: the closing day is still open
+		{"data fissa, ultimo giorno", models.Termine{Tipo: models.TermineDataFissa, Chiusura: "2026-03-01"}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta, Chiusura: "2026-03-01"}},
+		{"data fissa, passata", models.Termine{Tipo: models.TermineDataFissa, Chiusura: "2026-02-28"}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraChiusa, Chiusura: "2026-02-28"}},
+		{"finestra futura", models.Termine{Tipo: models.TermineFinestra, Apertura: "2026-04-01", Chiusura: "2026-04-30"}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraInApertura, Apertura: "2026-04-01", Chiusura: "2026-04-30"}},
+		{"finestra, primo giorno", models.Termine{Tipo: models.TermineFinestra, Apertura: "2026-04-01", Chiusura: "2026-04-30"}, models.UserProfile{}, "2026-04-01",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2026-04-01", Chiusura: "2026-04-30"}},
+
+		// Click days
+		{"click day, prima", models.Termine{Tipo: models.TermineClickDay, Apertura: "2026-03-15", Ora: "09:30"}, models.UserProfile{}, "2026-03-14",
+			models.Finestra{Stato: models.FinestraInApertura, Apertura: "2026-03-15", Ora: "09:30"}},
+		{"click day, il giorno", models.Termine{Tipo: models.TermineClickDay, Apertura: "2026-03-15", Ora: "09:30"}, models.UserProfile{}, "2026-03-15",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2026-03-15", Ora: "09:30"}},
+		{"click day, dopo la chiusura", models.Termine{Tipo: models.TermineClickDay, Apertura: "2026-03-15", Chiusura: "2026-03-20", Ora: "09:30"}, models.UserProfile{}, "2026-03-21",
+			models.Finestra{Stato: models.FinestraChiusa, Apertura: "2026-03-15", Chiusura: "2026-03-20", Ora: "09:30"}},
+
+		// Yearly windows
+		{"annuale, dentro", models.Termine{Tipo: models.TermineAnnuale, Apertura: "03-01", Chiusura: "05-31"}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2026-03-01", Chiusura: "2026-05-31", Ricorrente: true}},
+		{"annuale, prima", models.Termine{Tipo: models.TermineAnnuale, Apertura: "03-01", Chiusura: "05-31"}, models.UserProfile{}, "2026-01-10",
+			models.Finestra{Stato: models.FinestraInApertura, Apertura: "2026-03-01", Chiusura: "2026-05-31", Ricorrente: true}},
+		{"annuale, dopo: anno seguente", models.Termine{Tipo: models.TermineAnnuale, Apertura: "03-01", Chiusura: "05-31"}, models.UserProfile{}, "2026-06-01",
+			models.Finestra{Stato: models.FinestraInApertura, Apertura: "2027-03-01", Chiusura: "2027-05-31", Ricorrente: true}},
+		{"annuale, solo chiusura", models.Termine{Tipo: models.TermineAnnuale, Chiusura: "09-30"}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2026-01-01", Chiusura: "2026-09-30", Ricorrente: true}},
+
+		// Windows across the new year
+		{"a cavallo, gennaio", models.Termine{Tipo: models.TermineAnnuale, Apertura: "11-01", Chiusura: "02-28"}, models.UserProfile{}, "2026-01-15",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2025-11-01", Chiusura: "2026-02-28", Ricorrente: true}},
+		{"a cavallo, tra due finestre", models.Termine{Tipo: models.TermineAnnuale, Apertura: "11-01", Chiusura: "02-28"}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraInApertura, Apertura: "2026-11-01", Chiusura: "2027-02-28", Ricorrente: true}},
+		{"a cavallo, dicembre", models.Termine{Tipo: models.TermineAnnuale, Apertura: "11-01", Chiusura: "02-28"}, models.UserProfile{}, "2026-12-31",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2026-11-01", Chiusura: "2027-02-28", Ricorrente: true}},
+		{"29 febbraio, anno comune", models.Termine{Tipo: models.TermineAnnuale, Apertura: "11-01", Chiusura: "02-29"}, models.UserProfile{}, "2027-01-10",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2026-11-01", Chiusura: "2027-02-28", Ricorrente: true}},
+		{"29 febbraio, anno bisestile", models.Termine{Tipo: models.TermineAnnuale, Apertura: "11-01", Chiusura: "02-29"}, models.UserProfile{}, "2028-01-10",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2027-11-01", Chiusura: "2028-02-29", Ricorrente: true}},
+
+		// Deadlines counted from the birth of a child
+		{"nascita, dentro", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 90}, nascita("2026-01-10"), "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2026-01-10", Chiusura: "2026-04-10"}},
+		{"nascita il 29 febbraio", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 365}, nascita("2024-02-29"), "2025-02-28",
+			models.Finestra{Stato: models.FinestraAperta, Apertura: "2024-02-29", Chiusura: "2025-02-28"}},
+		{"nascita il 29 febbraio, scaduta", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 365}, nascita("2024-02-29"), "2025-03-01",
+			models.Finestra{Stato: models.FinestraChiusa, Apertura: "2024-02-29", Chiusura: "2025-02-28"}},
+		{"nascita futura", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 90}, nascita("2026-05-01"), "2026-03-01",
+			models.Finestra{Stato: models.FinestraInApertura, Apertura: "2026-05-01", Chiusura: "2026-07-30"}},
+
+		// Deadlines counted from the 18th birthday, known only by year
+		{"maggiore eta", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta, Chiusura: "06-30", AnniDopo: 1}, models.UserProfile{Eta: 18}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta, Chiusura: "2027-06-30", Nota: "finestra.stimata_eta"}},
+		{"maggiore eta, scaduta", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta, Chiusura: "06-30", AnniDopo: 1}, models.UserProfile{Eta: 20}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraChiusa, Chiusura: "2025-06-30", Nota: "finestra.stimata_eta"}},
+		{"maggiore eta, 29 febbraio", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta, Chiusura: "02-29", AnniDopo: 1}, models.UserProfile{Eta: 18}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraAperta, Chiusura: "2027-02-28", Nota: "finestra.stimata_eta"}},
+
+		// Profiles missing the event
+		{"senza data di nascita", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 90}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraDaDefinire, Nota: "finestra.serve_data_nascita"}},
+		{"data di nascita non valida", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 90}, nascita("10/01/2026"), "2026-03-01",
+			models.Finestra{Stato: models.FinestraDaDefinire, Nota: "finestra.serve_data_nascita"}},
+		{"minorenne", models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta, Chiusura: "06-30"}, models.UserProfile{Eta: 17}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraDaDefinire, Nota: "finestra.serve_eta"}},
+		{"evento sconosciuto", models.Termine{Tipo: models.TermineRelativo, Evento: "laurea", Giorni: 30}, models.UserProfile{}, "2026-03-01",
+			models.Finestra{Stato: models.FinestraDaDefinire}},
+	} {
+		if got := Calcola(tc.termine, tc.profilo, day(tc.oggi)); got != tc.want {
+			t.Errorf("%s: Calcola = %+v, want %+v", tc.nome, got, tc.want)
+		}
+	}
+}
+
+func TestAperturaChiusura(t *testing.T) {
+	f := models.Finestra{Apertura: "2026-03-15", Chiusura: "2026-03-20", Ora: "09:30"}
+	if got := Apertura(f); !got.Equal(time.Date(2026, 3, 15, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("Apertura = %s", got)
+	}
+	if got := Chiusura(f); !got.Equal(time.Date(2026, 3, 20, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("Chiusura = %s", got)
+	}
+	if !Apertura(models.Finestra{}).IsZero() || !Chiusura(models.Finestra{}).IsZero() {
+		t.Error("a window without dates has zero bounds")
+	}
+}
+
+func TestValida(t *testing.T) {
+	for _, tc := range []struct {
+		termine *models.Termine
+		errori  []string // substrings of the expected messages, in order
+	}{
+		{nil, nil},
+		{&models.Termine{Tipo: models.TerminePermanente}, nil},
+		{&models.Termine{Tipo: models.TermineDataFissa, Chiusura: "2026-06-30"}, nil},
+		{&models.Termine{Tipo: models.TermineFinestra, Apertura: "2026-03-01", Chiusura: "2026-04-30"}, nil},
+		{&models.Termine{Tipo: models.TermineClickDay, Apertura: "2026-03-15", Ora: "09:30"}, nil},
+		{&models.Termine{Tipo: models.TermineAnnuale, Apertura: "11-01", Chiusura: "02-29"}, nil},
+		{&models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 90}, nil},
+		{&models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta, Chiusura: "06-30", AnniDopo: 1}, nil},
+
+		{&models.Termine{Tipo: "mensile"}, []string{`unknown tipo "mensile"`}},
+		{&models.Termine{Tipo: models.TermineDataFissa}, []string{"chiusura is required"}},
+		{&models.Termine{Tipo: models.TermineDataFissa, Chiusura: "30/06/2026"}, []string{`chiusura "30/06/2026" is not a date`}},
+		{&models.Termine{Tipo: models.TermineFinestra, Apertura: "2026-03-01"}, []string{"apertura and chiusura are required"}},
+		{&models.Termine{Tipo: models.TermineFinestra, Apertura: "2026-04-30", Chiusura: "2026-03-01"}, []string{"chiusura is before apertura"}},
+		{&models.Termine{Tipo: models.TermineClickDay}, []string{"apertura is required"}},
+		{&models.Termine{Tipo: models.TermineAnnuale}, []string{"apertura or chiusura is required"}},
+		{&models.Termine{Tipo: models.TermineAnnuale, Apertura: "13-01", Chiusura: "04-31"},
+			[]string{`apertura "13-01" is not a day of the year`, `chiusura "04-31" is not a day of the year`}},
+		{&models.Termine{Tipo: models.TermineRelativo, Evento: "laurea", Giorni: 30}, []string{`unknown evento "laurea"`}},
+		{&models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita}, []string{"either giorni or chiusura"}},
+		{&models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoNascita, Giorni: 90, Chiusura: "06-30"}, []string{"either giorni or chiusura"}},
+		{&models.Termine{Tipo: models.TermineClickDay, Apertura: "2026-03-15", Ora: "25:00"}, []string{`ora "25:00" is not HH:MM`}},
+		{&models.Termine{Tipo: models.TermineRelativo, Evento: models.EventoMaggioreEta, Chiusura: "06-30", AnniDopo: -1}, []string{"must not be negative"}},
+	} {
+		got := Valida(tc.termine)
+		ok := len(got) == len(tc.errori)
+		for i := 0; ok && i < len(got); i++ {
+			ok = strings.Contains(got[i], tc.errori[i])
+		}
+		if !ok {
+			t.Errorf("Valida(%+v) = %q, want %q", tc.termine, got, tc.errori)
+		}
+	}
+}
+
+// Every catalog-style termine Deriva returns is valid.
+func TestDerivaValida(t *testing.T) {
+	for _, s := range []string{
+		"Entro 120 giorni dalla nascita",
+		"Entro il 30 giugno dell'anno successivo al compimento dei 18 anni",
+		"Click day 15 marzo 2026 ore 9.30",
+		"Bando regionale febbraio-aprile",
+		"Dal 1 marzo 2026 al 30 aprile 2026",
+		"Entro il 15/02/2026",
+		"Non prorogato dopo il 31 dicembre 2024",
+	} {
+		tm := Deriva(s)
+		if msgs := Valida(&tm); !reflect.DeepEqual(msgs, []string(nil)) {
+			t.Errorf("Deriva(%q) = %+v, invalid: %v", s, tm, msgs)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Package dsu reads the values of an INPS ISEE attestation (DSU) from
+// the text layer of its PDF. Only figures are extracted: names, codici
+// fiscali and addresses printed on the attestation are never returned.
+package dsu
+
+import (
+	"bonusperme/internal/i18n"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Avviso tipi.
+const (
+	AvvisoScansionato  = "scansionato"
+	AvvisoDifformita   = "omissioni_difformita"
+	AvvisoScaduta      = "scaduta"
+	AvvisoIncoerente   = "valori_incoerenti"
+	AvvisoNonTrovato   = "isee_non_trovato"
+	AvvisoSoloGenerico = "tipo_isee_non_indicato"
+)
+
+// Avviso is a warning about the uploaded attestation, shown to the user.
+// Its text is the i18n message "dsu.<tipo>", in Italian unless Localized.
+type Avviso struct {
+	Tipo      string `json:"tipo"`
+	Messaggio string `json:"messaggio"`
+}
+
+func avviso(tipo string) Avviso {
+	return Avviso{Tipo: tipo}.Localized(i18n.Default)
+}
+
+// Localized returns the avviso with its text in lang.
+func (a Avviso) Localized(lang string) Avviso {
+	a.Messaggio = i18n.M("dsu." + a.Tipo).Text(lang)
+	return a
+}
+
+// Attestazione are the values read from an ISEE attestation. Zero values
+// mean the field was not found.
+type Attestazione struct {
+	ISEEOrdinario    float64 `json:"isee_ordinario,omitempty"`
+	ISEEMinorenni    float64 `json:"isee_minorenni,omitempty"`
+	ISEECorrente     float64 `json:"isee_corrente,omitempty"`
+	ISE              float64 `json:"ise,omitempty"`
+	ISR              float64 `json:"isr,omitempty"`
+	ISP              float64 `json:"isp,omitempty"`
+	ScalaEquivalenza float64 `json:"scala_equivalenza,omitempty"`
+	Componenti       int     `json:"componenti,omitempty"`
+	Protocollo       string  `json:"protocollo,omitempty"`
+	Scadenza         string  `json:"scadenza,omitempty"` // YYYY-MM-DD
+	Difformita       bool    `json:"omissioni_difformita"`
+
+	// generico is an ISEE value whose label doesn't say which kind it is.
+	generico float64
+}
+
+// importo matches Italian amounts: 18.432,00 or 18432,00.
+const importo = `(\d{1,3}(?:\.\d{3})+,\d{2}|\d+,\d{2})`
+
+var (
+	reSigle = strings.NewReplacer("I.S.E.E.", "ISEE", "I.S.E.", "ISE", "I.S.R.", "ISR", "I.S.P.", "ISP", "D.S.U.", "DSU")
+
+	reISEE  = regexp.MustCompile(`(?i)(?:\bISEE\b|indicatore della situazione economica equivalente)([^0-9]{0,80}?)` + importo)
+	reISE   = regexp.MustCompile(`(?i)(?:\bISE\b|indicatore della situazione economica)([^0-9]{0,80}?)` + importo)
+	reISR   = regexp.MustCompile(`(?i)(?:\bISR\b|indicatore della situazione reddituale)([^0-9]{0,80}?)` + importo)
+	reISP   = regexp.MustCompile(`(?i)(?:\bISP\b|indicatore della situazione patrimoniale)([^0-9]{0,80}?)` + importo)
+	reScala = regexp.MustCompile(`(?i)scala di equivalenza([^0-9]{0,60}?)(\d{1,2},\d{1,2})\b`)
+	reComp  = regexp.MustCompile(`(?i)(?:numero (?:dei )?componenti|componenti del nucleo(?: familiare)?)([^0-9]{0,40}?)(\d{1,2})\b`)
+
+	reProtocollo = regexp.MustCompile(`(?i)\b(INPS-ISEE-(\d{4})-[A-Z0-9]+(?:-\d{2})?)`)
+	reScadenza   = regexp.MustCompile(`(?i)(?:valid[ao] fino al|data di scadenza|scadenza|scade il)[^0-9]{0,30}?(\d{1,2})[/.\-](\d{1,2})[/.\-](\d{4})`)
+
+	reNoDifformita = regexp.MustCompile(`(?i)(?:non (?:sono )?(?:state )?(?:rilevate|presenti|riscontrate)|non risultano|assenza di|senza) (?:omissioni|difformit)`)
+	reDifformita   = regexp.MustCompile(`(?i)(?:rilevat[ea]|present[ei]|riscontrat[ea]|con) (?:le seguenti )?(?:omissioni|difformit)`)
+
+	// Labels of the other indicators: a gap between label and number that
+	// contains one of these means the number belongs to the next label.
+	escludiISEE  = regexp.MustCompile(`(?i)\bIS[ERP]\b|scala|reddituale|patrimoniale`)
+	escludiISE   = regexp.MustCompile(`(?i)\bIS(?:EE|R|P)\b|equivalente|scala|reddituale|patrimoniale`)
+	escludiISR   = regexp.MustCompile(`(?i)\bIS(?:EE|E|P)\b|equivalente|scala|patrimoniale`)
+	escludiISP   = regexp.MustCompile(`(?i)\bIS(?:EE|E|R)\b|equivalente|scala|reddituale`)
+	escludiScala = regexp.MustCompile(`(?i)\bIS(?:EE|E|R|P)\b|reddituale|patrimoniale`)
+)
+
+// Parse extracts the attestation values from the PDF text.
+func Parse(text string) Attestazione {
+	text = strings.Join(strings.Fields(reSigle.Replace(text)), " ")
+	var a Attestazione
+
+	for _, m := range trova(reISEE, escludiISEE, text) {
+		gap := strings.ToLower(m[1])
+		v := ParseImporto(m[2])
+		switch {
+		case strings.Contains(gap, "minorenn"):
+			setFirst(&a.ISEEMinorenni, v)
+		case strings.Contains(gap, "corrente"):
+			setFirst(&a.ISEECorrente, v)
+		case strings.Contains(gap, "ordinari"):
+			setFirst(&a.ISEEOrdinario, v)
+		case strings.Contains(gap, "universit"), strings.Contains(gap, "sociosanit"),
+			strings.Contains(gap, "residenzial"), strings.Contains(gap, "dottorato"):
+			// ISEE for specific services, not used by the matcher
+		default:
+			setFirst(&a.generico, v)
+		}
+	}
+	if a.ISEEOrdinario == 0 && a.generico > 0 {
+		a.ISEEOrdinario = a.generico
+	}
+
+	a.ISE = primo(reISE, escludiISE, text)
+	a.ISR = primo(reISR, escludiISR, text)
+	a.ISP = primo(reISP, escludiISP, text)
+	a.ScalaEquivalenza = primo(reScala, escludiScala, text)
+	if m := reComp.FindStringSubmatch(text); m != nil {
+		a.Componenti, _ = strconv.Atoi(m[2])
+	}
+
+	if m := reProtocollo.FindStringSubmatch(text); m != nil {
+		a.Protocollo = strings.ToUpper(m[1])
+	}
+	if m := reScadenza.FindStringSubmatch(text); m != nil {
+		d, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[2])
+		y, _ := strconv.Atoi(m[3])
+		if t := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, time.UTC); t.Day() == d && t.Month() == time.Month(mo) {
+			a.Scadenza = t.Format("2006-01-02")
+		}
+	} else if m := reProtocollo.FindStringSubmatch(text); m != nil {
+		// Attestations are valid until 31 December of the year the DSU
+		// was presented, which is the year in the protocol number.
+		a.Scadenza = m[2] + "-12-31"
+	}
+
+	a.Difformita = reDifformita.MatchString(reNoDifformita.ReplaceAllString(text, ""))
+	return a
+}
+
+// Principale returns the ISEE to use for matching and its kind: the
+// corrente when present (it replaces the ordinario), then the ordinario,
+// then the minorenni.
+func (a Attestazione) Principale() (float64, string) {
+	switch {
+	case a.ISEECorrente > 0:
+		return a.ISEECorrente, "corrente"
+	case a.ISEEOrdinario > 0:
+		return a.ISEEOrdinario, "ordinario"
+	case a.ISEEMinorenni > 0:
+		return a.ISEEMinorenni, "minorenni"
+	}
+	return 0, ""
+}
+
+// Verifica flags missing values, omissions, expiry (as of oggi) and values
+// that don't add up.
+func (a Attestazione) Verifica(oggi time.Time) []Avviso {
+	var avvisi []Avviso
+	isee, _ := a.Principale()
+	if isee == 0 {
+		avvisi = append(avvisi, avviso(AvvisoNonTrovato))
+	} else if a.generico > 0 && a.generico == a.ISEEOrdinario && a.ISEEMinorenni == 0 && a.ISEECorrente == 0 {
+		avvisi = append(avvisi, avviso(AvvisoSoloGenerico))
+	}
+	if a.Difformita {
+		avvisi = append(avvisi, avviso(AvvisoDifformita))
+	}
+	if a.Scadenza != "" {
+		if t, err := time.Parse("2006-01-02", a.Scadenza); err == nil && oggi.After(t.AddDate(0, 0, 1)) {
+			avvisi = append(avvisi, avviso(AvvisoScaduta))
+		}
+	}
+
+	incoerente := false
+	if a.ISE > 0 && a.ISR > 0 && a.ISP > 0 {
+		// ISE = ISR + 20% ISP
+		incoerente = math.Abs(a.ISR+0.2*a.ISP-a.ISE) > 1
+	}
+	if a.ISE > 0 && a.ScalaEquivalenza > 0 && a.ISEEOrdinario > 0 {
+		// the scale is printed with two decimals: allow 1% for rounding
+		incoerente = incoerente || math.Abs(a.ISE/a.ScalaEquivalenza-a.ISEEOrdinario) > 0.01*a.ISEEOrdinario
+	}
+	if incoerente {
+		avvisi = append(avvisi, avviso(AvvisoIncoerente))
+	}
+	return avvisi
+}
+
+// trova returns the label matches of re whose gap before the number
+// doesn't contain another label.
+func trova(re, escludi *regexp.Regexp, text string) [][]string {
+	var out [][]string
+	for _, m := range re.FindAllStringSubmatch(text, -1) {
+		if !escludi.MatchString(m[1]) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// primo returns the first amount found for a label, 0 if none.
+func primo(re, escludi *regexp.Regexp, text string) float64 {
+	if m := trova(re, escludi, text); len(m) > 0 {
+		return ParseImporto(m[0][2])
+	}
+	return 0
+}
+
+func setFirst(dst *float64, v float64) {
+	if *dst == 0 {
+		*dst = v
+	}
+}
+
+// ParseImporto converts an Italian amount (18.432,00) to a float, 0 when
+// s is not one. The CU and 730 reader uses it too.
+func ParseImporto(s string) float64 {
+	s = strings.ReplaceAll(s, ".", "")
+	s = strings.Replace(s, ",", ".", 1)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
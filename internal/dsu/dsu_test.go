@@ -0,0 +1,88 @@
+package dsu
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseImporto(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"18.432,00", 18432},
+		{"1.234.567,89", 1234567.89},
+		{"0,50", 0.5},
+		{"250", 250},
+		{"", 0},
+		{"n.d.", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseImporto(tt.in); got != tt.want {
+			t.Errorf("ParseImporto(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+const attestazione = `ATTESTAZIONE I.S.E.E. - Protocollo DSU INPS-ISEE-2026-01234567A-00
+Nucleo familiare: numero componenti 3
+Indicatore della Situazione Reddituale (ISR): 24.000,00
+Indicatore della Situazione Patrimoniale (ISP): 15.000,00
+Indicatore della Situazione Economica (ISE): 27.000,00
+Parametro scala di equivalenza: 2,04
+ISEE ordinario: 13.235,29
+ISEE per prestazioni agevolate rivolte a minorenni: 12.100,50
+ISEE per prestazioni universitarie: 14.000,00
+Nella DSU non sono state rilevate omissioni o difformita`
+
+func TestParse(t *testing.T) {
+	a := Parse(attestazione)
+	want := Attestazione{
+		ISEEOrdinario: 13235.29, ISEEMinorenni: 12100.50, ISE: 27000, ISR: 24000, ISP: 15000,
+		ScalaEquivalenza: 2.04, Componenti: 3, Protocollo: "INPS-ISEE-2026-01234567A-00", Scadenza: "2026-12-31",
+	}
+	if a != want {
+		t.Errorf("Parse:\n got %+v\nwant %+v", a, want)
+	}
+	if isee, tipo := a.Principale(); isee != 13235.29 || tipo != "ordinario" {
+		t.Errorf("Principale = %v %s", isee, tipo)
+	}
+
+	// An explicit expiry wins over the protocol year; omissions are flagged
+	a = Parse("ISEE corrente 9.500,00 valida fino al 15/06/2027 - rilevate le seguenti omissioni " + attestazione)
+	if a.ISEECorrente != 9500 || a.Scadenza != "2027-06-15" || !a.Difformita {
+		t.Errorf("corrente: %+v", a)
+	}
+	if isee, tipo := a.Principale(); isee != 9500 || tipo != "corrente" {
+		t.Errorf("Principale = %v %s", isee, tipo)
+	}
+}
+
+func TestVerifica(t *testing.T) {
+	tipi := func(a Attestazione, oggi time.Time) string {
+		var out []string
+		for _, av := range a.Verifica(oggi) {
+			out = append(out, av.Tipo)
+		}
+		return strings.Join(out, ",")
+	}
+	oggi := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := Parse(attestazione)
+	if got := tipi(a, oggi); got != "" {
+		t.Errorf("valid attestation: %s", got)
+	}
+	if got := tipi(a, time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC)); got != AvvisoScaduta {
+		t.Errorf("expired attestation: %s", got)
+	}
+	a.ISE = 30000
+	if got := tipi(a, oggi); got != AvvisoIncoerente {
+		t.Errorf("ISE that doesn't add up: %s", got)
+	}
+	if got := tipi(Parse("Valore ISEE: 18.432,00"), oggi); got != AvvisoSoloGenerico {
+		t.Errorf("generic ISEE: %s", got)
+	}
+	if got := tipi(Parse("Dichiarazione Sostitutiva Unica"), oggi); got != AvvisoNonTrovato {
+		t.Errorf("no ISEE: %s", got)
+	}
+}
@@ -0,0 +1,105 @@
+package eligibility
+
+import (
+	"bonusperme/internal/models"
+	"sort"
+)
+
+// Candidati returns, for every field the rules of the bonuses test, the
+// answers that can make a difference: both sides of each numeric bound
+// (the bound and one unit, or one cent for amounts, around it), true and
+// false for yes/no fields, and the listed values for text fields. The
+// conditions of the value tiers count too, since they change amounts.
+// Values are sorted and of the JSON type of the profile field.
+func Candidati(bonus []models.Bonus) map[string][]interface{} {
+	num := make(map[string]map[float64]bool)
+	txt := make(map[string]map[string]bool)
+	bools := make(map[string]bool)
+	var walk func(c models.Condizione)
+	walk = func(c models.Condizione) {
+		for _, sub := range c.UnaTra {
+			walk(sub)
+		}
+		for _, sub := range c.Tutte {
+			walk(sub)
+		}
+		f, ok := Fields[c.Campo]
+		if !ok {
+			return
+		}
+		switch f.Kind {
+		case Number:
+			if num[f.Name] == nil {
+				num[f.Name] = make(map[float64]bool)
+			}
+			step := 1.0
+			if f.Euro {
+				step = 0.01
+			}
+			for _, b := range []*float64{c.Min, c.Max, c.Oltre, c.Sotto} {
+				if b == nil {
+					continue
+				}
+				for _, v := range []float64{*b - step, *b, *b + step} {
+					if v >= 0 {
+						num[f.Name][v] = true
+					}
+				}
+			}
+		case Bool:
+			bools[f.Name] = true
+		case Text:
+			if txt[f.Name] == nil {
+				txt[f.Name] = make(map[string]bool)
+			}
+			for _, v := range c.In {
+				txt[f.Name][v] = true
+			}
+		}
+	}
+	for _, b := range bonus {
+		if r := b.Idoneita; r != nil {
+			for _, c := range r.Requisiti {
+				walk(c)
+			}
+			for _, p := range r.Punteggi {
+				for _, c := range p.Se {
+					walk(c)
+				}
+			}
+		}
+		if v := b.Valore; v != nil {
+			for _, f := range v.Fasce {
+				for _, c := range f.Se {
+					walk(c)
+				}
+			}
+		}
+	}
+
+	out := make(map[string][]interface{})
+	for name := range bools {
+		out[name] = []interface{}{false, true}
+	}
+	for name, set := range num {
+		vals := make([]float64, 0, len(set))
+		for v := range set {
+			vals = append(vals, v)
+		}
+		sort.Float64s(vals)
+		for _, v := range vals {
+			out[name] = append(out[name], v)
+		}
+	}
+	for name, set := range txt {
+		vals := make([]string, 0, len(set))
+		for v := range set {
+			vals = append(vals, v)
+		}
+		sort.Strings(vals)
+		for _, v := range vals {
+			out[name] = append(out[name], v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+// Package eligibility evaluates the declarative eligibility rules
+// (models.Idoneita) that each catalog bonus carries against a user profile.
+package eligibility
+
+import (
+	"bonusperme/internal/models"
+	"fmt"
+)
+
+// Kind is the type of value a profile field holds.
+type Kind int
+
+const (
+	Number Kind = iota
+	Bool
+	Text
+)
+
+// Field describes a profile field that conditions can test.
+// Euro marks amounts (formatted as currency in explanations); Unset marks
+// fields where the zero value means the user did not answer.
+type Field struct {
+	Name  string
+	Kind  Kind
+	Label string
+	Euro  bool
+	Unset bool
+	get   func(p models.UserProfile) interface{}
+}
+
+// Fields lists every field usable in a condition, keyed by the JSON name
+// used in UserProfile. "componenti_nucleo" is derived: the applicant plus
+// the listed household members or, without a list, children and over-65
+// members.
+var Fields = map[string]Field{}
+
+func init() {
+	for _, f := range []Field{
+		{Name: "eta", Kind: Number, Label: "Età", Unset: true, get: func(p models.UserProfile) interface{} { return float64(p.Eta) }},
+		{Name: "isee", Kind: Number, Label: "ISEE", Euro: true, Unset: true, get: func(p models.UserProfile) interface{} { return p.ISEE }},
+		{Name: "reddito_annuo", Kind: Number, Label: "Reddito annuo", Euro: true, Unset: true, get: func(p models.UserProfile) interface{} { return p.RedditoAnnuo }},
+		{Name: "spese_mediche", Kind: Number, Label: "Spese mediche", Euro: true, Unset: true, get: func(p models.UserProfile) interface{} { return p.SpeseMediche }},
+		{Name: "interessi_mutuo", Kind: Number, Label: "Interessi mutuo", Euro: true, Unset: true, get: func(p models.UserProfile) interface{} { return p.InteressiMutuo }},
+		{Name: "spese_ristrutturazione", Kind: Number, Label: "Spese di ristrutturazione", Euro: true, Unset: true, get: func(p models.UserProfile) interface{} { return p.SpeseRistrutturazione }},
+		{Name: "numero_figli", Kind: Number, Label: "Numero figli", get: func(p models.UserProfile) interface{} { return float64(p.NumeroFigli) }},
+		{Name: "figli_minorenni", Kind: Number, Label: "Figli minorenni", get: func(p models.UserProfile) interface{} { return float64(p.FigliMinorenni) }},
+		{Name: "figli_under3", Kind: Number, Label: "Figli under 3", get: func(p models.UserProfile) interface{} { return float64(p.FigliUnder3) }},
+		{Name: "figli_under1", Kind: Number, Label: "Figli under 1", get: func(p models.UserProfile) interface{} { return float64(p.FigliUnder1) }},
+		{Name: "figli_maggiorenni", Kind: Number, Label: "Figli maggiorenni", get: func(p models.UserProfile) interface{} { return float64(p.FigliMaggiorenni) }},
+		{Name: "figli_disabili", Kind: Number, Label: "Figli disabili", get: func(p models.UserProfile) interface{} { return float64(p.FigliDisabili) }},
+		{Name: "over65", Kind: Number, Label: "Over 65 nel nucleo", get: func(p models.UserProfile) interface{} { return float64(p.Over65) }},
+		{Name: "componenti_nucleo", Kind: Number, Label: "Componenti del nucleo", get: func(p models.UserProfile) interface{} {
+			if len(p.Nucleo) > 0 {
+				return float64(1 + len(p.Nucleo))
+			}
+			return float64(p.NumeroFigli + 1 + p.Over65)
+		}},
+		{Name: "disabilita", Kind: Bool, Label: "Disabilità", get: func(p models.UserProfile) interface{} { return p.Disabilita }},
+		{Name: "affittuario", Kind: Bool, Label: "In affitto", get: func(p models.UserProfile) interface{} { return p.Affittuario }},
+		{Name: "prima_abitazione", Kind: Bool, Label: "Prima abitazione", get: func(p models.UserProfile) interface{} { return p.PrimaAbitazione }},
+		{Name: "ristrutturaz_casa", Kind: Bool, Label: "Lavori in casa", get: func(p models.UserProfile) interface{} { return p.RistrutturazCasa }},
+		{Name: "studente", Kind: Bool, Label: "Studente", get: func(p models.UserProfile) interface{} { return p.Studente }},
+		{Name: "nuovo_nato_2026", Kind: Bool, Label: "Nuovo nato 2026", get: func(p models.UserProfile) interface{} { return p.NuovoNato2026 }},
+		{Name: "entrambi_genitori_lavoratori", Kind: Bool, Label: "Entrambi i genitori lavorano", get: func(p models.UserProfile) interface{} { return p.EntrambiGenitoriLavoratori }},
+		{Name: "madre_under21", Kind: Bool, Label: "Madre under 21", get: func(p models.UserProfile) interface{} { return p.MadreUnder21 }},
+		{Name: "occupazione", Kind: Text, Label: "Occupazione", get: func(p models.UserProfile) interface{} { return p.Occupazione }},
+		{Name: "stato_civile", Kind: Text, Label: "Stato civile", get: func(p models.UserProfile) interface{} { return p.StatoCivile }},
+		{Name: "residenza", Kind: Text, Label: "Regione di residenza", get: func(p models.UserProfile) interface{} { return p.Residenza }},
+		{Name: "comune", Kind: Text, Label: "Comune", get: func(p models.UserProfile) interface{} { return p.Comune }},
+		{Name: "disabilita_figli", Kind: Text, Label: "Disabilità dei figli", get: func(p models.UserProfile) interface{} { return p.DisabilitaFigli }},
+	} {
+		Fields[f.Name] = f
+	}
+}
+
+// Score returns the compatibility score (0-100) of a bonus for the profile:
+// 0 if the bonus has no rules or a requirement fails, otherwise the score
+// of the first matching tier.
+func Score(r *models.Idoneita, p models.UserProfile) int {
+	if r == nil {
+		return 0
+	}
+	if !All(r.Requisiti, p) {
+		return 0
+	}
+	for _, f := range r.Punteggi {
+		if All(f.Se, p) {
+			return f.Punteggio
+		}
+	}
+	return 0
+}
+
+// All reports whether every condition holds.
+func All(conds []models.Condizione, p models.UserProfile) bool {
+	for _, c := range conds {
+		if !Eval(c, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval reports whether a single condition holds for the profile.
+func Eval(c models.Condizione, p models.UserProfile) bool {
+	if len(c.UnaTra) > 0 {
+		for _, sub := range c.UnaTra {
+			if Eval(sub, p) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(c.Tutte) > 0 {
+		return All(c.Tutte, p)
+	}
+
+	f, ok := Fields[c.Campo]
+	if !ok {
+		return false
+	}
+	switch v := f.get(p).(type) {
+	case float64:
+		return inRange(c, v)
+	case bool:
+		return c.Vero == nil || *c.Vero == v
+	case string:
+		if len(c.In) == 0 {
+			return true
+		}
+		for _, allowed := range c.In {
+			if allowed == v {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+func inRange(c models.Condizione, v float64) bool {
+	if c.Min != nil && v < *c.Min {
+		return false
+	}
+	if c.Max != nil && v > *c.Max {
+		return false
+	}
+	if c.Oltre != nil && v <= *c.Oltre {
+		return false
+	}
+	if c.Sotto != nil && v >= *c.Sotto {
+		return false
+	}
+	return true
+}
+
+// Validate checks that a rule set is well formed: known fields, operators
+// matching the field type and scores in range. Used by the catalog loader.
+func Validate(r *models.Idoneita) []string {
+	if r == nil {
+		return []string{"idoneita is required"}
+	}
+	var msgs []string
+	for i, c := range r.Requisiti {
+		msgs = append(msgs, validateCond(fmt.Sprintf("requisiti[%d]", i), c)...)
+	}
+	if len(r.Punteggi) == 0 {
+		msgs = append(msgs, "idoneita.punteggi must have at least one tier")
+	}
+	for i, f := range r.Punteggi {
+		if f.Punteggio < 1 || f.Punteggio > 100 {
+			msgs = append(msgs, fmt.Sprintf("punteggi[%d]: punteggio %d out of range 1-100", i, f.Punteggio))
+		}
+		for j, c := range f.Se {
+			msgs = append(msgs, validateCond(fmt.Sprintf("punteggi[%d].se[%d]", i, j), c)...)
+		}
+	}
+	return msgs
+}
+
+func validateCond(where string, c models.Condizione) []string {
+	if len(c.UnaTra) > 0 || len(c.Tutte) > 0 {
+		if c.Campo != "" {
+			return []string{where + ": campo cannot be combined with una_tra/tutte"}
+		}
+		var msgs []string
+		for i, sub := range c.UnaTra {
+			msgs = append(msgs, validateCond(fmt.Sprintf("%s.una_tra[%d]", where, i), sub)...)
+		}
+		for i, sub := range c.Tutte {
+			msgs = append(msgs, validateCond(fmt.Sprintf("%s.tutte[%d]", where, i), sub)...)
+		}
+		return msgs
+	}
+
+	f, ok := Fields[c.Campo]
+	if !ok {
+		return []string{fmt.Sprintf("%s: unknown campo %q", where, c.Campo)}
+	}
+	numeric := c.Min != nil || c.Max != nil || c.Oltre != nil || c.Sotto != nil
+	switch {
+	case f.Kind == Number && (!numeric || c.Vero != nil || len(c.In) > 0):
+		return []string{fmt.Sprintf("%s: %s needs min/max/oltre/sotto", where, c.Campo)}
+	case f.Kind == Bool && (c.Vero == nil || numeric || len(c.In) > 0):
+		return []string{fmt.Sprintf("%s: %s needs vero", where, c.Campo)}
+	case f.Kind == Text && (len(c.In) == 0 || numeric || c.Vero != nil):
+		return []string{fmt.Sprintf("%s: %s needs in", where, c.Campo)}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package eligibility
+
+import (
+	"bonusperme/internal/models"
+	"strings"
+	"testing"
+)
+
+func num(v float64) *float64 { return &v }
+
+func vero(v bool) *bool { return &v }
+
+func TestEval(t *testing.T) {
+	p := models.UserProfile{Eta: 30, ISEE: 15000, NumeroFigli: 2, Studente: true, Occupazione: "dipendente"}
+	for _, tc := range []struct {
+		nome string
+		c    models.Condizione
+		want bool
+	}{
+		{"min, uguale", models.Condizione{Campo: "eta", Min: num(30)}, true},
+		{"min, sotto", models.Condizione{Campo: "eta", Min: num(31)}, false},
+		{"max, uguale", models.Condizione{Campo: "isee", Max: num(15000)}, true},
+		{"max, sopra", models.Condizione{Campo: "isee", Max: num(14999.99)}, false},
+		{"oltre, uguale", models.Condizione{Campo: "numero_figli", Oltre: num(2)}, false},
+		{"oltre, sopra", models.Condizione{Campo: "numero_figli", Oltre: num(1)}, true},
+		{"sotto, uguale", models.Condizione{Campo: "eta", Sotto: num(30)}, false},
+		{"sotto, sotto", models.Condizione{Campo: "eta", Sotto: num(36)}, true},
+		{"intervallo", models.Condizione{Campo: "eta", Min: num(18), Sotto: num(36)}, true},
+		{"intervallo, fuori", models.Condizione{Campo: "eta", Min: num(18), Max: num(29)}, false},
+		{"in, presente", models.Condizione{Campo: "occupazione", In: []string{"disoccupato", "dipendente"}}, true},
+		{"in, assente", models.Condizione{Campo: "occupazione", In: []string{"autonomo"}}, false},
+		{"vero, si", models.Condizione{Campo: "studente", Vero: vero(true)}, true},
+		{"vero, no", models.Condizione{Campo: "studente", Vero: vero(false)}, false},
+		{"vero, falso atteso", models.Condizione{Campo: "affittuario", Vero: vero(false)}, true},
+		{"una_tra, una vera", models.Condizione{UnaTra: []models.Condizione{
+			{Campo: "affittuario", Vero: vero(true)}, {Campo: "eta", Max: num(35)}}}, true},
+		{"una_tra, nessuna", models.Condizione{UnaTra: []models.Condizione{
+			{Campo: "affittuario", Vero: vero(true)}, {Campo: "eta", Max: num(25)}}}, false},
+		{"tutte, vere", models.Condizione{Tutte: []models.Condizione{
+			{Campo: "studente", Vero: vero(true)}, {Campo: "isee", Max: num(20000)}}}, true},
+		{"tutte, una falsa", models.Condizione{Tutte: []models.Condizione{
+			{Campo: "studente", Vero: vero(true)}, {Campo: "isee", Max: num(10000)}}}, false},
+		{"annidate", models.Condizione{UnaTra: []models.Condizione{
+			{Tutte: []models.Condizione{{Campo: "studente", Vero: vero(true)}, {Campo: "eta", Max: num(25)}}},
+			{Campo: "numero_figli", Min: num(2)}}}, true},
+		{"campo derivato", models.Condizione{Campo: "componenti_nucleo", Min: num(3)}, true},
+		{"campo sconosciuto", models.Condizione{Campo: "patrimonio", Max: num(1)}, false},
+	} {
+		if got := Eval(tc.c, p); got != tc.want {
+			t.Errorf("%s: Eval = %v, want %v", tc.nome, got, tc.want)
+		}
+	}
+}
+
+// An unanswered field passes Eval as its zero value, so a profile is not
+// excluded for what it did not say; Explain reports it as unknown instead.
+func TestUnset(t *testing.T) {
+	p := models.UserProfile{Eta: 30}
+	if !Eval(models.Condizione{Campo: "isee", Max: num(17000)}, p) {
+		t.Error("unset ISEE should pass an upper bound")
+	}
+	for _, tc := range []struct {
+		nome  string
+		c     models.Condizione
+		esito string
+	}{
+		{"importo non indicato", models.Condizione{Campo: "isee", Max: num(17000)}, models.EsitoNonNoto},
+		{"importo non indicato, soglia minima", models.Condizione{Campo: "reddito_annuo", Oltre: num(0)}, models.EsitoNonNoto},
+		{"testo non indicato", models.Condizione{Campo: "occupazione", In: []string{"dipendente"}}, models.EsitoNonNoto},
+		{"conteggio a zero e una risposta", models.Condizione{Campo: "numero_figli", Min: num(1)}, models.EsitoNonSoddisfatto},
+		{"booleano falso e una risposta", models.Condizione{Campo: "disabilita", Vero: vero(true)}, models.EsitoNonSoddisfatto},
+		{"campo indicato", models.Condizione{Campo: "eta", Max: num(35)}, models.EsitoSoddisfatto},
+		{"una_tra, nota solo se vera", models.Condizione{UnaTra: []models.Condizione{
+			{Campo: "studente", Vero: vero(true)}, {Campo: "isee", Max: num(10000)}}}, models.EsitoNonNoto},
+		{"una_tra, una vera basta", models.Condizione{UnaTra: []models.Condizione{
+			{Campo: "isee", Max: num(10000)}, {Campo: "eta", Max: num(35)}}}, models.EsitoSoddisfatto},
+		{"tutte, una falsa decide", models.Condizione{Tutte: []models.Condizione{
+			{Campo: "isee", Max: num(10000)}, {Campo: "eta", Max: num(25)}}}, models.EsitoNonSoddisfatto},
+		{"tutte, le altre vere", models.Condizione{Tutte: []models.Condizione{
+			{Campo: "isee", Max: num(10000)}, {Campo: "eta", Max: num(35)}}}, models.EsitoNonNoto},
+	} {
+		r := &models.Idoneita{Requisiti: []models.Condizione{tc.c}, Punteggi: []models.Fascia{{Punteggio: 50}}}
+		e := Explain(r, p)
+		if len(e) != 1 || e[0].Esito != tc.esito || !e[0].Obbligatorio {
+			t.Errorf("%s: Explain = %+v, want esito %s", tc.nome, e, tc.esito)
+		}
+	}
+
+	e := Explain(&models.Idoneita{Requisiti: []models.Condizione{{Campo: "isee", Max: num(17000)}},
+		Punteggi: []models.Fascia{{Punteggio: 50}}}, p)
+	if e[0].Campo != "isee" || e[0].Valore != "non indicato" {
+		t.Errorf("unset value: %+v", e[0])
+	}
+}
+
+func TestScore(t *testing.T) {
+	r := &models.Idoneita{
+		Requisiti: []models.Condizione{{Campo: "numero_figli", Min: num(1)}},
+		Punteggi: []models.Fascia{
+			{Se: []models.Condizione{{Campo: "isee", Max: num(10000)}}, Punteggio: 95},
+			{Se: []models.Condizione{{Campo: "isee", Max: num(25000)}, {Campo: "affittuario", Vero: vero(true)}}, Punteggio: 80},
+			{Punteggio: 60},
+		},
+	}
+	for _, tc := range []struct {
+		nome string
+		r    *models.Idoneita
+		p    models.UserProfile
+		want int
+	}{
+		{"senza regole", nil, models.UserProfile{NumeroFigli: 1}, 0},
+		{"requisito mancato", r, models.UserProfile{ISEE: 5000}, 0},
+		{"prima fascia", r, models.UserProfile{NumeroFigli: 1, ISEE: 5000, Affittuario: true}, 95},
+		{"fascia con piu condizioni", r, models.UserProfile{NumeroFigli: 1, ISEE: 20000, Affittuario: true}, 80},
+		{"condizioni in parte vere", r, models.UserProfile{NumeroFigli: 1, ISEE: 20000}, 60},
+		{"fascia di base", r, models.UserProfile{NumeroFigli: 1, ISEE: 40000}, 60},
+		{"ISEE non indicato", r, models.UserProfile{NumeroFigli: 1}, 95},
+		{"nessuna fascia", &models.Idoneita{Punteggi: []models.Fascia{
+			{Se: []models.Condizione{{Campo: "studente", Vero: vero(true)}}, Punteggio: 70}}}, models.UserProfile{}, 0},
+	} {
+		if got := Score(tc.r, tc.p); got != tc.want {
+			t.Errorf("%s: Score = %d, want %d", tc.nome, got, tc.want)
+		}
+	}
+
+	// Explain stops at the tier that gave the score
+	e := Explain(r, models.UserProfile{NumeroFigli: 1, ISEE: 20000, Affittuario: true})
+	var descr []string
+	for _, x := range e {
+		descr = append(descr, x.Descrizione)
+	}
+	want := "Numero figli almeno 1|ISEE fino a €10.000|ISEE fino a €25.000|In affitto"
+	if got := strings.Join(descr, "|"); got != want {
+		t.Errorf("Explain = %q, want %q", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		nome   string
+		r      *models.Idoneita
+		errore string
+	}{
+		{"valida", &models.Idoneita{Requisiti: []models.Condizione{{Campo: "isee", Max: num(1)}},
+			Punteggi: []models.Fascia{{Punteggio: 50}}}, ""},
+		{"assente", nil, "idoneita is required"},
+		{"senza fasce", &models.Idoneita{}, "at least one tier"},
+		{"punteggio fuori scala", &models.Idoneita{Punteggi: []models.Fascia{{Punteggio: 101}}}, "out of range"},
+		{"campo sconosciuto", &models.Idoneita{Requisiti: []models.Condizione{{Campo: "patrimonio", Max: num(1)}},
+			Punteggi: []models.Fascia{{Punteggio: 50}}}, `unknown campo "patrimonio"`},
+		{"numero senza limiti", &models.Idoneita{Requisiti: []models.Condizione{{Campo: "isee", In: []string{"x"}}},
+			Punteggi: []models.Fascia{{Punteggio: 50}}}, "isee needs min/max/oltre/sotto"},
+		{"booleano senza vero", &models.Idoneita{Requisiti: []models.Condizione{{Campo: "studente", Min: num(1)}},
+			Punteggi: []models.Fascia{{Punteggio: 50}}}, "studente needs vero"},
+		{"testo senza in", &models.Idoneita{Requisiti: []models.Condizione{{Campo: "occupazione"}},
+			Punteggi: []models.Fascia{{Punteggio: 50}}}, "occupazione needs in"},
+		{"campo e una_tra", &models.Idoneita{Requisiti: []models.Condizione{{Campo: "eta",
+			UnaTra: []models.Condizione{{Campo: "eta", Max: num(1)}}}}, Punteggi: []models.Fascia{{Punteggio: 50}}},
+			"cannot be combined"},
+		{"annidata", &models.Idoneita{Punteggi: []models.Fascia{{Punteggio: 50, Se: []models.Condizione{
+			{Tutte: []models.Condizione{{Campo: "eta", Max: num(1)}, {Campo: "eta"}}}}}}},
+			"punteggi[0].se[0].tutte[1]: eta needs min/max/oltre/sotto"},
+	} {
+		msgs := Validate(tc.r)
+		if tc.errore == "" {
+			if len(msgs) > 0 {
+				t.Errorf("%s: unexpected errors %q", tc.nome, msgs)
+			}
+			continue
+		}
+		if len(msgs) != 1 || !strings.Contains(msgs[0], tc.errore) {
+			t.Errorf("%s: Validate = %q, want %q", tc.nome, msgs, tc.errore)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	for _, tc := range []struct {
+		c    models.Condizione
+		want string
+	}{
+		{models.Condizione{Campo: "isee", Max: num(17468.51)}, "ISEE fino a €17.468,51"},
+		{models.Condizione{Campo: "isee", Oltre: num(0), Max: num(40000)}, "ISEE fino a €40.000"},
+		{models.Condizione{Campo: "reddito_annuo", Oltre: num(0)}, "Reddito annuo indicato"},
+		{models.Condizione{Campo: "numero_figli", Oltre: num(2)}, "Numero figli almeno 3"},
+		{models.Condizione{Campo: "eta", Min: num(18), Sotto: num(36)}, "Età tra 18 e 35"},
+		{models.Condizione{Campo: "isee", Sotto: num(9360)}, "ISEE inferiore a €9.360"},
+		{models.Condizione{Campo: "affittuario", Vero: vero(false)}, "In affitto: no"},
+		{models.Condizione{Campo: "occupazione", In: []string{"disoccupato", "in_cerca"}}, "Occupazione: disoccupato o in cerca"},
+		{models.Condizione{UnaTra: []models.Condizione{{Campo: "studente", Vero: vero(true)}, {Campo: "eta", Max: num(25)}}},
+			"Almeno uno tra: Studente; Età fino a 25"},
+	} {
+		if got := Describe(tc.c); got != tc.want {
+			t.Errorf("Describe(%+v) = %q, want %q", tc.c, got, tc.want)
+		}
+	}
+}
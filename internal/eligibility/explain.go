@@ -0,0 +1,238 @@
+package eligibility
+
+import (
+	"bonusperme/internal/models"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Explain lists the conditions behind a bonus score for the profile:
+// every requirement (Obbligatorio) followed by the conditions of the
+// scoring tiers up to the one that produced the score, so the user can
+// see why a higher tier was missed. Conditions on fields the user left
+// blank are reported as models.EsitoNonNoto.
+func Explain(r *models.Idoneita, p models.UserProfile) []models.EsitoRequisito {
+	if r == nil {
+		return nil
+	}
+	var out []models.EsitoRequisito
+	seen := make(map[string]bool)
+	add := func(c models.Condizione, obbligatorio bool) {
+		e := explainCond(c, p)
+		if seen[e.Descrizione] {
+			return
+		}
+		seen[e.Descrizione] = true
+		e.Obbligatorio = obbligatorio
+		out = append(out, e)
+	}
+
+	for _, c := range r.Requisiti {
+		add(c, true)
+	}
+	for _, f := range r.Punteggi {
+		for _, c := range f.Se {
+			add(c, false)
+		}
+		if All(f.Se, p) {
+			break
+		}
+	}
+	return out
+}
+
+func explainCond(c models.Condizione, p models.UserProfile) models.EsitoRequisito {
+	e := models.EsitoRequisito{Descrizione: Describe(c), Esito: outcome(c, p)}
+	if f, ok := Fields[c.Campo]; ok {
+		e.Campo = f.Name
+		e.Valore = formatValue(f, f.get(p))
+	}
+	return e
+}
+
+// outcome evaluates a condition with three states: a condition on a field
+// the user did not fill in is unknown rather than failed.
+func outcome(c models.Condizione, p models.UserProfile) string {
+	if len(c.UnaTra) > 0 {
+		res := models.EsitoNonSoddisfatto
+		for _, sub := range c.UnaTra {
+			switch outcome(sub, p) {
+			case models.EsitoSoddisfatto:
+				return models.EsitoSoddisfatto
+			case models.EsitoNonNoto:
+				res = models.EsitoNonNoto
+			}
+		}
+		return res
+	}
+	if len(c.Tutte) > 0 {
+		res := models.EsitoSoddisfatto
+		for _, sub := range c.Tutte {
+			switch outcome(sub, p) {
+			case models.EsitoNonSoddisfatto:
+				return models.EsitoNonSoddisfatto
+			case models.EsitoNonNoto:
+				res = models.EsitoNonNoto
+			}
+		}
+		return res
+	}
+
+	if f, ok := Fields[c.Campo]; ok && isUnset(f, f.get(p)) {
+		return models.EsitoNonNoto
+	}
+	if Eval(c, p) {
+		return models.EsitoSoddisfatto
+	}
+	return models.EsitoNonSoddisfatto
+}
+
+func isUnset(f Field, v interface{}) bool {
+	switch v := v.(type) {
+	case float64:
+		return f.Unset && v == 0
+	case string:
+		return v == ""
+	}
+	return false
+}
+
+// Describe renders a condition as a short Italian sentence,
+// e.g. "ISEE fino a €17.468,51" or "Almeno uno tra: Studente; Eta fino a 25".
+func Describe(c models.Condizione) string {
+	if len(c.UnaTra) > 0 {
+		parts := make([]string, len(c.UnaTra))
+		for i, sub := range c.UnaTra {
+			parts[i] = Describe(sub)
+		}
+		return "Almeno uno tra: " + strings.Join(parts, "; ")
+	}
+	if len(c.Tutte) > 0 {
+		parts := make([]string, len(c.Tutte))
+		for i, sub := range c.Tutte {
+			parts[i] = Describe(sub)
+		}
+		return strings.Join(parts, " e ")
+	}
+
+	f, ok := Fields[c.Campo]
+	if !ok {
+		return c.Campo
+	}
+	switch f.Kind {
+	case Bool:
+		if c.Vero != nil && !*c.Vero {
+			return f.Label + ": no"
+		}
+		return f.Label
+	case Text:
+		vals := make([]string, len(c.In))
+		for i, v := range c.In {
+			vals[i] = strings.ReplaceAll(v, "_", " ")
+		}
+		if len(vals) > 1 {
+			return f.Label + ": " + strings.Join(vals[:len(vals)-1], ", ") + " o " + vals[len(vals)-1]
+		}
+		return f.Label + ": " + strings.Join(vals, "")
+	}
+	return f.Label + " " + describeRange(f, c)
+}
+
+// describeRange phrases the numeric bounds of a condition. Counts and ages
+// are integers, so strict bounds are shown as inclusive ones ("oltre 0"
+// becomes "almeno 1"). On fields where 0 means "not answered", "oltre: 0"
+// next to another bound only checks the value was given and is omitted.
+func describeRange(f Field, c models.Condizione) string {
+	var lo, hi *float64
+	loIncl, hiIncl := true, true
+	upper := c.Max != nil || c.Sotto != nil
+
+	switch {
+	case c.Min != nil:
+		lo = c.Min
+	case c.Oltre != nil && f.Unset && *c.Oltre == 0 && upper:
+	case c.Oltre != nil && f.Euro:
+		lo, loIncl = c.Oltre, false
+	case c.Oltre != nil:
+		v := *c.Oltre + 1
+		lo = &v
+	}
+	switch {
+	case c.Max != nil:
+		hi = c.Max
+	case c.Sotto != nil && f.Euro:
+		hi, hiIncl = c.Sotto, false
+	case c.Sotto != nil:
+		v := *c.Sotto - 1
+		hi = &v
+	}
+
+	switch {
+	case lo != nil && hi != nil && loIncl && hiIncl:
+		return "tra " + formatNumber(f, *lo) + " e " + formatNumber(f, *hi)
+	case lo != nil && hi != nil:
+		return lowerPhrase(f, *lo, loIncl) + " e " + upperPhrase(f, *hi, hiIncl)
+	case lo != nil && f.Unset && !loIncl && *lo == 0:
+		return "indicato"
+	case lo != nil:
+		return lowerPhrase(f, *lo, loIncl)
+	case hi != nil:
+		return upperPhrase(f, *hi, hiIncl)
+	}
+	return "indicato"
+}
+
+func lowerPhrase(f Field, v float64, incl bool) string {
+	if incl {
+		return "almeno " + formatNumber(f, v)
+	}
+	return "superiore a " + formatNumber(f, v)
+}
+
+func upperPhrase(f Field, v float64, incl bool) string {
+	if incl {
+		return "fino a " + formatNumber(f, v)
+	}
+	return "inferiore a " + formatNumber(f, v)
+}
+
+func formatValue(f Field, v interface{}) string {
+	if isUnset(f, v) {
+		return "non indicato"
+	}
+	switch v := v.(type) {
+	case float64:
+		return formatNumber(f, v)
+	case bool:
+		if v {
+			return "si"
+		}
+		return "no"
+	case string:
+		return strings.ReplaceAll(v, "_", " ")
+	}
+	return ""
+}
+
+// formatNumber formats amounts as "€1.234,56" (decimals only when present)
+// and everything else as a plain integer.
+func formatNumber(f Field, v float64) string {
+	if !f.Euro {
+		return strconv.Itoa(int(v))
+	}
+	neg := v < 0
+	v = math.Abs(v)
+	cents := int64(math.Round(v * 100))
+	s := strconv.FormatInt(cents/100, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "." + s[i:]
+	}
+	if frac := cents % 100; frac != 0 {
+		s += "," + strconv.FormatInt(frac/10, 10) + strconv.FormatInt(frac%10, 10)
+	}
+	if neg {
+		return "-€" + s
+	}
+	return "€" + s
+}
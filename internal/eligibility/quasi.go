@@ -0,0 +1,143 @@
+package eligibility
+
+import (
+	"bonusperme/internal/models"
+	"math"
+)
+
+// Mancanza is the single requirement a profile misses a bonus on, with
+// the bound to reach and how far the profile's value is from it.
+type Mancanza struct {
+	// Requisito is the failed condition (the sub-condition closest to
+	// being met for una_tra/tutte) with the user's value.
+	Requisito models.EsitoRequisito
+	Soglia    float64
+	Scarto    float64
+	// Oltre is true when the value is above a maximum, false when it is
+	// below a minimum.
+	Oltre bool
+	// SogliaTesto and ScartoTesto are Soglia and Scarto formatted like the
+	// field ("€25.000", "35").
+	SogliaTesto string
+	ScartoTesto string
+}
+
+// Quasi reports whether the profile narrowly misses a bonus: every
+// requirement but one holds, the failed one is a numeric bound on a field
+// the user filled in, the value misses it by at most the field tolerance
+// (1 for ages and counts, 10% of the bound and at least €500 for
+// amounts), and meeting it would give a score. Requirements on yes/no and
+// list fields are never near misses.
+func Quasi(r *models.Idoneita, p models.UserProfile) (Mancanza, bool) {
+	if r == nil || Score(r, p) > 0 {
+		return Mancanza{}, false
+	}
+	fallito := -1
+	for i, c := range r.Requisiti {
+		if Eval(c, p) {
+			continue
+		}
+		if fallito >= 0 {
+			return Mancanza{}, false
+		}
+		fallito = i
+	}
+	if fallito < 0 {
+		return Mancanza{}, false
+	}
+	m, ok := mancanza(r.Requisiti[fallito], p)
+	if !ok {
+		return Mancanza{}, false
+	}
+	resto := &models.Idoneita{Punteggi: r.Punteggi}
+	resto.Requisiti = append(resto.Requisiti, r.Requisiti[:fallito]...)
+	resto.Requisiti = append(resto.Requisiti, r.Requisiti[fallito+1:]...)
+	if Score(resto, p) == 0 {
+		return Mancanza{}, false
+	}
+	m.Requisito.Obbligatorio = true
+	return m, true
+}
+
+// mancanza measures a failed condition: the closest alternative of an
+// una_tra, the only failing part of a tutte, or a numeric bound.
+func mancanza(c models.Condizione, p models.UserProfile) (Mancanza, bool) {
+	if len(c.UnaTra) > 0 {
+		var best Mancanza
+		found := false
+		for _, sub := range c.UnaTra {
+			if m, ok := mancanza(sub, p); ok && (!found || relativo(m) < relativo(best)) {
+				best, found = m, true
+			}
+		}
+		return best, found
+	}
+	if len(c.Tutte) > 0 {
+		var failed []models.Condizione
+		for _, sub := range c.Tutte {
+			if !Eval(sub, p) {
+				failed = append(failed, sub)
+			}
+		}
+		if len(failed) != 1 {
+			return Mancanza{}, false
+		}
+		return mancanza(failed[0], p)
+	}
+
+	f, ok := Fields[c.Campo]
+	if !ok || f.Kind != Number {
+		return Mancanza{}, false
+	}
+	raw := f.get(p)
+	if isUnset(f, raw) {
+		return Mancanza{}, false
+	}
+	v := raw.(float64)
+
+	// Integer fields turn strict bounds into inclusive ones, like
+	// describeRange; amounts keep them.
+	var m Mancanza
+	switch {
+	case c.Min != nil && v < *c.Min:
+		m.Soglia = *c.Min
+	case c.Oltre != nil && v <= *c.Oltre && f.Euro:
+		m.Soglia = *c.Oltre
+	case c.Oltre != nil && v <= *c.Oltre:
+		m.Soglia = *c.Oltre + 1
+	case c.Max != nil && v > *c.Max:
+		m.Soglia, m.Oltre = *c.Max, true
+	case c.Sotto != nil && v >= *c.Sotto && f.Euro:
+		m.Soglia, m.Oltre = *c.Sotto, true
+	case c.Sotto != nil && v >= *c.Sotto:
+		m.Soglia, m.Oltre = *c.Sotto-1, true
+	default:
+		return Mancanza{}, false
+	}
+	m.Scarto = math.Abs(v - m.Soglia)
+	if m.Scarto == 0 {
+		m.Scarto = 0.01 // on a strict bound of an amount
+	}
+	if m.Scarto > tolleranza(f, m.Soglia) {
+		return Mancanza{}, false
+	}
+	m.Requisito = explainCond(c, p)
+	m.SogliaTesto = formatNumber(f, m.Soglia)
+	m.ScartoTesto = formatNumber(f, m.Scarto)
+	return m, true
+}
+
+// tolleranza is how far a value may be from the bound of a field for the
+// miss to count as narrow.
+func tolleranza(f Field, soglia float64) float64 {
+	if f.Euro {
+		return math.Max(500, math.Abs(soglia)*0.1)
+	}
+	return 1
+}
+
+// relativo is the miss as a share of the tolerance, to compare misses on
+// different fields.
+func relativo(m Mancanza) float64 {
+	return m.Scarto / tolleranza(Fields[m.Requisito.Campo], m.Soglia)
+}
@@ -0,0 +1,125 @@
+package eligibility
+
+import (
+	"bonusperme/internal/models"
+	"fmt"
+	"time"
+)
+
+// Calcoli maps the formula names usable in Beneficio.Calcolo to functions
+// returning the expected amount per period for a profile as of a date, and
+// false when the profile lacks the data (the catalog amounts are kept).
+// Packages that own a formula register it from init (see matcher).
+var Calcoli = map[string]func(models.UserProfile, time.Time) (float64, bool){}
+
+var validTipo = map[string]bool{
+	models.TipoTrasferimento: true, models.TipoDetrazione: true,
+	models.TipoScontoBolletta: true, models.TipoGaranzia: true,
+	models.TipoEsoneroContributivo: true, models.TipoSconto: true,
+}
+
+var validPeriodicita = map[string]bool{
+	models.PeriodicitaUnaTantum: true, models.PeriodicitaMensile: true, models.PeriodicitaAnnuale: true,
+}
+
+// Valore resolves a catalog Beneficio for the profile as of a date: the
+// first tier in Fasce whose conditions hold overrides the base values, then
+// Calcolo (if any) computes the exact expected amount. The result has no
+// Fasce or Calcolo left and is safe to expose as the user's estimate.
+func Valore(v *models.Beneficio, p models.UserProfile, asOf time.Time) *models.Beneficio {
+	if v == nil {
+		return nil
+	}
+	out := *v
+	out.Fasce = nil
+	out.Calcolo = ""
+
+	for _, f := range v.Fasce {
+		if !All(f.Se, p) {
+			continue
+		}
+		if f.Tipo != "" {
+			out.Tipo = f.Tipo
+		}
+		if f.Periodicita != "" {
+			out.Periodicita = f.Periodicita
+		}
+		if f.Min != 0 {
+			out.Min = f.Min
+		}
+		if f.Max != 0 {
+			out.Max = f.Max
+		}
+		if f.Atteso != 0 {
+			out.Atteso = f.Atteso
+		}
+		if f.Durata != 0 {
+			out.Durata = f.Durata
+		}
+		break
+	}
+
+	if fn, ok := Calcoli[v.Calcolo]; ok {
+		if atteso, ok := fn(p, asOf); ok {
+			out.Atteso = atteso
+			out.Min, out.Max = atteso, atteso
+		}
+	}
+	return &out
+}
+
+// ValidateValore checks a catalog Beneficio: known tipo and periodicita,
+// a consistent range and registered formulas. Used by the catalog loader.
+func ValidateValore(v *models.Beneficio) []string {
+	if v == nil {
+		return []string{"valore is required"}
+	}
+	var msgs []string
+	if !validTipo[v.Tipo] {
+		msgs = append(msgs, fmt.Sprintf("unknown tipo %q", v.Tipo))
+	}
+	if !validPeriodicita[v.Periodicita] {
+		msgs = append(msgs, fmt.Sprintf("unknown periodicita %q", v.Periodicita))
+	}
+	msgs = append(msgs, validateRange("", v.Min, v.Max, v.Atteso, v.Durata)...)
+	if v.Calcolo != "" && Calcoli[v.Calcolo] == nil {
+		msgs = append(msgs, fmt.Sprintf("unknown calcolo %q", v.Calcolo))
+	}
+	for i, f := range v.Fasce {
+		where := fmt.Sprintf("fasce[%d]", i)
+		if len(f.Se) == 0 {
+			msgs = append(msgs, where+": se is required")
+		}
+		for j, c := range f.Se {
+			msgs = append(msgs, validateCond(fmt.Sprintf("%s.se[%d]", where, j), c)...)
+		}
+		if f.Tipo != "" && !validTipo[f.Tipo] {
+			msgs = append(msgs, fmt.Sprintf("%s: unknown tipo %q", where, f.Tipo))
+		}
+		if f.Periodicita != "" && !validPeriodicita[f.Periodicita] {
+			msgs = append(msgs, fmt.Sprintf("%s: unknown periodicita %q", where, f.Periodicita))
+		}
+		msgs = append(msgs, validateRange(where+": ", f.Min, f.Max, f.Atteso, f.Durata)...)
+	}
+	return msgs
+}
+
+// validateRange checks amounts are not negative and, when a maximum is
+// set, that min <= atteso <= max. Atteso 0 means "not estimated" and is
+// always accepted.
+func validateRange(prefix string, min, max, atteso float64, durata int) []string {
+	var msgs []string
+	if min < 0 || max < 0 || atteso < 0 || durata < 0 {
+		msgs = append(msgs, prefix+"amounts and durata must not be negative")
+	}
+	if max > 0 && min > max {
+		msgs = append(msgs, fmt.Sprintf("%smin %.2f greater than max %.2f", prefix, min, max))
+	}
+	if atteso > 0 && max > 0 && atteso > max {
+		msgs = append(msgs, fmt.Sprintf("%satteso %.2f greater than max %.2f", prefix, atteso, max))
+	}
+	if atteso > 0 && atteso < min {
+		msgs = append(msgs, fmt.Sprintf("%satteso %.2f lower than min %.2f", prefix, atteso, min))
+	}
+	return msgs
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bonusperme/internal/calc"
+	"bonusperme/internal/clock"
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/models"
+	"bonusperme/internal/nucleo"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// assegnoUnicoRequest is a profile plus the year whose parameters to use
+// (default: current year). The household is taken as it is on today's day
+// of that year.
+type assegnoUnicoRequest struct {
+	models.UserProfile
+	Anno int `json:"anno"`
+}
+
+// AssegnoUnicoCalcHandler serves /api/calc/assegno-unico.
+// GET returns the parameter tables by year; POST computes the monthly
+// amount for a profile with every maggiorazione itemised.
+func AssegnoUnicoCalcHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		anni := make([]int, 0, len(calc.TabelleAU))
+		for a := range calc.TabelleAU {
+			anni = append(anni, a)
+		}
+		sort.Ints(anni)
+		tabelle := make([]calc.ParametriAU, len(anni))
+		for i, a := range anni {
+			tabelle[i] = calc.TabelleAU[a]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		json.NewEncoder(w).Encode(map[string]interface{}{"parametri": tabelle})
+		return
+	case http.MethodPost:
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req assegnoUnicoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if msg, ok := validateProfile(req.UserProfile); !ok {
+		i18n.Error(w, r, msg, http.StatusBadRequest)
+		return
+	}
+	oggi := clock.Now()
+	anno := req.Anno
+	if anno == 0 {
+		anno = oggi.Year()
+	} else if _, ok := calc.TabelleAU[anno]; !ok {
+		i18n.Error(w, r, i18n.M("calc.anno"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(calc.AssegnoUnico(nucleo.Deriva(req.UserProfile, nellAnno(oggi, anno)), anno))
+}
+
+// nellAnno returns the day of oggi in year anno; 29 February becomes the
+// 28th in common years.
+func nellAnno(oggi time.Time, anno int) time.Time {
+	d := oggi.AddDate(anno-oggi.Year(), 0, 0)
+	if d.Month() != oggi.Month() {
+		d = d.AddDate(0, 0, -d.Day())
+	}
+	return d
+}
+
+// stimaISEERequest are the DSU components plus, optionally, the profile
+// to fill with the estimate (ISEE, or ISEESimulato when Simulato is set).
+type stimaISEERequest struct {
+	calc.NucleoISEE
+	Profilo  *models.UserProfile `json:"profilo,omitempty"`
+	Simulato bool                `json:"simulato,omitempty"`
+}
+
+type stimaISEEResponse struct {
+	calc.StimaISEE
+	Profilo *models.UserProfile `json:"profilo,omitempty"`
+}
+
+// StimaISEEHandler serves POST /api/calc/isee.
+// Estimates the ISEE from household incomes, assets, rent and composition
+// for users who don't have an attestation yet.
+func StimaISEEHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req stimaISEERequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if msg, ok := validateNucleoISEE(req.NucleoISEE); !ok {
+		i18n.Error(w, r, msg, http.StatusBadRequest)
+		return
+	}
+
+	resp := stimaISEEResponse{StimaISEE: calc.StimaISEEDaNucleo(req.NucleoISEE)}
+	if req.Profilo != nil {
+		p := *req.Profilo
+		if req.Simulato {
+			p.ISEESimulato = resp.ISEE
+		} else {
+			p.ISEE = resp.ISEE
+		}
+		if msg, ok := validateProfile(p); !ok {
+			i18n.Error(w, r, msg, http.StatusBadRequest)
+			return
+		}
+		resp.Profilo = &p
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func validateNucleoISEE(n calc.NucleoISEE) (i18n.Message, bool) {
+	if len(n.Componenti) == 0 || len(n.Componenti) > 20 {
+		return i18n.M("nucleo.componenti", "min", 1, "max", 20), false
+	}
+	if n.NumeroFigli < 0 || n.FigliMinorenni < 0 || n.FigliUnder3 < 0 || n.NumeroFigli >= len(n.Componenti) {
+		return i18n.M("nucleo.figli"), false
+	}
+	if n.FigliMinorenni > n.NumeroFigli || n.FigliUnder3 > n.FigliMinorenni {
+		return i18n.M("nucleo.figli_eta"), false
+	}
+	neg := n.CanoneAffitto < 0 || n.PatrimonioMobiliare < 0 || n.TitoliStato < 0
+	for _, c := range n.Componenti {
+		neg = neg || c.RedditoDipendente < 0 || c.RedditoPensione < 0 || c.RedditoAltro < 0
+	}
+	for _, im := range n.Immobili {
+		neg = neg || im.ValoreIMU < 0 || im.MutuoResiduo < 0
+	}
+	if neg {
+		return i18n.M("nucleo.importi_negativi"), false
+	}
+	return i18n.Message{}, true
+}
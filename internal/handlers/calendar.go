@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/clock"
+	"bonusperme/internal/deadline"
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/matcher"
+	"bonusperme/internal/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// icsEvent is one VEVENT. All-day events span [Start, End) by date; timed
+// events (click day) carry the wall clock time in Italy, written with
+// TZID=Europe/Rome.
+type icsEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start, End  time.Time
+	Timed       bool
+	Annuale     bool // RRULE:FREQ=YEARLY
+	Alarms      []icsAlarm
+}
+
+type icsAlarm struct {
+	Trigger     string // e.g. -P7D
+	Description string
+}
+
+// vtimezoneRome defines the TZID of the timed events: CET, and CEST from
+// the last Sunday of March to the last Sunday of October.
+var vtimezoneRome = []string{
+	"BEGIN:VTIMEZONE",
+	"TZID:Europe/Rome",
+	"BEGIN:DAYLIGHT",
+	"TZOFFSETFROM:+0100",
+	"TZOFFSETTO:+0200",
+	"TZNAME:CEST",
+	"DTSTART:19700329T020000",
+	"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+	"END:DAYLIGHT",
+	"BEGIN:STANDARD",
+	"TZOFFSETFROM:+0200",
+	"TZOFFSETTO:+0100",
+	"TZNAME:CET",
+	"DTSTART:19701025T030000",
+	"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+	"END:STANDARD",
+	"END:VTIMEZONE",
+}
+
+// calendarFeed describes the VCALENDAR envelope of the feed.
+type calendarFeed struct {
+	Nome     string
+	Modified time.Time
+}
+
+// writeCalendar renders a VCALENDAR. stamp is the DTSTAMP of every event.
+func writeCalendar(sb *strings.Builder, feed *calendarFeed, events []icsEvent, stamp time.Time) {
+	line := func(s string) {
+		sb.WriteString(foldICS(s))
+		sb.WriteString("\r\n")
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//BonusPerMe//IT")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if feed != nil {
+		line("X-WR-CALNAME:" + escapeICS(feed.Nome))
+		line("X-WR-TIMEZONE:Europe/Rome")
+		line("REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+		line("X-PUBLISHED-TTL:PT12H")
+	}
+	for _, e := range events {
+		if e.Timed {
+			for _, l := range vtimezoneRome {
+				line(l)
+			}
+			break
+		}
+	}
+
+	dtstamp := stamp.UTC().Format("20060102T150405Z")
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + dtstamp)
+		if feed != nil && !feed.Modified.IsZero() {
+			line("LAST-MODIFIED:" + feed.Modified.UTC().Format("20060102T150405Z"))
+		}
+		if e.Timed {
+			line("DTSTART;TZID=Europe/Rome:" + e.Start.Format("20060102T150405"))
+			line("DTEND;TZID=Europe/Rome:" + e.End.Format("20060102T150405"))
+		} else {
+			line("DTSTART;VALUE=DATE:" + e.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + e.End.Format("20060102"))
+		}
+		if e.Annuale {
+			line("RRULE:FREQ=YEARLY")
+		}
+		line("SUMMARY:" + escapeICS(e.Summary))
+		line("DESCRIPTION:" + escapeICS(e.Description))
+		for _, a := range e.Alarms {
+			line("BEGIN:VALARM")
+			line("TRIGGER:" + a.Trigger)
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:" + escapeICS(a.Description))
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeICS escapes a TEXT value (RFC 5545 §3.3.11).
+func escapeICS(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+// foldICS folds a content line longer than 75 octets (RFC 5545 §3.1),
+// without splitting UTF-8 sequences.
+func foldICS(s string) string {
+	if len(s) <= 75 {
+		return s
+	}
+	var sb strings.Builder
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > 75 {
+			sb.WriteString("\r\n ")
+			n = 1
+		}
+		sb.WriteRune(r)
+		n += size
+	}
+	return sb.String()
+}
+
+// eventiBonus returns the calendar events of a bonus deadline window: the
+// opening of the applications (spanning the window for bandi, at the hour
+// for click days) and the closing date. Yearly windows recur. suffix makes
+// UIDs unique per subscription while staying stable across fetches.
+func eventiBonus(id, nome string, f models.Finestra, suffix string) []icsEvent {
+	if f.Stato == models.FinestraChiusa {
+		return nil
+	}
+	var out []icsEvent
+	ap, ch := deadline.Apertura(f), deadline.Chiusura(f)
+	ch = time.Date(ch.Year(), ch.Month(), ch.Day(), 0, 0, 0, 0, time.UTC)
+
+	if !ap.IsZero() && (f.Stato == models.FinestraInApertura || f.Ricorrente) {
+		e := icsEvent{
+			UID:         id + "-apertura" + suffix + "@bonusperme.it",
+			Summary:     "Apertura domande: " + nome,
+			Description: "Si aprono le domande per " + nome + ". Verifica requisiti su BonusPerMe.",
+			Start:       ap,
+			End:         ap.AddDate(0, 0, 1),
+			Annuale:     f.Ricorrente,
+			Alarms: []icsAlarm{
+				{"-P7D", "Tra 7 giorni si aprono le domande: " + nome},
+				{"-P1D", "Domande aperte da domani: " + nome},
+			},
+		}
+		switch {
+		case f.Ora != "":
+			e.Timed = true
+			e.End = ap.Add(time.Hour)
+			e.Description = "Click day per " + nome + " alle ore " + f.Ora + ": i fondi si esauriscono in fretta, prepara SPID e documenti."
+			e.Alarms = append(e.Alarms, icsAlarm{"-PT1H", "Tra un'ora il click day: " + nome})
+		case !ch.IsZero():
+			// Bando: the event spans the whole window
+			e.Summary = "Domande aperte: " + nome
+			e.End = ch.AddDate(0, 0, 1)
+		}
+		out = append(out, e)
+	}
+
+	if !ch.IsZero() {
+		out = append(out, icsEvent{
+			UID:         id + "-scadenza" + suffix + "@bonusperme.it",
+			Summary:     "Scadenza: " + nome,
+			Description: "Ricorda di presentare domanda per " + nome + " prima della scadenza. Verifica requisiti su BonusPerMe.",
+			Start:       ch,
+			End:         ch.AddDate(0, 0, 1),
+			Annuale:     f.Ricorrente,
+			Alarms: []icsAlarm{
+				{"-P7D", "Scadenza tra 7 giorni: " + nome},
+				{"-P1D", "Scadenza domani: " + nome},
+			},
+		})
+	}
+	return out
+}
+
+// eventiISEE returns the yearly ISEE renewal reminders: attestations
+// expire on 31 December, and the Assegno Unico drops to the minimum from
+// March without a new DSU.
+func eventiISEE(now time.Time, assegnoUnico bool, suffix string) []icsEvent {
+	prossimo := func(m time.Month, d int) time.Time {
+		t := time.Date(now.Year(), m, d, 0, 0, 0, 0, time.UTC)
+		if t.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+			t = t.AddDate(1, 0, 0)
+		}
+		return t
+	}
+	scade := prossimo(time.December, 31)
+	out := []icsEvent{{
+		UID:         "isee-scadenza" + suffix + "@bonusperme.it",
+		Summary:     "ISEE in scadenza",
+		Description: "L'attestazione ISEE scade il 31 dicembre. Dal 1 gennaio presenta una nuova DSU (online sul sito INPS o al CAF) per continuare a ricevere i bonus.",
+		Start:       scade,
+		End:         scade.AddDate(0, 0, 1),
+		Annuale:     true,
+		Alarms: []icsAlarm{
+			{"-P30D", "L'ISEE scade tra un mese: prepara i documenti per la nuova DSU"},
+			{"-P7D", "L'ISEE scade tra 7 giorni"},
+		},
+	}}
+	if assegnoUnico {
+		fine := prossimo(time.February, 28)
+		out = append(out, icsEvent{
+			UID:         "isee-assegno-unico" + suffix + "@bonusperme.it",
+			Summary:     "Rinnova l'ISEE per l'Assegno Unico",
+			Description: "Senza un ISEE valido entro fine febbraio, da marzo l'Assegno Unico viene pagato nell'importo minimo. Con la DSU presentata entro il 30 giugno ricevi gli arretrati.",
+			Start:       fine,
+			End:         fine.AddDate(0, 0, 1),
+			Annuale:     true,
+			Alarms: []icsAlarm{
+				{"-P14D", "Rinnova l'ISEE entro fine febbraio per l'Assegno Unico"},
+			},
+		})
+	}
+	return out
+}
+
+// CalendarFeedHandler serves GET /api/calendar/feed/<codice>.ics, a
+// calendar to subscribe to (webcal://). Every fetch re-runs the match for
+// the profile code against the current catalog, so moved deadlines and new
+// bonuses reach the subscribed calendar. UIDs depend only on the bonus and
+// the code.
+func CalendarFeedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	code := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/calendar/feed/"), ".ics")
+	profile, msg, ok := decodeProfileCode(code)
+	if !ok {
+		i18n.Error(w, r, msg, http.StatusBadRequest)
+		return
+	}
+
+	now := clock.Now()
+	cachedBonus := matchableBonus()
+	result := matcher.MatchBonusAt(profile, now, cachedBonus)
+
+	sum := sha256.Sum256([]byte(code))
+	suffix := "-" + hex.EncodeToString(sum[:4])
+
+	var events []icsEvent
+	assegnoUnico := false
+	for _, b := range result.Bonus {
+		if b.Scaduto || b.Finestra == nil {
+			continue
+		}
+		assegnoUnico = assegnoUnico || b.ID == "assegno-unico"
+		events = append(events, eventiBonus(b.ID, b.Nome, *b.Finestra, suffix)...)
+	}
+	if profile.ISEE > 0 || assegnoUnico {
+		events = append(events, eventiISEE(now, assegnoUnico, suffix)...)
+	}
+
+	feed := &calendarFeed{Nome: "BonusPerMe - Le tue scadenze"}
+	if snap := catalog.Current(); snap != nil {
+		feed.Modified = snap.LoadedAt
+	}
+	var sb strings.Builder
+	writeCalendar(&sb, feed, events, now)
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="bonusperme.ics"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(sb.String()))
+}
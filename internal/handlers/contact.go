@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bonusperme/internal/config"
+	"bonusperme/internal/i18n"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -192,6 +193,15 @@ type contactRequest struct {
 	Messaggio string `json:"messaggio"`
 }
 
+// formError answers a form submission that was refused. Forms always get
+// 200 and {"ok": false}; the message is in the language of the request.
+func formError(w http.ResponseWriter, r *http.Request, m i18n.Message) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok": false, "error": m.Text(i18n.FromRequest(r)), "codice": m.Code,
+	})
+}
+
 // ContactHandler handles POST /api/contact
 func ContactHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -200,15 +210,13 @@ func ContactHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !verifyTurnstile(getTurnstileToken(r)) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Verifica di sicurezza non superata"})
+		formError(w, r, i18n.M("richiesta.verifica_sicurezza"))
 		return
 	}
 
 	var req contactRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Dati non validi"})
+		formError(w, r, i18n.M("modulo.dati"))
 		return
 	}
 	defer r.Body.Close()
@@ -218,13 +226,11 @@ func ContactHandler(w http.ResponseWriter, r *http.Request) {
 	req.Messaggio = strings.TrimSpace(req.Messaggio)
 
 	if req.Nome == "" || req.Email == "" || req.Messaggio == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Compila tutti i campi obbligatori"})
+		formError(w, r, i18n.M("modulo.campi_obbligatori"))
 		return
 	}
 	if !strings.Contains(req.Email, "@") {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Email non valida"})
+		formError(w, r, i18n.M("modulo.email"))
 		return
 	}
 
@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/json"
+	"bonusperme/internal/i18n"
 	"net/http"
 	"strings"
 )
@@ -9,9 +9,7 @@ import (
 // NotFoundHandler serves a styled 404 page or JSON error for API routes.
 func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
 	if strings.HasPrefix(r.URL.Path, "/api/") {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]string{"error": "endpoint not found"})
+		i18n.Error(w, r, i18n.M("richiesta.endpoint"), http.StatusNotFound)
 		return
 	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -22,9 +20,7 @@ func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
 // InternalErrorHandler serves a styled 500 page or JSON error for API routes.
 func InternalErrorHandler(w http.ResponseWriter, r *http.Request) {
 	if strings.HasPrefix(r.URL.Path, "/api/") {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "internal server error"})
+		i18n.Error(w, r, i18n.M("server.errore"), http.StatusInternalServerError)
 		return
 	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -1,68 +1,29 @@
 package handlers
 
 import (
-	"bonusperme/internal/linkcheck"
+	"bonusperme/internal/clock"
+	"bonusperme/internal/deadline"
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/istat"
+	"bonusperme/internal/logger"
 	"bonusperme/internal/matcher"
 	"bonusperme/internal/models"
-	"bonusperme/internal/scraper"
+	"bonusperme/internal/nucleo"
+	"bonusperme/internal/questionario"
 	sentryutil "bonusperme/internal/sentry"
-	"bonusperme/internal/validity"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"regexp"
-	"strconv"
 	"strings"
-	"time"
 
-	"github.com/jung-kurt/gofpdf"
+	"github.com/boombuler/barcode/qr"
 )
 
 // ---------- helpers ----------
 
-var italianMonths = map[string]int{
-	"gennaio":   1,
-	"febbraio":  2,
-	"marzo":     3,
-	"aprile":    4,
-	"maggio":    5,
-	"giugno":    6,
-	"luglio":    7,
-	"agosto":    8,
-	"settembre": 9,
-	"ottobre":   10,
-	"novembre":  11,
-	"dicembre":  12,
-}
-
-var italianDateRe = regexp.MustCompile(
-	`(\d{1,2})\s+(gennaio|febbraio|marzo|aprile|maggio|giugno|luglio|agosto|settembre|ottobre|novembre|dicembre)\s+(\d{4})`,
-)
-
-var slashDateRe = regexp.MustCompile(`(\d{2})/(\d{2})/(\d{4})`)
-
-func parseItalianDate(s string) time.Time {
-	lower := strings.ToLower(s)
-
-	if m := italianDateRe.FindStringSubmatch(lower); len(m) == 4 {
-		day, _ := strconv.Atoi(m[1])
-		month := italianMonths[m[2]]
-		year, _ := strconv.Atoi(m[3])
-		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
-	}
-
-	if m := slashDateRe.FindStringSubmatch(s); len(m) == 4 {
-		day, _ := strconv.Atoi(m[1])
-		month, _ := strconv.Atoi(m[2])
-		year, _ := strconv.Atoi(m[3])
-		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
-	}
-
-	return time.Date(time.Now().Year(), 12, 31, 0, 0, 0, 0, time.UTC)
-}
-
 func slugifyName(s string) string {
 	s = strings.ToLower(s)
 	s = strings.Map(func(r rune) rune {
@@ -77,115 +38,63 @@ func slugifyName(s string) string {
 	return strings.Trim(s, "-")
 }
 
-func transliterate(s string) string {
-	replacer := strings.NewReplacer(
-		"à", "a", "è", "e", "é", "e", "ì", "i", "ò", "o", "ù", "u",
-		"À", "A", "È", "E", "É", "E", "Ì", "I", "Ò", "O", "Ù", "U",
-		"\u2264", "<=", "\u2265", ">=",
-		"€", "EUR ", "–", "-", "\u2018", "'", "\u2019", "'",
-		"\u201C", "\"", "\u201D", "\"",
-	)
-	return replacer.Replace(s)
-}
-
-func parseEuroAmount(s string) float64 {
-	re := regexp.MustCompile(`[0-9][0-9.,]*`)
-	m := re.FindString(s)
-	if m == "" {
-		return 0
-	}
-	m = strings.ReplaceAll(m, ".", "")
-	m = strings.Replace(m, ",", ".", 1)
-	v, _ := strconv.ParseFloat(m, 64)
-	return v
-}
-
 // ---------- validateProfile ----------
 
-// Whitelists for enum fields
-var validResidenza = map[string]bool{
-	"": true, "Abruzzo": true, "Basilicata": true, "Calabria": true,
-	"Campania": true, "Emilia-Romagna": true, "Friuli-Venezia Giulia": true,
-	"Lazio": true, "Liguria": true, "Lombardia": true, "Marche": true,
-	"Molise": true, "Piemonte": true, "Puglia": true, "Sardegna": true,
-	"Sicilia": true, "Toscana": true, "Trentino-Alto Adige": true,
-	"Umbria": true, "Valle d'Aosta": true, "Veneto": true,
-}
-
-var validStatoCivile = map[string]bool{
-	"": true, "celibe/nubile": true, "coniugato/a": true,
-	"convivente": true, "separato/a": true, "divorziato/a": true,
-	"vedovo/a": true, "unione civile": true,
-}
-
-var validOccupazione = map[string]bool{
-	"": true, "dipendente": true, "autonomo": true,
-	"disoccupato": true, "pensionato": true, "studente": true,
-	"casalinga": true, "inoccupato": true,
-}
-
-var validDisabilitaFigli = map[string]bool{
-	"": true, "media": true, "grave": true, "non_autosufficienza": true,
-}
-
-func validateProfile(p models.UserProfile) (string, bool) {
-	if p.Eta < 18 || p.Eta > 120 {
-		return "Eta non valida (18-120)", false
-	}
-	if p.ISEE < 0 || p.ISEE > 500000 {
-		return "ISEE non valido (0-500000)", false
-	}
-	if p.RedditoAnnuo < 0 || p.RedditoAnnuo > 1000000 {
-		return "Reddito annuo non valido (0-1000000)", false
-	}
-	if p.NumeroFigli < 0 || p.NumeroFigli > 20 {
-		return "Numero figli non valido (0-20)", false
-	}
-	if p.FigliMinorenni < 0 || p.FigliMinorenni > 20 {
-		return "Figli minorenni non valido (0-20)", false
-	}
-	if p.FigliUnder3 < 0 || p.FigliUnder3 > 20 {
-		return "Figli under 3 non valido (0-20)", false
-	}
-	if p.FigliUnder1 < 0 || p.FigliUnder1 > 20 {
-		return "Figli under 1 non valido (0-20)", false
-	}
-	if p.FigliMaggiorenni < 0 || p.FigliMaggiorenni > 20 {
-		return "Figli maggiorenni non valido (0-20)", false
-	}
-	if p.FigliDisabili < 0 || p.FigliDisabili > 20 {
-		return "Figli disabili non valido (0-20)", false
-	}
-	if p.Over65 < 0 || p.Over65 > 10 {
-		return "Over 65 non valido (0-10)", false
-	}
-	// Cross-field checks
-	if p.FigliUnder1 > p.FigliUnder3 {
-		return "Figli under 1 non puo superare figli under 3", false
-	}
-	if p.FigliUnder3 > p.FigliMinorenni {
-		return "Figli under 3 non puo superare figli minorenni", false
-	}
-	if p.FigliMinorenni+p.FigliMaggiorenni > p.NumeroFigli {
-		return "Figli minorenni + maggiorenni non puo superare numero figli", false
-	}
-	if p.FigliDisabili > p.NumeroFigli {
-		return "Figli disabili non puo superare numero figli", false
-	}
-	// Whitelist checks
-	if !validDisabilitaFigli[p.DisabilitaFigli] {
-		return "Grado disabilita figli non valido", false
+var validRelazione = func() map[string]bool {
+	m := map[string]bool{}
+	for _, r := range nucleo.Relazioni {
+		m[r] = true
+	}
+	return m
+}()
+
+// validateProfile checks a profile against the questionnaire, which holds
+// the bounds, allowed values and cross-field rules of every field; only
+// the household members and the comune-regione match are checked here.
+func validateProfile(p models.UserProfile) (i18n.Message, bool) {
+	// Household members replace the children and over 65 counters, which
+	// are derived from them for today and then checked like typed ones
+	if len(p.Nucleo) > 0 {
+		if msg, ok := validateNucleo(p.Nucleo); !ok {
+			return msg, false
+		}
+		p = nucleo.Deriva(p, clock.Now())
 	}
-	if !validResidenza[p.Residenza] {
-		return "Regione non valida", false
+	if msg, ok := questionario.Valida(p); !ok {
+		return msg, false
 	}
-	if !validStatoCivile[p.StatoCivile] {
-		return "Stato civile non valido", false
+	// A known comune, when given with the regione, must lie in it
+	if c, ok := istat.Find(p.Comune); ok && p.Residenza != "" {
+		if regione := istat.CodiceRegione(p.Residenza); !istat.Copre(regione, c.Residenza) {
+			return i18n.M("profilo.comune_regione", "comune", c.Nome, "regione", istat.NomeRegione(c.Residenza)), false
+		}
 	}
-	if !validOccupazione[p.Occupazione] {
-		return "Occupazione non valida", false
+	return i18n.Message{}, true
+}
+
+// validateNucleo checks the household members; n in the messages counts
+// members from 1.
+func validateNucleo(membri []models.Componente) (i18n.Message, bool) {
+	if len(membri) > nucleo.MaxComponenti {
+		return i18n.M("profilo.nucleo", "max", nucleo.MaxComponenti), false
+	}
+	oggi := clock.Now()
+	for i, c := range membri {
+		n := i + 1
+		if !validRelazione[c.Relazione] {
+			return i18n.M("profilo.componente_relazione", "n", n), false
+		}
+		// Only children may be expected, up to a year ahead
+		d, err := nucleo.Nascita(c)
+		if err != nil || d.Before(oggi.AddDate(-120, 0, 0)) || d.After(oggi.AddDate(1, 0, 0)) ||
+			(d.After(oggi) && c.Relazione != nucleo.Figlio) {
+			return i18n.M("profilo.componente_nascita", "n", n), false
+		}
+		if !questionario.Ammesso("disabilita_figli", c.Disabilita) || !questionario.Ammesso("occupazione", c.Occupazione) || c.Reddito < 0 || c.Reddito > 1000000 {
+			return i18n.M("profilo.componente_dati", "n", n), false
+		}
 	}
-	return "", true
+	return i18n.Message{}, true
 }
 
 // ---------- 1. CalendarHandler ----------
@@ -202,12 +111,15 @@ func CalendarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Finestra is the deadline computed for the user by /api/match; items
+	// without it are read from the Scadenza text.
 	var items []struct {
-		Nome     string `json:"nome"`
-		Scadenza string `json:"scadenza"`
+		Nome     string           `json:"nome"`
+		Scadenza string           `json:"scadenza"`
+		Finestra *models.Finestra `json:"finestra"`
 	}
 	if err := json.Unmarshal([]byte(raw), &items); err != nil {
-		http.Error(w, "Invalid bonuses JSON", http.StatusBadRequest)
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
 		return
 	}
 
@@ -216,52 +128,29 @@ func CalendarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := time.Now().UTC().Format("20060102T150405Z")
-
-	var sb strings.Builder
-	sb.WriteString("BEGIN:VCALENDAR\r\n")
-	sb.WriteString("VERSION:2.0\r\n")
-	sb.WriteString("PRODID:-//BonusPerMe//IT\r\n")
-	sb.WriteString("CALSCALE:GREGORIAN\r\n")
-	sb.WriteString("METHOD:PUBLISH\r\n")
-
-	validCount := 0
+	oggi := clock.Now()
+	var events []icsEvent
 	for _, item := range items {
 		if item.Nome == "" {
 			continue
 		}
-		validCount++
-		dt := parseItalianDate(item.Scadenza)
-		dtStr := dt.Format("20060102")
-		uid := slugifyName(item.Nome) + "@bonusperme.it"
-
-		sb.WriteString("BEGIN:VEVENT\r\n")
-		sb.WriteString("UID:" + uid + "\r\n")
-		sb.WriteString("DTSTAMP:" + now + "\r\n")
-		sb.WriteString("DTSTART;VALUE=DATE:" + dtStr + "\r\n")
-		sb.WriteString("DTEND;VALUE=DATE:" + dtStr + "\r\n")
-		sb.WriteString("SUMMARY:Scadenza: " + item.Nome + "\r\n")
-		sb.WriteString("DESCRIPTION:Ricorda di presentare domanda per " + item.Nome + " prima della scadenza. Verifica requisiti su BonusPerMe.\r\n")
-		sb.WriteString("BEGIN:VALARM\r\n")
-		sb.WriteString("TRIGGER:-P7D\r\n")
-		sb.WriteString("ACTION:DISPLAY\r\n")
-		sb.WriteString("DESCRIPTION:Scadenza tra 7 giorni: " + item.Nome + "\r\n")
-		sb.WriteString("END:VALARM\r\n")
-		sb.WriteString("BEGIN:VALARM\r\n")
-		sb.WriteString("TRIGGER:-P1D\r\n")
-		sb.WriteString("ACTION:DISPLAY\r\n")
-		sb.WriteString("DESCRIPTION:Scadenza domani: " + item.Nome + "\r\n")
-		sb.WriteString("END:VALARM\r\n")
-		sb.WriteString("END:VEVENT\r\n")
-	}
-
-	sb.WriteString("END:VCALENDAR\r\n")
-
-	if validCount == 0 {
+		var f models.Finestra
+		if item.Finestra != nil {
+			f = *item.Finestra
+		} else {
+			f = deadline.Calcola(deadline.Deriva(item.Scadenza), models.UserProfile{}, oggi)
+		}
+		// Bonuses with no date have nothing to remind
+		events = append(events, eventiBonus(slugifyName(item.Nome), item.Nome, f, "")...)
+	}
+	if len(events) == 0 {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
+	var sb strings.Builder
+	writeCalendar(&sb, nil, events, oggi)
+
 	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
 	w.Header().Set("Content-Disposition", `attachment; filename="bonusperme-scadenze.ics"`)
 	w.Write([]byte(sb.String()))
@@ -269,59 +158,150 @@ func CalendarHandler(w http.ResponseWriter, r *http.Request) {
 
 // ---------- 2. SimulateHandler ----------
 
+// simulateRequest is the profile plus, optionally, an ISEE range to sweep
+// and what-if scenarios. Modifiche is a shorthand for a single scenario.
+type simulateRequest struct {
+	models.UserProfile
+	Sweep     *sweepRange            `json:"sweep,omitempty"`
+	Modifiche map[string]interface{} `json:"modifiche,omitempty"`
+	Scenari   []scenarioRequest      `json:"scenari,omitempty"`
+}
+
+type scenarioRequest struct {
+	Nome      string                 `json:"nome"`
+	Modifiche map[string]interface{} `json:"modifiche"`
+}
+
+const maxScenari = 10
+
+type sweepRange struct {
+	Da    float64 `json:"da"`
+	A     float64 `json:"a"`
+	Passo float64 `json:"passo"`
+}
+
+// Sweep limits: ISEE 0-100.000 by default in €500 steps, at most 400 steps.
+const (
+	sweepA        = 100000
+	sweepPasso    = 500
+	sweepMaxPunti = 400
+)
+
 func SimulateHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	var profile models.UserProfile
-	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var req simulateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
 		return
 	}
 	defer r.Body.Close()
+	profile := req.UserProfile
 
 	if msg, ok := validateProfile(profile); !ok {
-		http.Error(w, msg, http.StatusBadRequest)
+		i18n.Error(w, r, msg, http.StatusBadRequest)
+		return
+	}
+	asOf, custom, ok := parseAsOf(r)
+	if !ok {
+		i18n.Error(w, r, i18n.M("richiesta.as_of"), http.StatusBadRequest)
 		return
 	}
+	scenari := req.Scenari
+	if len(req.Modifiche) > 0 {
+		scenari = append([]scenarioRequest{{Nome: "Scenario", Modifiche: req.Modifiche}}, scenari...)
+	}
+	if len(scenari) > maxScenari {
+		i18n.Error(w, r, i18n.M("simulazione.troppi_scenari", "max", maxScenari), http.StatusBadRequest)
+		return
+	}
+	scenarioProfiles := make([]models.UserProfile, len(scenari))
+	for i, sc := range scenari {
+		if sc.Nome == "" {
+			sc.Nome = fmt.Sprintf("Scenario %d", i+1)
+			scenari[i].Nome = sc.Nome
+		}
+		p, err := matcher.ApplyOverrides(profile, sc.Modifiche)
+		var cerr *matcher.ContatoreNucleoError
+		if errors.As(err, &cerr) {
+			i18n.Error(w, r, i18n.M("simulazione.contatore_nucleo", "scenario", sc.Nome, "campo", cerr.Campo,
+				"etichetta", questionario.Etichetta(cerr.Campo, i18n.FromRequest(r))), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			i18n.Error(w, r, i18n.M("simulazione.modifiche", "scenario", sc.Nome), http.StatusBadRequest)
+			return
+		}
+		if msg, ok := validateProfile(p); !ok {
+			i18n.Error(w, r, i18n.M("simulazione.scenario", "scenario", sc.Nome, "motivo", msg.Text(i18n.FromRequest(r)), "causa", msg.Code), http.StatusBadRequest)
+			return
+		}
+		scenarioProfiles[i] = p
+	}
+	if sw := req.Sweep; sw != nil {
+		if sw.A == 0 {
+			sw.A = sweepA
+		}
+		if sw.Passo == 0 {
+			sw.Passo = sweepPasso
+		}
+		if sw.Da < 0 || sw.A > 500000 || sw.Da >= sw.A || sw.Passo < 1 || (sw.A-sw.Da)/sw.Passo > sweepMaxPunti {
+			i18n.Error(w, r, i18n.M("simulazione.sweep", "max", 500000, "passi", sweepMaxPunti), http.StatusBadRequest)
+			return
+		}
+	}
 
-	cachedBonus := scraper.GetCachedBonus()
+	// Territorial bonuses are included so scenarios can change residenza
+	cachedBonus := matchableBonus()
 
-	reale := matcher.MatchBonus(profile, cachedBonus)
-	linkcheck.ApplyStatus(reale.Bonus)
-	validity.ApplyStatus(reale.Bonus)
-	reale.Avvisi = validity.GenerateAvvisi(reale.Bonus)
+	reale := matcher.MatchBonusAt(profile, asOf, cachedBonus)
+	applyStatus(&reale, asOf, custom)
 
 	simProfile := profile
 	simProfile.ISEE = profile.ISEESimulato
-	simulato := matcher.MatchBonus(simProfile, cachedBonus)
-	linkcheck.ApplyStatus(simulato.Bonus)
-	validity.ApplyStatus(simulato.Bonus)
-	simulato.Avvisi = validity.GenerateAvvisi(simulato.Bonus)
+	simulato := matcher.MatchBonusAt(simProfile, asOf, cachedBonus)
+	applyStatus(&simulato, asOf, custom)
 
 	bonusExtra := simulato.BonusTrovati - reale.BonusTrovati
 	if bonusExtra < 0 {
 		bonusExtra = 0
 	}
 
-	risparmioReale := parseEuroAmount(reale.RisparmioStimato)
-	risparmioSim := parseEuroAmount(simulato.RisparmioStimato)
-	extraVal := risparmioSim - risparmioReale
+	extraVal := simulato.RisparmioStimatoEuro - reale.RisparmioStimatoEuro
 	if extraVal < 0 {
 		extraVal = 0
 	}
 
 	result := models.SimulateResult{
-		Reale:          reale,
-		Simulato:       simulato,
-		BonusExtra:     bonusExtra,
-		RisparmioExtra: fmt.Sprintf("EUR %.0f", extraVal),
+		Reale:              reale,
+		Simulato:           simulato,
+		BonusExtra:         bonusExtra,
+		RisparmioExtra:     fmt.Sprintf("EUR %.0f", extraVal),
+		RisparmioExtraEuro: math.Round(extraVal*100) / 100,
+	}
+	if sw := req.Sweep; sw != nil {
+		sweep := matcher.SweepISEE(profile, cachedBonus, asOf, sw.Da, sw.A, sw.Passo)
+		result.Sweep = &sweep
 	}
+	for i, sc := range scenari {
+		sim := matcher.MatchBonusAt(scenarioProfiles[i], asOf, cachedBonus)
+		result.Scenari = append(result.Scenari, models.Scenario{
+			Nome:      sc.Nome,
+			Modifiche: sc.Modifiche,
+			Diff:      matcher.DiffResults(reale, sim),
+		})
+	}
+
+	lang := i18n.FromRequest(r)
+	localizeResult(&result.Reale, lang)
+	localizeResult(&result.Simulato, lang)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Vary", "Accept-Language")
 	json.NewEncoder(w).Encode(result)
 }
 
@@ -363,9 +343,9 @@ const (
 	contentW = pageW - marginL - marginR // 170mm
 )
 
-func setFill(pdf *gofpdf.Fpdf, c [3]int)  { pdf.SetFillColor(c[0], c[1], c[2]) }
-func setText(pdf *gofpdf.Fpdf, c [3]int)   { pdf.SetTextColor(c[0], c[1], c[2]) }
-func setDraw(pdf *gofpdf.Fpdf, c [3]int)   { pdf.SetDrawColor(c[0], c[1], c[2]) }
+func setFill(pdf *reportPDF, c [3]int)  { pdf.SetFillColor(c[0], c[1], c[2]) }
+func setText(pdf *reportPDF, c [3]int)   { pdf.SetTextColor(c[0], c[1], c[2]) }
+func setDraw(pdf *reportPDF, c [3]int)   { pdf.SetDrawColor(c[0], c[1], c[2]) }
 
 func fmtEuro(amount float64) string {
 	if amount == 0 {
@@ -388,6 +368,48 @@ func fmtEuro(amount float64) string {
 	return prefix + s
 }
 
+// fmtValore formats a typed amount in the report language, e.g.
+// "EUR 60/mese x 12" or "detrazione EUR 500/anno x 10". Returns "" when
+// there is no estimate.
+func fmtValore(pdf *reportPDF, v *models.Beneficio) string {
+	if v == nil || v.Atteso <= 0 {
+		return ""
+	}
+	s := "EUR " + fmtEuro(v.Atteso)
+	switch v.Tipo {
+	case models.TipoDetrazione:
+		s = pdf.T("pdf.val_deduction", s)
+	case models.TipoGaranzia:
+		s = pdf.T("pdf.val_guarantee", s)
+	case models.TipoEsoneroContributivo:
+		s = pdf.T("pdf.val_exemption", s)
+	}
+	switch v.Periodicita {
+	case models.PeriodicitaMensile:
+		s += pdf.T("pdf.per_month")
+		if v.Durata > 0 {
+			s += fmt.Sprintf(" x %d", v.Durata)
+		}
+	case models.PeriodicitaAnnuale:
+		s += pdf.T("pdf.per_year")
+		if v.Durata > 1 {
+			s += fmt.Sprintf(" x %d", v.Durata)
+		}
+	default:
+		s += " " + pdf.T("pdf.one_off")
+	}
+	return s
+}
+
+// truncRunes shortens s to at most n characters, ending with "...".
+func truncRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
+
 func addDotSep(s string) string {
 	n := len(s)
 	if n <= 3 {
@@ -417,7 +439,7 @@ func truncURL(url string, max int) string {
 }
 
 // ensureSpace checks if there's enough room; if not, adds a page.
-func ensureSpace(pdf *gofpdf.Fpdf, needed float64) float64 {
+func ensureSpace(pdf *reportPDF, needed float64) float64 {
 	y := pdf.GetY()
 	if y+needed > pageH-25 {
 		pdf.AddPage()
@@ -426,26 +448,48 @@ func ensureSpace(pdf *gofpdf.Fpdf, needed float64) float64 {
 	return y
 }
 
+// drawQR draws content as a QR code of side size at (x, y), one filled
+// square per module so it stays sharp when printed. The code itself is
+// never mirrored.
+func drawQR(pdf *reportPDF, content string, x, y, size float64) error {
+	code, err := qr.Encode(content, qr.M, qr.AlphaNumeric)
+	if err != nil {
+		return err
+	}
+	b := code.Bounds()
+	module := size / float64(b.Dx())
+	x = pdf.mirror(x, size)
+	setFill(pdf, cInk90)
+	for row := b.Min.Y; row < b.Max.Y; row++ {
+		for col := b.Min.X; col < b.Max.X; col++ {
+			if r, _, _, _ := code.At(col, row).RGBA(); r == 0 {
+				pdf.Fpdf.Rect(x+float64(col-b.Min.X)*module, y+float64(row-b.Min.Y)*module, module, module, "F")
+			}
+		}
+	}
+	return nil
+}
+
 // drawAccentBar draws a vertical colored bar on the left side of a bonus section.
-func drawAccentBar(pdf *gofpdf.Fpdf, x, startY, endY float64, c [3]int) {
+func drawAccentBar(pdf *reportPDF, x, startY, endY float64, c [3]int) {
 	setFill(pdf, c)
 	pdf.Rect(x, startY, 2.5, endY-startY, "F")
 }
 
 // drawPill draws a rounded pill label.
-func drawPill(pdf *gofpdf.Fpdf, x, y float64, text string, bg, fg [3]int) float64 {
+func drawPill(pdf *reportPDF, x, y float64, text string, bg, fg [3]int) float64 {
 	pdf.SetFont("Helvetica", "B", 7.5)
-	w := pdf.GetStringWidth(transliterate(text)) + 8
+	w := pdf.GetStringWidth(text) + 8
 	setFill(pdf, bg)
 	pdf.RoundedRect(x, y, w, 5.5, 2.5, "1234", "F")
 	setText(pdf, fg)
 	pdf.SetXY(x, y+0.5)
-	pdf.CellFormat(w, 5, transliterate(text), "", 0, "C", false, 0, "")
+	pdf.CellFormat(w, 5, text, "", 0, "C", false, 0, "")
 	return w
 }
 
 // drawStepNum draws a numbered circle for steps.
-func drawStepNum(pdf *gofpdf.Fpdf, x, y float64, num int) {
+func drawStepNum(pdf *reportPDF, x, y float64, num int) {
 	setFill(pdf, cBlue)
 	pdf.Circle(x+2, y+2, 2.8, "F")
 	pdf.SetFont("Helvetica", "B", 7)
@@ -455,15 +499,34 @@ func drawStepNum(pdf *gofpdf.Fpdf, x, y float64, num int) {
 }
 
 // drawCheckmark draws a small green checkmark icon.
-func drawCheckmark(pdf *gofpdf.Fpdf, x, y float64) {
+func drawCheckmark(pdf *reportPDF, x, y float64) {
 	setDraw(pdf, cGreen)
 	pdf.SetLineWidth(0.4)
 	pdf.Line(x+0.3, y+1.8, x+1.2, y+2.8)
 	pdf.Line(x+1.2, y+2.8, x+3, y+0.8)
 }
 
+// drawEsito draws the status mark of an eligibility check:
+// green checkmark, red cross or amber question mark.
+func drawEsito(pdf *reportPDF, x, y float64, esito string) {
+	switch esito {
+	case models.EsitoSoddisfatto:
+		drawCheckmark(pdf, x, y)
+	case models.EsitoNonSoddisfatto:
+		setDraw(pdf, cRed)
+		pdf.SetLineWidth(0.4)
+		pdf.Line(x+0.5, y+0.8, x+2.7, y+3)
+		pdf.Line(x+2.7, y+0.8, x+0.5, y+3)
+	default:
+		pdf.SetFont("Helvetica", "B", 8)
+		setText(pdf, cAmber)
+		pdf.SetXY(x, y)
+		pdf.CellFormat(3.2, 4, "?", "", 0, "C", false, 0, "")
+	}
+}
+
 // drawSquare draws a small empty checkbox.
-func drawSquare(pdf *gofpdf.Fpdf, x, y float64) {
+func drawSquare(pdf *reportPDF, x, y float64) {
 	setDraw(pdf, cInk30)
 	pdf.SetLineWidth(0.25)
 	pdf.Rect(x, y+0.3, 3, 3, "D")
@@ -477,49 +540,48 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var profile models.UserProfile
+	lang := i18n.FromRequest(r)
 	ct := r.Header.Get("Content-Type")
 	if strings.Contains(ct, "application/x-www-form-urlencoded") || strings.Contains(ct, "multipart/form-data") {
 		r.ParseForm()
+		if l := r.FormValue("lang"); l != "" {
+			lang = l
+		}
 		dataStr := r.FormValue("data")
 		if dataStr == "" {
-			http.Error(w, "Missing data field", http.StatusBadRequest)
+			i18n.Error(w, r, i18n.M("richiesta.dati_mancanti"), http.StatusBadRequest)
 			return
 		}
 		if err := json.Unmarshal([]byte(dataStr), &profile); err != nil {
-			http.Error(w, "Invalid profile data", http.StatusBadRequest)
+			i18n.Error(w, r, i18n.M("richiesta.profilo"), http.StatusBadRequest)
 			return
 		}
 	} else {
 		if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
 			return
 		}
 		defer r.Body.Close()
 	}
 
 	if msg, ok := validateProfile(profile); !ok {
-		http.Error(w, msg, http.StatusBadRequest)
+		i18n.Error(w, r, msg, http.StatusBadRequest)
 		return
 	}
 
-	cachedBonus := scraper.GetCachedBonus()
+	cachedBonus := matchableBonus()
 	result := matcher.MatchBonus(profile, cachedBonus)
-	linkcheck.ApplyStatus(result.Bonus)
-	validity.ApplyStatus(result.Bonus)
-	result.Avvisi = validity.GenerateAvvisi(result.Bonus)
-
-	profileCode := "BPM-..."
-	compact := toCompact(profile)
-	if data, err := json.Marshal(compact); err == nil {
-		b64 := base64.RawURLEncoding.EncodeToString(data)
-		code := codePrefix + b64
-		if len(code) > 64 {
-			code = code[:64]
-		}
-		profileCode = code
+	applyStatus(&result, clock.Now(), false)
+
+	profileCode, err := encodeProfileCode(profile)
+	if err != nil {
+		i18n.Error(w, r, i18n.M("server.errore"), http.StatusInternalServerError)
+		return
 	}
+	// The code keeps the household members; the PDF shows today's counters
+	profile = nucleo.Deriva(profile, clock.Now())
 
-	now := time.Now()
+	now := clock.Now()
 	dateStr := now.Format("2006-01-02")
 	dateDisplay := now.Format("02/01/2006")
 
@@ -532,9 +594,14 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	risparmioVal := parseEuroAmount(result.RisparmioStimato)
+	risparmioVal := result.RisparmioStimatoEuro
 
-	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf := newReportPDF(lang)
+	for _, list := range [][]models.Bonus{activeBonuses, expiredBonuses} {
+		for i := range list {
+			list[i] = list[i].Localized(pdf.lang)
+		}
+	}
 	pdf.SetMargins(marginL, 15, marginR)
 	pdf.SetAutoPageBreak(false, 20)
 
@@ -566,7 +633,7 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.CellFormat(contentW/2, 4, "BonusPerMe", "", 0, "L", false, 0, "")
 		pdf.SetFont("Helvetica", "", 7)
 		setText(pdf, cInk30)
-		pdf.CellFormat(contentW/2, 4, transliterate("Report del "+dateDisplay), "", 0, "R", false, 0, "")
+		pdf.CellFormat(contentW/2, 4, pdf.T("pdf.header_date", dateDisplay), "", 0, "R", false, 0, "")
 		setDraw(pdf, cBlue)
 		pdf.SetLineWidth(0.5)
 		pdf.Line(marginL, 13.5, pageW-marginR, 13.5)
@@ -602,13 +669,13 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.SetXY(marginL, 34)
 	pdf.SetFont("Helvetica", "", 12)
 	pdf.SetTextColor(210, 220, 230)
-	pdf.CellFormat(contentW, 6, "Report Personalizzato", "", 1, "L", false, 0, "")
+	pdf.CellFormat(contentW, 6, pdf.T("pdf.title"), "", 1, "L", false, 0, "")
 
 	// Date
 	pdf.SetXY(marginL, 42)
 	pdf.SetFont("Helvetica", "", 8.5)
 	pdf.SetTextColor(170, 185, 200)
-	pdf.CellFormat(contentW, 5, transliterate("Generato il "+dateDisplay), "", 1, "L", false, 0, "")
+	pdf.CellFormat(contentW, 5, pdf.T("pdf.generated", dateDisplay), "", 1, "L", false, 0, "")
 
 	// ── Summary metrics (overlapping header) ──
 	cardW := 150.0
@@ -632,16 +699,13 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.SetXY(cardX+12, cardY+19)
 	pdf.SetFont("Helvetica", "", 8.5)
 	setText(pdf, cInk50)
-	label := "bonus trovati"
-	if result.BonusScaduti > 0 {
-		label += fmt.Sprintf("  (+%d scadut", result.BonusScaduti)
-		if result.BonusScaduti == 1 {
-			label += "o)"
-		} else {
-			label += "i)"
-		}
+	label := pdf.T("pdf.found")
+	if result.BonusScaduti == 1 {
+		label += "  " + pdf.T("pdf.expired_one", result.BonusScaduti)
+	} else if result.BonusScaduti > 1 {
+		label += "  " + pdf.T("pdf.expired_other", result.BonusScaduti)
 	}
-	pdf.CellFormat(cardW/2-12, 4, transliterate(label), "", 0, "L", false, 0, "")
+	pdf.CellFormat(cardW/2-12, 4, label, "", 0, "L", false, 0, "")
 
 	// Vertical divider in card
 	setDraw(pdf, cInk15)
@@ -656,12 +720,12 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.SetFont("Courier", "B", 26)
 	}
 	setText(pdf, cGreen)
-	pdf.CellFormat(cardW/2-20, 10, transliterate("EUR "+euroStr), "", 0, "R", false, 0, "")
+	pdf.CellFormat(cardW/2-20, 10, "EUR "+euroStr, "", 0, "R", false, 0, "")
 
 	pdf.SetXY(cardX+cardW/2+8, cardY+19)
 	pdf.SetFont("Helvetica", "", 8.5)
 	setText(pdf, cInk50)
-	pdf.CellFormat(cardW/2-20, 4, "risparmio stimato / anno", "", 0, "R", false, 0, "")
+	pdf.CellFormat(cardW/2-20, 4, pdf.T("pdf.savings"), "", 0, "R", false, 0, "")
 
 	// ── Profile section ──
 	profStartY := cardY + cardH + 14
@@ -669,7 +733,7 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.SetX(marginL)
 	pdf.SetFont("Helvetica", "B", 7)
 	setText(pdf, cInk30)
-	pdf.CellFormat(contentW, 4, "PROFILO", "", 1, "L", false, 0, "")
+	pdf.CellFormat(contentW, 4, pdf.T("pdf.profile"), "", 1, "L", false, 0, "")
 	pdf.Ln(3)
 
 	profBoxY := pdf.GetY()
@@ -682,38 +746,30 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	row2Y := profBoxY + 16
 
 	// Row 1: Eta, ISEE, Regione
-	profileCell(pdf, marginL+6, row1Y, colW, "Eta", fmt.Sprintf("%d anni", profile.Eta))
+	profileCell(pdf, marginL+6, row1Y, colW, pdf.T("label.eta"), pdf.T("pdf.age_value", profile.Eta))
 	iseeStr := fmtEuro(profile.ISEE)
-	profileCell(pdf, marginL+6+colW, row1Y, colW, "ISEE", transliterate("EUR "+iseeStr))
-	regioneVal := profile.Residenza
+	profileCell(pdf, marginL+6+colW, row1Y, colW, "ISEE", "EUR "+iseeStr)
+	regioneVal := istat.NomeRegione(profile.Residenza)
 	if regioneVal == "" {
 		regioneVal = "-"
 	}
-	profileCell(pdf, marginL+6+colW*2, row1Y, colW, "Regione", transliterate(regioneVal))
+	profileCell(pdf, marginL+6+colW*2, row1Y, colW, pdf.T("label.regione"), regioneVal)
 
 	// Row 2: Figli, Occupazione, Stato civile
 	figliStr := fmt.Sprintf("%d", profile.NumeroFigli)
 	if profile.FigliMinorenni > 0 {
-		figliStr += fmt.Sprintf(" (%d min.)", profile.FigliMinorenni)
-	}
-	profileCell(pdf, marginL+6, row2Y, colW, "Figli", figliStr)
-	occVal := profile.Occupazione
-	if occVal == "" {
-		occVal = "-"
-	}
-	profileCell(pdf, marginL+6+colW, row2Y, colW, "Occupazione", transliterate(occVal))
-	civVal := profile.StatoCivile
-	if civVal == "" {
-		civVal = "-"
+		figliStr += " " + pdf.T("pdf.minors", profile.FigliMinorenni)
 	}
-	profileCell(pdf, marginL+6+colW*2, row2Y, colW, "Stato civile", transliterate(civVal))
+	profileCell(pdf, marginL+6, row2Y, colW, pdf.T("pdf.children"), figliStr)
+	profileCell(pdf, marginL+6+colW, row2Y, colW, pdf.T("label.occupazione"), pdf.opt(profile.Occupazione))
+	profileCell(pdf, marginL+6+colW*2, row2Y, colW, pdf.T("label.stato_civile"), pdf.opt(profile.StatoCivile))
 
 	// ── Panoramica ──
 	pdf.SetY(profBoxY + profBoxH + 10)
 	pdf.SetX(marginL)
 	pdf.SetFont("Helvetica", "B", 7)
 	setText(pdf, cInk30)
-	pdf.CellFormat(contentW, 4, "PANORAMICA", "", 1, "L", false, 0, "")
+	pdf.CellFormat(contentW, 4, pdf.T("pdf.overview"), "", 1, "L", false, 0, "")
 	pdf.Ln(4)
 
 	// Table header line
@@ -742,22 +798,20 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.SetXY(marginL+8, y)
 		pdf.SetFont("Helvetica", "", 8.5)
 		setText(pdf, cInk75)
-		nome := b.Nome
-		if len(nome) > 50 {
-			nome = nome[:47] + "..."
-		}
-		pdf.CellFormat(contentW-55, 5.5, transliterate(nome), "", 0, "L", false, 0, "")
+		nome := truncRunes(b.Nome, 50)
+		pdf.CellFormat(contentW-55, 5.5, nome, "", 0, "L", false, 0, "")
 
 		// Importo right-aligned
 		pdf.SetFont("Courier", "", 8)
 		setText(pdf, cBlue)
-		importoDisplay := transliterate(b.Importo)
+		importoDisplay := b.Importo
+		if v := fmtValore(pdf, b.ValoreStimato); v != "" {
+			importoDisplay = v
+		}
 		if importoDisplay == "" {
 			importoDisplay = "-"
 		}
-		if len(importoDisplay) > 45 {
-			importoDisplay = importoDisplay[:42] + "..."
-		}
+		importoDisplay = truncRunes(importoDisplay, 45)
 		pdf.CellFormat(47, 5.5, importoDisplay, "", 1, "R", false, 0, "")
 		pdf.SetY(y + 6.5)
 	}
@@ -785,12 +839,12 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 			pdf.SetXY(marginL+8, y)
 			pdf.SetFont("Helvetica", "", 8.5)
 			setText(pdf, cInk30)
-			pdf.CellFormat(contentW-40, 5.5, transliterate(b.Nome), "", 0, "L", false, 0, "")
+			pdf.CellFormat(contentW-40, 5.5, b.Nome, "", 0, "L", false, 0, "")
 
 			// SCADUTO
 			pdf.SetFont("Helvetica", "B", 7)
 			setText(pdf, cRed)
-			pdf.CellFormat(32, 5.5, "SCADUTO", "", 1, "R", false, 0, "")
+			pdf.CellFormat(32, 5.5, pdf.T("pdf.expired_tag"), "", 1, "R", false, 0, "")
 			pdf.SetY(y + 6.5)
 		}
 	}
@@ -805,26 +859,26 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	setFill(pdf, cGreen)
 	pdf.Circle(xLeg+1.5, legendY+1.5, 1, "F")
 	pdf.SetXY(xLeg+4, legendY)
-	pdf.CellFormat(15, 3, "alta", "", 0, "L", false, 0, "")
+	pdf.CellFormat(15, 3, pdf.T("pdf.legend_high"), "", 0, "L", false, 0, "")
 	xLeg += 18
 
 	setFill(pdf, cAmber)
 	pdf.Circle(xLeg+1.5, legendY+1.5, 1, "F")
 	pdf.SetXY(xLeg+4, legendY)
-	pdf.CellFormat(15, 3, "media", "", 0, "L", false, 0, "")
+	pdf.CellFormat(15, 3, pdf.T("pdf.legend_medium"), "", 0, "L", false, 0, "")
 	xLeg += 18
 
 	setFill(pdf, cInk30)
 	pdf.Circle(xLeg+1.5, legendY+1.5, 1, "F")
 	pdf.SetXY(xLeg+4, legendY)
-	pdf.CellFormat(15, 3, "bassa", "", 0, "L", false, 0, "")
+	pdf.CellFormat(15, 3, pdf.T("pdf.legend_low"), "", 0, "L", false, 0, "")
 	xLeg += 18
 
 	if len(expiredBonuses) > 0 {
 		setFill(pdf, cRed)
 		pdf.Circle(xLeg+1.5, legendY+1.5, 1, "F")
 		pdf.SetXY(xLeg+4, legendY)
-		pdf.CellFormat(15, 3, "scaduto", "", 0, "L", false, 0, "")
+		pdf.CellFormat(15, 3, pdf.T("pdf.legend_expired"), "", 0, "L", false, 0, "")
 	}
 
 	// Cover footer
@@ -836,8 +890,8 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.SetX(marginL)
 	pdf.SetFont("Helvetica", "", 6.5)
 	setText(pdf, cInk30)
-	pdf.CellFormat(contentW/2, 4, transliterate("Documento orientativo -- non sostituisce consulenza professionale"), "", 0, "L", false, 0, "")
-	pdf.CellFormat(contentW/2, 4, transliterate(profileCode), "", 0, "R", false, 0, "")
+	pdf.CellFormat(contentW/2, 4, pdf.T("pdf.cover_note"), "", 0, "L", false, 0, "")
+	pdf.CellFormat(contentW/2, 4, profileCode, "", 0, "R", false, 0, "")
 
 	isFirstPage = false
 
@@ -857,7 +911,7 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.SetX(marginL + 6)
 	pdf.SetFont("Helvetica", "", 7)
 	pdf.SetTextColor(146, 64, 14)
-	pdf.MultiCell(contentW-10, 3.5, transliterate("Risultati orientativi. Importi, requisiti e scadenze possono variare. Verifica sempre sui siti ufficiali (INPS, Agenzia delle Entrate, Regione) prima di fare domanda."), "", "L", false)
+	pdf.MultiCell(contentW-10, 3.5, pdf.T("pdf.disclaimer"), "", "L", false)
 	pdf.Ln(5)
 
 	// Active bonus details
@@ -874,7 +928,7 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.SetX(marginL)
 		pdf.SetFont("Helvetica", "B", 10)
 		setText(pdf, cInk30)
-		pdf.CellFormat(contentW, 6, "Bonus non attivi", "", 1, "L", false, 0, "")
+		pdf.CellFormat(contentW, 6, pdf.T("pdf.inactive"), "", 1, "L", false, 0, "")
 		pdf.Ln(3)
 
 		for _, b := range expiredBonuses {
@@ -885,8 +939,8 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	// ═════════════════════════════════════════════════════════════
 	// LAST PAGE — PROSSIMI PASSI + LEGAL
 	// ═════════════════════════════════════════════════════════════
-	ensureSpace(pdf, 140)
-    y = ensureSpace(pdf, 140)
+	ensureSpace(pdf, 175)
+    y = ensureSpace(pdf, 175)
     if y < 24 {
         y = 24
     }
@@ -896,7 +950,7 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.SetX(marginL)
 	pdf.SetFont("Helvetica", "B", 18)
 	setText(pdf, cBlue)
-	pdf.CellFormat(contentW, 10, "Prossimi passi", "", 1, "L", false, 0, "")
+	pdf.CellFormat(contentW, 10, pdf.T("pdf.next_steps"), "", 1, "L", false, 0, "")
 
 	// Thin blue accent line
 	setDraw(pdf, cTerra)
@@ -909,9 +963,9 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 		Title string
 		Desc  string
 	}{
-		{"1", "Verifica i requisiti", "Controlla ogni bonus sui siti ufficiali indicati nelle pagine precedenti."},
-		{"2", "Prepara i documenti", "ISEE aggiornato, SPID o CIE, documento d'identita, coordinate bancarie."},
-		{"3", "Presenta le domande", "Online sui portali ufficiali (INPS, Agenzia delle Entrate) o presso un CAF/patronato."},
+		{"1", pdf.T("pdf.step1_title"), pdf.T("pdf.step1_desc")},
+		{"2", pdf.T("pdf.step2_title"), pdf.T("pdf.step2_desc")},
+		{"3", pdf.T("pdf.step3_title"), pdf.T("pdf.step3_desc")},
 	}
 
 	for _, step := range nextSteps {
@@ -929,17 +983,39 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.SetXY(marginL+16, stepY+1)
 		pdf.SetFont("Helvetica", "B", 11)
 		setText(pdf, cInk90)
-		pdf.CellFormat(contentW-20, 5, transliterate(step.Title), "", 1, "L", false, 0, "")
+		pdf.CellFormat(contentW-20, 5, step.Title, "", 1, "L", false, 0, "")
 
 		// Description
 		pdf.SetXY(marginL+16, stepY+7.5)
 		pdf.SetFont("Helvetica", "", 8.5)
 		setText(pdf, cInk50)
-		pdf.CellFormat(contentW-20, 4.5, transliterate(step.Desc), "", 1, "L", false, 0, "")
+		pdf.CellFormat(contentW-20, 4.5, step.Desc, "", 1, "L", false, 0, "")
 
 		pdf.SetY(stepY + 18)
 	}
 
+	// ── Profile code ──
+	codeY := pdf.GetY() + 2
+	qrSize := 26.0
+	if err := drawQR(pdf, profileCode, marginL, codeY, qrSize); err != nil {
+		logger.Warn("report: qr code failed", map[string]interface{}{"error": err.Error()})
+		qrSize = 0
+	}
+	textX := marginL + qrSize + 6
+	pdf.SetXY(textX, codeY+3)
+	pdf.SetFont("Helvetica", "B", 11)
+	setText(pdf, cInk90)
+	pdf.CellFormat(contentW-qrSize-6, 5, pdf.T("pdf.code_title"), "", 1, "L", false, 0, "")
+	pdf.SetX(textX)
+	pdf.SetFont("Courier", "B", 9)
+	setText(pdf, cBlue)
+	pdf.MultiCell(contentW-qrSize-6, 4.5, profileCode, "", "L", false)
+	pdf.SetX(textX)
+	pdf.SetFont("Helvetica", "", 8.5)
+	setText(pdf, cInk50)
+	pdf.MultiCell(contentW-qrSize-6, 4.5, pdf.T("pdf.code_desc"), "", "L", false)
+	pdf.SetY(codeY + qrSize + 2)
+
 	// ── Legal section ──
 	pdf.Ln(8)
 	sepY := pdf.GetY()
@@ -969,9 +1045,9 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 	// Disclaimer
 	pdf.SetFont("Helvetica", "I", 7)
 	pdf.SetTextColor(146, 64, 14)
-	pdf.CellFormat(contentW, 3.5, transliterate("Questi risultati sono orientativi e potrebbero contenere errori."), "", 1, "C", false, 0, "")
-	pdf.CellFormat(contentW, 3.5, transliterate("Verifica sempre sui siti ufficiali prima di presentare domanda."), "", 1, "C", false, 0, "")
-	pdf.CellFormat(contentW, 3.5, transliterate("BonusPerMe non e un CAF ne un patronato."), "", 1, "C", false, 0, "")
+	pdf.CellFormat(contentW, 3.5, pdf.T("pdf.legal1"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(contentW, 3.5, pdf.T("pdf.legal2"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(contentW, 3.5, pdf.T("pdf.legal3"), "", 1, "C", false, 0, "")
 
 	// ═════════════════════════════════════════════════════════════
 	// OUTPUT
@@ -986,12 +1062,12 @@ func ReportHandler(w http.ResponseWriter, r *http.Request) {
 
 	if err := pdf.Output(w); err != nil {
 		sentryutil.CaptureError(err, map[string]string{"handler": "report", "phase": "pdf-output"})
-		http.Error(w, "Errore generazione PDF", http.StatusInternalServerError)
+		i18n.Error(w, r, i18n.M("report.errore_pdf"), http.StatusInternalServerError)
 	}
 }
 
 // profileCell draws a label+value pair in the profile grid.
-func profileCell(pdf *gofpdf.Fpdf, x, y, w float64, label, value string) {
+func profileCell(pdf *reportPDF, x, y, w float64, label, value string) {
 	pdf.SetXY(x, y)
 	pdf.SetFont("Helvetica", "", 7)
 	setText(pdf, cInk50)
@@ -1003,7 +1079,7 @@ func profileCell(pdf *gofpdf.Fpdf, x, y, w float64, label, value string) {
 }
 
 // ─── drawBonusDetail: clean left-accent-bar design ───
-func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfile) {
+func drawBonusDetail(pdf *reportPDF, b models.Bonus, profile models.UserProfile) {
 	// Estimate space needed
 	needed := 60.0
 	if len(b.Requisiti) > 0 {
@@ -1015,6 +1091,12 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 	if len(b.Documenti) > 0 {
 		needed += float64(len(b.Documenti))*5 + 8
 	}
+	if len(b.Spiegazione) > 0 {
+		needed += float64(len(b.Spiegazione))*5 + 8
+	}
+	if len(b.RiferimentiNormativi) > 0 {
+		needed += float64(len(b.RiferimentiNormativi))*4.5 + 8
+	}
 	// Cap at reasonable max (will page break if needed)
 	if needed > 200 {
 		needed = 80
@@ -1036,7 +1118,7 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 	pdf.SetXY(innerX, y)
 	pdf.SetFont("Helvetica", "B", 13)
 	setText(pdf, cInk90)
-	nome := transliterate(b.Nome)
+	nome := b.Nome
 	pdf.CellFormat(innerW-30, 7, nome, "", 0, "L", false, 0, "")
 
 	// Percentage pill
@@ -1051,7 +1133,7 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 	pdf.SetXY(innerX, y)
 	pdf.SetFont("Helvetica", "", 8)
 	setText(pdf, cInk50)
-	meta := transliterate(b.Ente)
+	meta := b.Ente
 	if b.Scadenza != "" {
 		meta += "  |  "
 	}
@@ -1061,13 +1143,17 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 		pdf.SetXY(xAfter, y)
 		setText(pdf, cTerra)
 		pdf.SetFont("Helvetica", "B", 8)
-		pdf.CellFormat(0, 4, transliterate(b.Scadenza), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 4, b.Scadenza, "", 0, "L", false, 0, "")
 	}
 	y += 7
 
 	// ── C) IMPORTO BAR ──
+	stimato := b.ImportoReale
+	if stimato == "" {
+		stimato = fmtValore(pdf, b.ValoreStimato)
+	}
 	boxH := 14.0
-	if b.ImportoReale != "" && b.ImportoReale != b.Importo {
+	if stimato != "" && stimato != b.Importo {
 		boxH = 18.0
 	}
 	setFill(pdf, cCream)
@@ -1076,25 +1162,25 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 	pdf.SetXY(innerX+5, y+3)
 	pdf.SetFont("Courier", "B", 11)
 	setText(pdf, cGreen)
-	importoText := transliterate(b.Importo)
+	importoText := b.Importo
 	if importoText == "" {
-		importoText = "Vedi sito ufficiale"
+		importoText = pdf.T("pdf.see_site")
 		pdf.SetFont("Helvetica", "", 9)
 		setText(pdf, cInk50)
 	}
 	pdf.CellFormat(innerW-10, 5, importoText, "", 1, "L", false, 0, "")
 
-	if b.ImportoReale != "" && b.ImportoReale != b.Importo {
+	if stimato != "" && stimato != b.Importo {
 		pdf.SetXY(innerX+5, y+10)
 		pdf.SetFont("Helvetica", "", 7.5)
 		setText(pdf, cInk50)
-		pdf.CellFormat(innerW-10, 4, transliterate("Stimato per te: "+b.ImportoReale), "", 0, "L", false, 0, "")
+		pdf.CellFormat(innerW-10, 4, pdf.T("pdf.estimated", stimato), "", 0, "L", false, 0, "")
 
-		// "STIMATO PER TE" label
+		// pdf.T("pdf.estimated_tag") label
 		pdf.SetFont("Helvetica", "B", 6)
 		setText(pdf, cGreen)
 		pdf.SetXY(innerX+innerW-35, y+2)
-		pdf.CellFormat(30, 3, "STIMATO PER TE", "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 3, pdf.T("pdf.estimated_tag"), "", 0, "R", false, 0, "")
 	}
 	y += boxH + 4
 
@@ -1102,11 +1188,8 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 	pdf.SetXY(innerX, y)
 	pdf.SetFont("Helvetica", "", 8.5)
 	setText(pdf, cInk75)
-	desc := b.Descrizione
-	if len(desc) > 280 {
-		desc = desc[:277] + "..."
-	}
-	pdf.MultiCell(innerW, 4.2, transliterate(desc), "", "L", false)
+	desc := truncRunes(b.Descrizione, 280)
+	pdf.MultiCell(innerW, 4.2, desc, "", "L", false)
 	y = pdf.GetY() + 3
 
 	// ── E) THIN SEPARATOR ──
@@ -1115,7 +1198,37 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 	pdf.Line(innerX, y, innerX+innerW, y)
 	y += 4
 
-	// ── F) TWO-COLUMN LAYOUT: Requisiti | Documenti ──
+	// ── F) PERCHE TI E STATO PROPOSTO: esito di ogni requisito ──
+	if len(b.Spiegazione) > 0 {
+		y = ensureSpace(pdf, float64(len(b.Spiegazione))*5.5+10)
+		pdf.SetXY(innerX, y)
+		pdf.SetFont("Helvetica", "B", 7)
+		setText(pdf, cInk30)
+		pdf.CellFormat(innerW, 4, pdf.T("pdf.why"), "", 1, "L", false, 0, "")
+		y += 6
+
+		for i, e := range b.Spiegazione {
+			rowY := y + float64(i)*5.5
+			drawEsito(pdf, innerX, rowY, e.Esito)
+			text := e.Descrizione
+			if !e.Obbligatorio {
+				text += " " + pdf.T("pdf.score_cond")
+			}
+			text = truncRunes(text, 90)
+			pdf.SetXY(innerX+5, rowY)
+			pdf.SetFont("Helvetica", "", 7.5)
+			setText(pdf, cInk75)
+			pdf.CellFormat(innerW*0.7-5, 4, text, "", 0, "L", false, 0, "")
+			if e.Valore != "" {
+				pdf.SetFont("Helvetica", "", 7)
+				setText(pdf, cInk50)
+				pdf.CellFormat(innerW*0.3, 4, pdf.T("pdf.your_data", e.Valore), "", 0, "R", false, 0, "")
+			}
+		}
+		y += float64(len(b.Spiegazione))*5.5 + 3
+	}
+
+	// ── G) TWO-COLUMN LAYOUT: Requisiti | Documenti ──
 	hasReq := len(b.Requisiti) > 0
 	hasDoc := len(b.Documenti) > 0
 
@@ -1135,11 +1248,11 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 		pdf.SetXY(innerX, y)
 		pdf.SetFont("Helvetica", "B", 7)
 		setText(pdf, cInk30)
-		pdf.CellFormat(colLeft, 4, "REQUISITI", "", 0, "L", false, 0, "")
+		pdf.CellFormat(colLeft, 4, pdf.T("pdf.requirements"), "", 0, "L", false, 0, "")
 
 		// Right: Documenti
 		pdf.SetXY(colRightX, y)
-		pdf.CellFormat(colRight, 4, "DOCUMENTI", "", 1, "L", false, 0, "")
+		pdf.CellFormat(colRight, 4, pdf.T("pdf.documents"), "", 1, "L", false, 0, "")
 		y += 6
 
 		maxRows := len(b.Requisiti)
@@ -1154,22 +1267,16 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 				pdf.SetXY(innerX+5, rowY)
 				pdf.SetFont("Helvetica", "", 7.5)
 				setText(pdf, cInk75)
-				req := b.Requisiti[i]
-				if len(req) > 50 {
-					req = req[:47] + "..."
-				}
-				pdf.CellFormat(colLeft-5, 4, transliterate(req), "", 0, "L", false, 0, "")
+				req := truncRunes(b.Requisiti[i], 50)
+				pdf.CellFormat(colLeft-5, 4, req, "", 0, "L", false, 0, "")
 			}
 			if i < len(b.Documenti) {
 				drawSquare(pdf, colRightX, rowY)
 				pdf.SetXY(colRightX+5, rowY)
 				pdf.SetFont("Helvetica", "", 7.5)
 				setText(pdf, cInk75)
-				doc := b.Documenti[i]
-				if len(doc) > 50 {
-					doc = doc[:47] + "..."
-				}
-				pdf.CellFormat(colRight-5, 4, transliterate(doc), "", 0, "L", false, 0, "")
+				doc := truncRunes(b.Documenti[i], 50)
+				pdf.CellFormat(colRight-5, 4, doc, "", 0, "L", false, 0, "")
 			}
 		}
 		y += float64(maxRows)*5.5 + 3
@@ -1179,14 +1286,14 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 		pdf.SetXY(innerX, y)
 		pdf.SetFont("Helvetica", "B", 7)
 		setText(pdf, cInk30)
-		pdf.CellFormat(innerW, 4, "REQUISITI", "", 1, "L", false, 0, "")
+		pdf.CellFormat(innerW, 4, pdf.T("pdf.requirements"), "", 1, "L", false, 0, "")
 		y += 6
 		for i, req := range b.Requisiti {
 			drawCheckmark(pdf, innerX, y+float64(i)*5.5)
 			pdf.SetXY(innerX+5, y+float64(i)*5.5)
 			pdf.SetFont("Helvetica", "", 7.5)
 			setText(pdf, cInk75)
-			pdf.CellFormat(innerW-5, 4, transliterate(req), "", 1, "L", false, 0, "")
+			pdf.CellFormat(innerW-5, 4, req, "", 1, "L", false, 0, "")
 		}
 		y += float64(len(b.Requisiti))*5.5 + 3
 	} else if hasDoc {
@@ -1195,25 +1302,25 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 		pdf.SetXY(innerX, y)
 		pdf.SetFont("Helvetica", "B", 7)
 		setText(pdf, cInk30)
-		pdf.CellFormat(innerW, 4, "DOCUMENTI", "", 1, "L", false, 0, "")
+		pdf.CellFormat(innerW, 4, pdf.T("pdf.documents"), "", 1, "L", false, 0, "")
 		y += 6
 		for i, doc := range b.Documenti {
 			drawSquare(pdf, innerX, y+float64(i)*5.5)
 			pdf.SetXY(innerX+5, y+float64(i)*5.5)
 			pdf.SetFont("Helvetica", "", 7.5)
 			setText(pdf, cInk75)
-			pdf.CellFormat(innerW-5, 4, transliterate(doc), "", 1, "L", false, 0, "")
+			pdf.CellFormat(innerW-5, 4, doc, "", 1, "L", false, 0, "")
 		}
 		y += float64(len(b.Documenti))*5.5 + 3
 	}
 
-	// ── G) COME FARE DOMANDA ──
+	// ── H) COME FARE DOMANDA ──
 	if len(b.ComeRichiederlo) > 0 {
 		y = ensureSpace(pdf, float64(len(b.ComeRichiederlo))*5.5+10)
 		pdf.SetXY(innerX, y)
 		pdf.SetFont("Helvetica", "B", 7)
 		setText(pdf, cInk30)
-		pdf.CellFormat(innerW, 4, "COME FARE DOMANDA", "", 1, "L", false, 0, "")
+		pdf.CellFormat(innerW, 4, pdf.T("pdf.how_to"), "", 1, "L", false, 0, "")
 		y += 6
 
 		for i, step := range b.ComeRichiederlo {
@@ -1221,19 +1328,37 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 			pdf.SetXY(innerX+7, y+float64(i)*6)
 			pdf.SetFont("Helvetica", "", 7.5)
 			setText(pdf, cInk75)
-			pdf.CellFormat(innerW-7, 4, transliterate(step), "", 1, "L", false, 0, "")
+			pdf.CellFormat(innerW-7, 4, step, "", 1, "L", false, 0, "")
 		}
 		y += float64(len(b.ComeRichiederlo))*6 + 2
 	}
 
-	// ── H) FOOTER: link + scadenza ──
+	// ── I) RIFERIMENTI NORMATIVI: always in Italian, verbatim for the CAF ──
+	if len(b.RiferimentiNormativi) > 0 {
+		y = ensureSpace(pdf, float64(len(b.RiferimentiNormativi))*4.5+10)
+		pdf.SetXY(innerX, y)
+		pdf.SetFont("Helvetica", "B", 7)
+		setText(pdf, cInk30)
+		pdf.CellFormat(innerW, 4, pdf.T("pdf.legal_refs"), "", 1, "L", false, 0, "")
+		y += 6
+
+		pdf.SetFont("Helvetica", "", 7.5)
+		setText(pdf, cInk75)
+		for i, ref := range b.RiferimentiNormativi {
+			pdf.SetXY(innerX, y+float64(i)*4.5)
+			pdf.verbatimCell(innerW, 4, truncRunes(ref, 110), "L")
+		}
+		y += float64(len(b.RiferimentiNormativi))*4.5 + 2
+	}
+
+	// ── J) FOOTER: link + scadenza ──
 	y += 1
 	if b.LinkUfficiale != "" {
 		pdf.SetXY(innerX, y)
 		pdf.SetFont("Helvetica", "", 7)
 		setText(pdf, cBlueMid)
 		linkText := truncURL(b.LinkUfficiale, 60)
-		pdf.WriteLinkString(3.5, transliterate(linkText), b.LinkUfficiale)
+		pdf.WriteLinkString(3.5, linkText, b.LinkUfficiale)
 	}
 	y += 5
 
@@ -1245,7 +1370,7 @@ func drawBonusDetail(pdf *gofpdf.Fpdf, b models.Bonus, profile models.UserProfil
 }
 
 // ─── drawBonusExpired: minimal compact expired card ───
-func drawBonusExpired(pdf *gofpdf.Fpdf, b models.Bonus) {
+func drawBonusExpired(pdf *reportPDF, b models.Bonus) {
 	startY := ensureSpace(pdf, 16)
 	if startY < marginT+6 {
 		startY = marginT + 6
@@ -1260,16 +1385,18 @@ func drawBonusExpired(pdf *gofpdf.Fpdf, b models.Bonus) {
 	pdf.SetXY(innerX, y)
 	pdf.SetFont("Helvetica", "B", 10)
 	setText(pdf, cInk30)
-	pdf.CellFormat(innerW-35, 5.5, transliterate(b.Nome), "", 0, "L", false, 0, "")
+	pdf.CellFormat(innerW-35, 5.5, b.Nome, "", 0, "L", false, 0, "")
 
-	drawPill(pdf, marginL+contentW-pdf.GetStringWidth("SCADUTO")-8, y, "SCADUTO", cRedBg, cRed)
+	expiredTag := pdf.T("pdf.expired_tag")
+	pdf.SetFont("Helvetica", "B", 7.5)
+	drawPill(pdf, marginL+contentW-pdf.GetStringWidth(expiredTag)-8, y, expiredTag, cRedBg, cRed)
 	y += 7
 
 	// Importo struck through + note
 	pdf.SetXY(innerX, y)
 	pdf.SetFont("Courier", "", 8.5)
 	setText(pdf, cInk30)
-	importoText := transliterate(b.Importo)
+	importoText := b.Importo
 	if importoText != "" {
 		pdf.CellFormat(0, 4, importoText, "", 0, "L", false, 0, "")
 		strW := pdf.GetStringWidth(importoText)
@@ -1283,11 +1410,11 @@ func drawBonusExpired(pdf *gofpdf.Fpdf, b models.Bonus) {
 	pdf.SetXY(innerX, y)
 	pdf.SetFont("Helvetica", "I", 7.5)
 	setText(pdf, cInk50)
-	nota := "Bonus non piu disponibile."
+	nota := pdf.T("pdf.not_available")
 	if b.Scadenza != "" {
-		nota += " Scaduto il " + b.Scadenza + "."
+		nota += " " + pdf.T("pdf.expired_on", b.Scadenza)
 	}
-	pdf.CellFormat(innerW, 4, transliterate(nota), "", 1, "L", false, 0, "")
+	pdf.CellFormat(innerW, 4, nota, "", 1, "L", false, 0, "")
 	y += 6
 
 	// Grey accent bar
@@ -1312,7 +1439,7 @@ func NotifySignupHandler(w http.ResponseWriter, r *http.Request) {
 		Email string `json:"email"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
 		return
 	}
 	defer r.Body.Close()
@@ -1320,9 +1447,7 @@ func NotifySignupHandler(w http.ResponseWriter, r *http.Request) {
 	body.Email = strings.TrimSpace(body.Email)
 
 	if !emailRe.MatchString(body.Email) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Email non valida"})
+		i18n.Error(w, r, i18n.M("modulo.email"), http.StatusBadRequest)
 		return
 	}
 
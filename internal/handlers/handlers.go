@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"bonusperme/internal/clock"
+	"bonusperme/internal/dsu"
+	"bonusperme/internal/i18n"
 	"bonusperme/internal/linkcheck"
 	"bonusperme/internal/matcher"
 	"bonusperme/internal/models"
+	"bonusperme/internal/pipeline"
+	"bonusperme/internal/questionario"
+	"bonusperme/internal/redditi"
 	"bonusperme/internal/scraper"
 	sentryutil "bonusperme/internal/sentry"
 	"bonusperme/internal/validity"
@@ -11,8 +17,6 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -26,38 +30,132 @@ func MatchHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !verifyTurnstile(getTurnstileToken(r)) {
-		http.Error(w, "Verifica di sicurezza non superata", http.StatusForbidden)
+		i18n.Error(w, r, i18n.M("richiesta.verifica_sicurezza"), http.StatusForbidden)
 		return
 	}
 
 	var profile models.UserProfile
 	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
 		sentryutil.CaptureError(err, map[string]string{"handler": "match", "phase": "decode"})
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
 		return
 	}
 	defer r.Body.Close()
 
 	if msg, ok := validateProfile(profile); !ok {
-		http.Error(w, msg, http.StatusBadRequest)
+		i18n.Error(w, r, msg, http.StatusBadRequest)
+		return
+	}
+	asOf, custom, ok := parseAsOf(r)
+	if !ok {
+		i18n.Error(w, r, i18n.M("richiesta.as_of"), http.StatusBadRequest)
 		return
 	}
 
 	IncrementCounter()
 
-	cachedBonus := scraper.GetCachedBonus()
-	result := matcher.MatchBonus(profile, cachedBonus)
-	linkcheck.ApplyStatus(result.Bonus)
-	validity.ApplyStatus(result.Bonus)
-	result.Avvisi = validity.GenerateAvvisi(result.Bonus)
+	cachedBonus := matchableBonus()
+	result := matcher.MatchBonusAt(profile, asOf, cachedBonus)
+	applyStatus(&result, asOf, custom)
+	localizeResult(&result, i18n.FromRequest(r))
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Vary", "Accept-Language")
 	// No caching - data is ephemeral
 	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
 	w.Header().Set("Pragma", "no-cache")
 	json.NewEncoder(w).Encode(result)
 }
 
+// matchableBonus is what a profile is matched against: the enriched
+// national bonuses of the scraper cache, then the regional and local ones.
+func matchableBonus() []models.Bonus {
+	return append(scraper.GetCachedBonus(), matcher.GetTerritorialBonus()...)
+}
+
+// parseAsOf reads the optional as_of query parameter (YYYY-MM-DD), the date
+// to evaluate deadlines and amounts at. Without it the clock's current time
+// is used and custom is false.
+func parseAsOf(r *http.Request) (asOf time.Time, custom bool, ok bool) {
+	v := r.URL.Query().Get("as_of")
+	if v == "" {
+		return clock.Now(), false, true
+	}
+	t, err := clock.ParseDate(v)
+	if err != nil || t.Year() < 2000 || t.Year() > 2100 {
+		return time.Time{}, false, false
+	}
+	return t, true, true
+}
+
+// localizeResult puts the bonus texts, the avvisi, the alternatives and
+// the near misses of a match result in lang.
+func localizeResult(result *models.MatchResult, lang string) {
+	for i := range result.Bonus {
+		result.Bonus[i] = result.Bonus[i].Localized(lang)
+	}
+	for i, a := range result.Avvisi {
+		if a.Codice != "" {
+			result.Avvisi[i].Messaggio = i18n.Message{Code: a.Codice, Params: a.Parametri}.Text(lang)
+		}
+	}
+	// Alternatives name the bonuses they give way to: names and reason are
+	// taken from the localized bonuses, by ID
+	byID := make(map[string]models.Bonus, len(result.Bonus))
+	for _, b := range result.Bonus {
+		byID[b.ID] = b
+	}
+	for i := range result.Alternative {
+		a := &result.Alternative[i]
+		a.Bonus = a.Bonus.Localized(lang)
+		byID[a.Bonus.ID] = a.Bonus
+	}
+	nome := func(id string) string {
+		if b, ok := byID[id]; ok {
+			return b.Nome
+		}
+		return id
+	}
+	for i := range result.Alternative {
+		a := &result.Alternative[i]
+		if len(a.Scelti) > 0 {
+			a.Motivo = matcher.MotivoAlternativa(*a, byID[a.Scelti[0]])
+		}
+		m := matcher.MessaggioAlternativa(*a, nome)
+		a.Messaggio, a.Parametri = m.Text(lang), m.Params
+	}
+	for i, q := range result.QuasiIdonei {
+		result.QuasiIdonei[i].Bonus = q.Bonus.Localized(lang)
+		if e := questionario.Etichetta(q.Requisito.Campo, lang); e != "" && q.Parametri != nil {
+			params := make(map[string]interface{}, len(q.Parametri))
+			for k, v := range q.Parametri {
+				params[k] = v
+			}
+			params["etichetta"] = e
+			result.QuasiIdonei[i].Parametri = params
+		}
+		result.QuasiIdonei[i].Messaggio = i18n.Message{Code: q.Codice, Params: result.QuasiIdonei[i].Parametri}.Text(lang)
+	}
+	result.Lingua = lang
+}
+
+// applyStatus patches link and validity statuses onto a match result and
+// builds its avvisi. The cached validity statuses are today's: for a
+// custom evaluation date they are recomputed as of that date.
+func applyStatus(result *models.MatchResult, asOf time.Time, custom bool) {
+	linkcheck.ApplyStatus(result.Bonus)
+	if custom {
+		validity.ApplyStatusAt(result.Bonus, asOf)
+		// Staleness and year penalties as of the evaluation date
+		for i := range result.Bonus {
+			result.Bonus[i].ConfidenceScore = pipeline.CalculateConfidence(&result.Bonus[i], asOf)
+		}
+	} else {
+		validity.ApplyStatus(result.Bonus)
+	}
+	result.Avvisi = validity.GenerateAvvisi(result.Bonus, asOf)
+}
+
 func getVisitorsToday() int {
 	h := time.Now().Hour()
 	base := 10
@@ -99,56 +197,154 @@ func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
 
+// iseeResponse keeps isee/found for the wizard and adds the structured
+// attestation. Only values read from the uploaded PDF are returned.
 type iseeResponse struct {
-	ISEE  float64 `json:"isee"`
-	Found bool    `json:"found"`
+	ISEE         float64           `json:"isee"`
+	Found        bool              `json:"found"`
+	TipoISEE     string            `json:"tipo_isee,omitempty"`
+	Scansionato  bool              `json:"scansionato,omitempty"`
+	Attestazione *dsu.Attestazione `json:"attestazione,omitempty"`
+	Avvisi       []dsu.Avviso      `json:"avvisi,omitempty"`
 }
 
 func ParseISEEHandler(w http.ResponseWriter, r *http.Request) {
+	fullText, ok := readPDFUpload(w, r, "parse-isee")
+	if !ok {
+		return
+	}
+	lang := i18n.FromRequest(r)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+	w.Header().Set("Pragma", "no-cache")
+
+	if fullText == nil {
+		json.NewEncoder(w).Encode(iseeResponse{ISEE: 0, Found: false})
+		return
+	}
+
+	// No text layer: the attestation was printed and scanned
+	if scansionato(*fullText) {
+		json.NewEncoder(w).Encode(iseeResponse{
+			Scansionato: true,
+			Avvisi:      []dsu.Avviso{dsu.Avviso{Tipo: dsu.AvvisoScansionato}.Localized(lang)},
+		})
+		return
+	}
+
+	att := dsu.Parse(*fullText)
+	isee, tipo := att.Principale()
+	avvisi := att.Verifica(clock.Now())
+	for i := range avvisi {
+		avvisi[i] = avvisi[i].Localized(lang)
+	}
+	json.NewEncoder(w).Encode(iseeResponse{
+		ISEE:         isee,
+		Found:        isee > 0,
+		TipoISEE:     tipo,
+		Attestazione: &att,
+		Avvisi:       avvisi,
+	})
+}
+
+// redditiResponse carries the values read from a CU or 730 and, when a
+// profile was sent along, the profile filled with them.
+type redditiResponse struct {
+	Found         bool                   `json:"found"`
+	Scansionato   bool                   `json:"scansionato,omitempty"`
+	Dichiarazione *redditi.Dichiarazione `json:"dichiarazione,omitempty"`
+	Profilo       *models.UserProfile    `json:"profilo,omitempty"`
+}
+
+// ParseRedditiHandler serves POST /api/parse-redditi: reads income,
+// employment type and deductible expenses from a CU or 730 PDF. An
+// optional "profilo" form field (JSON) is returned filled with them.
+func ParseRedditiHandler(w http.ResponseWriter, r *http.Request) {
+	fullText, ok := readPDFUpload(w, r, "parse-redditi")
+	if !ok {
+		return
+	}
+
+	var profilo *models.UserProfile
+	if raw := r.FormValue("profilo"); raw != "" {
+		profilo = &models.UserProfile{}
+		if err := json.Unmarshal([]byte(raw), profilo); err != nil {
+			i18n.Error(w, r, i18n.M("richiesta.profilo"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+	w.Header().Set("Pragma", "no-cache")
+
+	if fullText == nil {
+		json.NewEncoder(w).Encode(redditiResponse{})
+		return
+	}
+	if scansionato(*fullText) {
+		json.NewEncoder(w).Encode(redditiResponse{Scansionato: true})
+		return
+	}
+
+	d := redditi.Parse(*fullText)
+	resp := redditiResponse{Found: d.Trovato(), Dichiarazione: &d}
+	if profilo != nil {
+		d.Applica(profilo)
+		if msg, ok := validateProfile(*profilo); !ok {
+			i18n.Error(w, r, msg, http.StatusBadRequest)
+			return
+		}
+		resp.Profilo = profilo
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// readPDFUpload reads the "file" PDF of a multipart upload (max 5MB) and
+// returns its text layer. It writes the error response and returns false
+// for invalid uploads; the text is nil when the PDF can't be parsed.
+func readPDFUpload(w http.ResponseWriter, r *http.Request, handler string) (*string, bool) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+		return nil, false
 	}
 
 	// Limit upload to 5MB
 	r.Body = http.MaxBytesReader(w, r.Body, 5<<20)
 
 	if err := r.ParseMultipartForm(5 << 20); err != nil {
-		http.Error(w, "File troppo grande (max 5MB)", http.StatusBadRequest)
-		return
+		i18n.Error(w, r, i18n.M("upload.troppo_grande", "mb", 5), http.StatusBadRequest)
+		return nil, false
 	}
 
 	file, _, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "File non trovato", http.StatusBadRequest)
-		return
+		i18n.Error(w, r, i18n.M("upload.mancante"), http.StatusBadRequest)
+		return nil, false
 	}
 	defer file.Close()
 
 	// Read the file into memory
 	data, err := io.ReadAll(file)
 	if err != nil {
-		sentryutil.CaptureError(err, map[string]string{"handler": "parse-isee", "phase": "read"})
-		http.Error(w, "Errore lettura file", http.StatusInternalServerError)
-		return
+		sentryutil.CaptureError(err, map[string]string{"handler": handler, "phase": "read"})
+		i18n.Error(w, r, i18n.M("upload.lettura"), http.StatusInternalServerError)
+		return nil, false
 	}
 
 	// MIME type check — reject non-PDF files
 	mime := http.DetectContentType(data)
 	if mime != "application/pdf" {
-		http.Error(w, "Formato non valido: solo PDF accettati", http.StatusBadRequest)
-		return
+		i18n.Error(w, r, i18n.M("upload.formato"), http.StatusBadRequest)
+		return nil, false
 	}
 
 	reader := bytes.NewReader(data)
 	pdfReader, err := pdf.NewReader(reader, int64(len(data)))
 	if err != nil {
-		sentryutil.CaptureError(err, map[string]string{"handler": "parse-isee", "phase": "pdf-parse"})
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
-		w.Header().Set("Pragma", "no-cache")
-		json.NewEncoder(w).Encode(iseeResponse{ISEE: 0, Found: false})
-		return
+		sentryutil.CaptureError(err, map[string]string{"handler": handler, "phase": "pdf-parse"})
+		return nil, true
 	}
 
 	var textBuilder strings.Builder
@@ -164,37 +360,16 @@ func ParseISEEHandler(w http.ResponseWriter, r *http.Request) {
 		textBuilder.WriteString(text)
 		textBuilder.WriteString(" ")
 	}
-
-	fullText := textBuilder.String()
-	iseeValue := extractISEE(fullText)
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
-	w.Header().Set("Pragma", "no-cache")
-
-	if iseeValue > 0 {
-		json.NewEncoder(w).Encode(iseeResponse{ISEE: iseeValue, Found: true})
-	} else {
-		json.NewEncoder(w).Encode(iseeResponse{ISEE: 0, Found: false})
-	}
+	text := textBuilder.String()
+	return &text, true
 }
 
-func extractISEE(text string) float64 {
-	re := regexp.MustCompile(`(?i)(?:isee|indicatore)[:\s€]*([0-9][0-9.,]*)`)
-	matches := re.FindStringSubmatch(text)
-	if len(matches) < 2 {
-		return 0
-	}
-	numStr := matches[1]
-	// Handle Italian number format: 18.432,00 -> 18432.00
-	if strings.Contains(numStr, ",") {
-		// Remove thousand separators (dots), replace comma with dot
-		numStr = strings.ReplaceAll(numStr, ".", "")
-		numStr = strings.Replace(numStr, ",", ".", 1)
-	}
-	val, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0
-	}
-	return val
+// minTestoPDF is the number of non-space characters below which a PDF is
+// considered to have no text layer.
+const minTestoPDF = 40
+
+// scansionato reports whether the PDF text is too short to be a text
+// layer, i.e. the document was printed and scanned.
+func scansionato(text string) bool {
+	return len(strings.Join(strings.Fields(text), "")) < minTestoPDF
 }
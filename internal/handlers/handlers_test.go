@@ -1,15 +1,37 @@
 package handlers
 
 import (
+	"bonusperme/internal/calc"
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/clock"
 	"bonusperme/internal/i18n"
+	"bonusperme/internal/matcher"
+	"bonusperme/internal/models"
+	"bonusperme/internal/questionario"
+	"bonusperme/internal/validity"
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"math"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
 )
 
 func init() {
+	if err := catalog.Load("../../data/catalog"); err != nil {
+		panic(err)
+	}
+	if err := i18n.Load("../../data/i18n"); err != nil {
+		panic(err)
+	}
 	InitCounter()
 	SetTranslationLoader(i18n.GetAll)
 }
@@ -48,6 +70,320 @@ func TestMatchHandler_AgeLessThan18(t *testing.T) {
 	}
 }
 
+func TestMatchHandler_AsOf(t *testing.T) {
+	body := `{"eta":40,"isee":20000,"occupazione":"dipendente","ristrutturaz_casa":true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/match?as_of=2027-01-15", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	MatchHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var result models.MatchResult
+	json.Unmarshal(w.Body.Bytes(), &result)
+	if result.DataValutazione != "2027-01-15" {
+		t.Errorf("Expected data_valutazione 2027-01-15, got %q", result.DataValutazione)
+	}
+	for _, b := range result.Bonus {
+		if b.ID == "bonus-mobili" && !b.Scaduto {
+			t.Error("Bonus mobili (scadenza 31 dicembre 2026) should be expired as of 2027-01-15")
+		}
+	}
+
+	// The confidence is computed as of the evaluation date too
+	verificato := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	b := models.Bonus{ID: "x", Importo: "100", Ente: "INPS", FonteURL: "https://www.inps.it",
+		RiferimentiNormativi: []string{"L. 1/2026"}, UltimaVerificaGU: &verificato,
+		UltimaVerifica: verificato, AnnoConferma: 2026}
+	for _, tc := range []struct {
+		asOf string
+		want float64
+	}{
+		{"2026-10-18", 0.50},
+		{"2027-03-01", 0.15}, // verified over 120 days before, not confirmed for 2027
+	} {
+		r := models.MatchResult{Bonus: []models.Bonus{b}}
+		at, _ := clock.ParseDate(tc.asOf)
+		applyStatus(&r, at, true)
+		if got := r.Bonus[0].ConfidenceScore; math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("confidence as of %s: got %.2f, want %.2f", tc.asOf, got, tc.want)
+		}
+	}
+
+	req2 := httptest.NewRequest(http.MethodPost, "/api/match?as_of=15/01/2027", strings.NewReader(body))
+	w2 := httptest.NewRecorder()
+	MatchHandler(w2, req2)
+	if w2.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed as_of, got %d", w2.Code)
+	}
+}
+
+func TestMatchHandler_Lang(t *testing.T) {
+	body := `{"eta":35,"numero_figli":1,"figli_minorenni":1,"figli_under3":1,"isee":15000,"occupazione":"dipendente"}`
+	match := func(target, acceptLanguage string) models.MatchResult {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		MatchHandler(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", target, w.Code, w.Body.String())
+		}
+		if strings.Contains(w.Body.String(), `"traduzioni"`) {
+			t.Errorf("%s: response should not carry every translation", target)
+		}
+		var result models.MatchResult
+		json.Unmarshal(w.Body.Bytes(), &result)
+		return result
+	}
+	find := func(r models.MatchResult, id string) models.Bonus {
+		t.Helper()
+		for _, b := range r.Bonus {
+			if b.ID == id {
+				return b
+			}
+		}
+		t.Fatalf("bonus %s not matched", id)
+		return models.Bonus{}
+	}
+
+	en := match("/api/match?lang=en", "fr")
+	if en.Lingua != "en" {
+		t.Errorf("lang parameter should win over Accept-Language, got %q", en.Lingua)
+	}
+	au := find(en, "assegno-unico")
+	if !strings.HasPrefix(au.Descrizione, "Monthly allowance") || au.Lingua != "en" || au.FAQ[0].Domanda != "Can I apply if I am separated?" {
+		t.Errorf("assegno-unico not in English: %q", au.Descrizione)
+	}
+	if au.Nome != "Assegno Unico Universale" {
+		t.Errorf("untranslated name should stay Italian, got %q", au.Nome)
+	}
+
+	fr := find(match("/api/match", "de-DE, fr-CH;q=0.8, en;q=0.5"), "assegno-unico")
+	if fr.Lingua != "fr" || !strings.HasPrefix(fr.Descrizione, "Allocation mensuelle") {
+		t.Errorf("Accept-Language fr not applied: %q", fr.Descrizione)
+	}
+
+	// No translation in this language: Italian text, no lingua.
+	for _, b := range match("/api/match?lang=ro", "").Bonus {
+		if b.ID != "assegno-unico" && b.Lingua != "" {
+			t.Errorf("%s: unexpected lingua %q", b.ID, b.Lingua)
+		}
+	}
+	it := find(match("/api/match?lang=xx", ""), "assegno-unico")
+	if it.Lingua != "" || !strings.HasPrefix(it.Descrizione, "Assegno mensile") {
+		t.Errorf("unsupported lang should fall back to Italian: %q", it.Descrizione)
+	}
+}
+
+func TestBonusPage(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/bonus/assegno-unico?lang=ar", nil)
+	w := httptest.NewRecorder()
+	BonusPageHandler(w, req)
+	page := w.Body.String()
+	for _, want := range []string{`<html lang="ar" dir="rtl">`, "إعانة شهرية", `hreflang="en" href="/bonus/assegno-unico?lang=en"`, "الموعد النهائي"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("bonus page missing %q", want)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/bonus/bonus-nido", nil)
+	req.Header.Set("Accept-Language", "en-GB,en;q=0.9")
+	w = httptest.NewRecorder()
+	BonusDetailHandler(w, req)
+	var b models.Bonus
+	json.Unmarshal(w.Body.Bytes(), &b)
+	if b.Lingua != "en" || b.Requisiti[0] != "Children under 3" || w.Header().Get("Vary") != "Accept-Language" {
+		t.Errorf("bonus detail not localized: %+v", b.Requisiti)
+	}
+}
+
+func TestLocalizedErrors(t *testing.T) {
+	post := func(target, acceptLanguage string) (int, map[string]interface{}) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"eta":16}`))
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		MatchHandler(w, req)
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: error body is not JSON: %q", target, w.Body.String())
+		}
+		return w.Code, body
+	}
+
+	for _, tc := range []struct{ target, acceptLanguage, want string }{
+		{"/api/match", "", "Eta non valida (18-120)"},
+		{"/api/match?lang=en", "fr", "Invalid age (18-120)"},
+		{"/api/match", "fr-CH, en;q=0.5", "Âge non valide (18-120)"},
+	} {
+		code, body := post(tc.target, tc.acceptLanguage)
+		if code != http.StatusBadRequest || body["codice"] != "profilo.eta" || body["error"] != tc.want {
+			t.Errorf("%s [%s]: got %d %v", tc.target, tc.acceptLanguage, code, body)
+		}
+		if p, _ := body["parametri"].(map[string]interface{}); p["min"] != 18.0 || p["max"] != 120.0 {
+			t.Errorf("%s: parametri = %v", tc.target, body["parametri"])
+		}
+	}
+
+	// Scenario errors carry the localized reason and its code
+	req := httptest.NewRequest(http.MethodPost, "/api/simulate?lang=en",
+		strings.NewReader(`{"eta":35,"scenari":[{"nome":"Pensione","modifiche":{"eta":200}}]}`))
+	w := httptest.NewRecorder()
+	SimulateHandler(w, req)
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if w.Code != http.StatusBadRequest || body["codice"] != "simulazione.scenario" || body["error"] != "Pensione: Invalid age (18-120)" {
+		t.Errorf("scenario error: got %d %v", w.Code, body)
+	}
+	if p, _ := body["parametri"].(map[string]interface{}); p["causa"] != "profilo.eta" {
+		t.Errorf("scenario error should name the cause, got %v", body["parametri"])
+	}
+}
+
+func TestLocalizeAvvisi(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	result := models.MatchResult{Bonus: []models.Bonus{
+		{ID: "a", StatoValidita: "in_scadenza", ScadenzaDomanda: now.Add(72 * time.Hour)},
+		{ID: "b", StatoValidita: "potenzialmente_scaduto"},
+	}}
+	applied := validity.GenerateAvvisi(result.Bonus, now)
+	if len(applied) != 2 || applied[0].Codice != "avviso.scade_tra" || applied[0].Messaggio != "Scade tra 3 giorni — Fai domanda subito" {
+		t.Fatalf("unexpected avvisi: %+v", applied)
+	}
+	result.Avvisi = applied
+	localizeResult(&result, "en")
+	if got := result.Avvisi[0].Messaggio; got != "Expires in 3 days — Apply now" {
+		t.Errorf("avviso not localized: %q", got)
+	}
+	if got := result.Avvisi[1].Messaggio; got != "This bonus may no longer be available" {
+		t.Errorf("avviso not localized: %q", got)
+	}
+
+	// Notes of windows the profile cannot compute are message codes too
+	nota := validity.GenerateAvvisi([]models.Bonus{{ID: "c", Finestra: &models.Finestra{Stato: models.FinestraDaDefinire, Nota: "finestra.serve_data_nascita"}}}, now)
+	if len(nota) != 1 || nota[0].Codice != "finestra.serve_data_nascita" {
+		t.Fatalf("window note: %+v", nota)
+	}
+	localizeResult(&models.MatchResult{Avvisi: nota}, "en")
+	if got := nota[0].Messaggio; got != "Enter the child's date of birth to calculate the deadline" {
+		t.Errorf("window note not localized: %q", got)
+	}
+
+	// Alternatives carry bonus IDs: names and reason come from the
+	// localized catalog
+	dedicata, _ := catalog.Lookup("carta-dedicata")
+	adi, _ := catalog.Lookup("adi")
+	alt := models.Alternativa{Bonus: dedicata, Scelti: []string{"adi"}, Motivo: dedicata.Incompatibili[1].Motivo}
+	m := matcher.MessaggioAlternativa(alt, func(id string) string { return map[string]string{"adi": "ADI", "carta-dedicata": "Carta"}[id] })
+	alt.Messaggio, alt.Codice, alt.Parametri = m.Text(i18n.Default), m.Code, m.Params
+	if m.Params["bonus_id"] != "carta-dedicata" || m.Params["scelti_id"] != "adi" {
+		t.Fatalf("alternative params: %v", m.Params)
+	}
+	result.Bonus = []models.Bonus{adi}
+	result.Alternative = []models.Alternativa{alt}
+	localizeResult(&result, "en")
+	want := dedicata.Localized("en").Nome + " cannot be combined with " + adi.Localized("en").Nome + ": the combination worth more to you is counted"
+	if got := result.Alternative[0].Messaggio; got != want {
+		t.Errorf("alternative not localized: %q, want %q", got, want)
+	}
+	if got := result.Alternative[0].Motivo; got != "The Carta Dedicata a te excludes households receiving the Assegno di Inclusione" {
+		t.Errorf("alternative reason not localized: %q", got)
+	}
+	result.Bonus = nil
+
+	// Near misses name the profile field: its label follows the language
+	m = i18n.M("quasi.oltre", "campo", "eta", "etichetta", "Eta", "valore", "36", "soglia", "35", "scarto", "1")
+	result.QuasiIdonei = []models.QuasiIdoneo{{Bonus: models.Bonus{ID: "adi"}, Requisito: models.EsitoRequisito{Campo: "eta"},
+		Messaggio: m.Text(i18n.Default), Codice: m.Code, Parametri: m.Params}}
+	if result.QuasiIdonei[0].Messaggio != "Eta 36: 1 sopra il limite di 35" {
+		t.Errorf("near miss message: %q", result.QuasiIdonei[0].Messaggio)
+	}
+	localizeResult(&result, "en")
+	if got := result.QuasiIdonei[0].Messaggio; got != "Age 36: 1 above the limit of 35" {
+		t.Errorf("near miss not localized: %q", got)
+	}
+}
+
+func TestCalendarHandler(t *testing.T) {
+	get := func(items string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/calendar?bonuses="+url.QueryEscape(items), nil)
+		w := httptest.NewRecorder()
+		CalendarHandler(w, req)
+		return w
+	}
+
+	// No datable deadline: nothing to export instead of a made-up 31 December
+	if w := get(`[{"nome":"Assegno Unico","scadenza":"In vigore"}]`); w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 for a bonus without deadline, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := get(`[{"nome":"Bonus Nascita","scadenza":"Entro 60 giorni dalla nascita","finestra":{"stato":"aperta","apertura":"2026-09-01","chiusura":"2026-10-31"}},
+		{"nome":"Borsa di studio","finestra":{"stato":"in_apertura","apertura":"2027-07-01","chiusura":"2027-09-30","ricorrente":true}}]`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"DTSTART;VALUE=DATE:20261031", "SUMMARY:Domande aperte: Borsa di studio", "RRULE:FREQ=YEARLY", "DTSTART;VALUE=DATE:20270701", "DTSTART;VALUE=DATE:20270930"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Missing %q in calendar:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "VTIMEZONE") {
+		t.Error("All-day events need no VTIMEZONE")
+	}
+
+	// A click day is at the hour in Italy, whatever the subscriber's zone
+	w = get(`[{"nome":"Bonus elettrodomestici","finestra":{"stato":"in_apertura","apertura":"2027-03-15","ora":"09:30"}}]`)
+	body = w.Body.String()
+	for _, want := range []string{"BEGIN:VTIMEZONE\r\nTZID:Europe/Rome", "DTSTART;TZID=Europe/Rome:20270315T093000", "DTEND;TZID=Europe/Rome:20270315T103000"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Missing %q in calendar:\n%s", want, body)
+		}
+	}
+	if strings.Index(body, "END:VTIMEZONE") > strings.Index(body, "BEGIN:VEVENT") {
+		t.Errorf("VTIMEZONE must precede the events:\n%s", body)
+	}
+}
+
+func TestCalendarFeedHandler(t *testing.T) {
+	defer clock.Set(clock.Fixed(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)))()
+
+	data, _ := json.Marshal(toCompact(models.UserProfile{Eta: 20, ISEE: 15000, Studente: true}))
+	code := legacyCodePrefix + base64.RawURLEncoding.EncodeToString(data)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		CalendarFeedHandler(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/api/calendar/feed/" + code + ".ics")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"X-WR-CALNAME:", "UID:borsa-studio-apertura-", "SUMMARY:Domande aperte: Borse di Studio", "DTSTART;VALUE=DATE:20270701",
+		"RRULE:FREQ=YEARLY", "UID:isee-scadenza-", "DTSTART;VALUE=DATE:20261231",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Missing %q in feed:\n%s", want, body)
+		}
+	}
+	if again := get("/api/calendar/feed/" + code + ".ics").Body.String(); again != body {
+		t.Error("Feed should be stable across fetches")
+	}
+
+	if w := get("/api/calendar/feed/BPM-nonvalido.ics"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid code, got %d", w.Code)
+	}
+}
+
 func TestTranslationsHandler_EN(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/translations?lang=en", nil)
 	w := httptest.NewRecorder()
@@ -86,43 +422,194 @@ func TestHealthHandler(t *testing.T) {
 	}
 }
 
-func TestEncodeDecodeProfile(t *testing.T) {
-	// Encode
-	body := `{"eta":30,"residenza":"Lombardia","numero_figli":2,"isee":15000}`
-	req := httptest.NewRequest(http.MethodPost, "/api/encode-profile", strings.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-	w := httptest.NewRecorder()
+func TestComuni(t *testing.T) {
+	get := func(query string) (int, []map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/api/comuni?"+query, nil)
+		w := httptest.NewRecorder()
+		ComuniHandler(w, req)
+		var out []map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &out)
+		return w.Code, out
+	}
+	if code, out := get("q=reggio+nell%27emilia"); code != http.StatusOK || len(out) != 1 || out[0]["codice"] != "035033" || out[0]["sigla"] != "RE" {
+		t.Errorf("search by name: %d %v", code, out)
+	}
+	if _, out := get("q=00144"); len(out) != 1 || out[0]["nome"] != "Roma" {
+		t.Errorf("search by CAP: %v", out)
+	}
+	if _, out := get("codice=015146"); len(out) != 1 || out[0]["regione"] != "Lombardia" {
+		t.Errorf("lookup by code: %v", out)
+	}
+	if _, out := get("codice=021008"); len(out) != 1 || out[0]["residenza"] != "021" {
+		t.Errorf("autonomous province: %v", out)
+	}
+	if code, _ := get("codice=999999"); code != http.StatusNotFound {
+		t.Errorf("unknown code: got %d", code)
+	}
+
+	for _, tc := range []struct {
+		body, codice string
+	}{
+		{`{"eta":30,"comune":"058091"}`, ""},
+		{`{"eta":30,"comune":"Roma","residenza":"Lazio"}`, ""},
+		{`{"eta":30,"comune":"Sauze d'Oulx","residenza":"Piemonte"}`, ""},
+		{`{"eta":30,"comune":"` + strings.Repeat("x", 81) + `"}`, "profilo.comune"},
+		{`{"eta":30,"comune":"058091","residenza":"Lombardia"}`, "profilo.comune_regione"},
+		{`{"eta":30,"residenza":"12"}`, ""},
+		{`{"eta":30,"residenza":"Friuli Venezia Giulia"}`, ""},
+		{`{"eta":30,"residenza":"Atlantide"}`, "profilo.regione"},
+		{`{"eta":30,"comune":"Trento","residenza":"Trentino-Alto Adige"}`, ""},
+		{`{"eta":30,"comune":"022205","residenza":"021"}`, "profilo.comune_regione"},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/match", strings.NewReader(tc.body))
+		w := httptest.NewRecorder()
+		MatchHandler(w, req)
+		var body map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &body)
+		if tc.codice == "" && w.Code != http.StatusOK {
+			t.Errorf("%s: got %d %v", tc.body, w.Code, body)
+		}
+		if tc.codice != "" && (w.Code != http.StatusBadRequest || body["codice"] != tc.codice) {
+			t.Errorf("%s: want %s, got %d %v", tc.body, tc.codice, w.Code, body)
+		}
+	}
+}
 
-	EncodeProfileHandler(w, req)
+func TestNucleo(t *testing.T) {
+	inArrivo := time.Now().AddDate(0, 4, 0).Format("2006-01-02")
+	for _, tc := range []struct {
+		nucleo, codice string
+	}{
+		{`[{"relazione":"figlio","data_nascita":"2024-01-10"},{"relazione":"coniuge","data_nascita":"1990-05-05","occupazione":"dipendente","reddito":18000}]`, ""},
+		{`[{"relazione":"figlio","data_nascita":"` + inArrivo + `"}]`, ""},
+		{`[{"relazione":"cugino","data_nascita":"1990-05-05"}]`, "profilo.componente_relazione"},
+		{`[{"relazione":"coniuge","data_nascita":"` + inArrivo + `"}]`, "profilo.componente_nascita"},
+		{`[{"relazione":"figlio","data_nascita":"10/01/2024"}]`, "profilo.componente_nascita"},
+		{`[{"relazione":"figlio","data_nascita":"2024-01-10","disabilita":"lieve"}]`, "profilo.componente_dati"},
+	} {
+		body := `{"eta":35,"isee":15000,"nucleo":` + tc.nucleo + `}`
+		req := httptest.NewRequest(http.MethodPost, "/api/match", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		MatchHandler(w, req)
+		var res map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &res)
+		if tc.codice == "" && w.Code != http.StatusOK {
+			t.Errorf("%s: got %d %v", tc.nucleo, w.Code, res)
+		}
+		if tc.codice != "" && (w.Code != http.StatusBadRequest || res["codice"] != tc.codice) {
+			t.Errorf("%s: want %s, got %d %v", tc.nucleo, tc.codice, w.Code, res)
+		}
+	}
 
+	// Members replace the counters, so stale counters sent alongside are
+	// not cross-checked
+	body := `{"eta":35,"numero_figli":0,"figli_under3":2,"nucleo":[{"relazione":"figlio","data_nascita":"2024-01-10"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/match", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	MatchHandler(w, req)
 	if w.Code != http.StatusOK {
-		t.Fatalf("Encode: expected 200, got %d: %s", w.Code, w.Body.String())
+		t.Errorf("counters with nucleo: got %d %s", w.Code, w.Body.String())
 	}
+}
 
-	var encResult map[string]string
-	json.Unmarshal(w.Body.Bytes(), &encResult)
-	code := encResult["code"]
-	if code == "" || !strings.HasPrefix(code, "BPM-") {
-		t.Fatalf("Expected code with BPM- prefix, got: %s", code)
+func TestNextQuestionsHandler(t *testing.T) {
+	post := func(url, body string) (int, []models.Domanda) {
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		NextQuestionsHandler(w, req)
+		var out struct {
+			Domande []models.Domanda `json:"domande"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &out)
+		return w.Code, out.Domande
 	}
 
-	// Decode
-	req2 := httptest.NewRequest(http.MethodGet, "/api/decode-profile?code="+code, nil)
-	w2 := httptest.NewRecorder()
+	// A zero ISEE sent by the client counts as answered
+	code, domande := post("/api/questionnaire/next", `{"eta":30,"isee":0,"numero_figli":1,"figli_minorenni":1}`)
+	if code != http.StatusOK || len(domande) == 0 {
+		t.Fatalf("status %d, %d questions", code, len(domande))
+	}
+	for _, d := range domande {
+		if d.Campo == "isee" || d.Campo == "eta" {
+			t.Errorf("answered field %s returned", d.Campo)
+		}
+	}
 
-	DecodeProfileHandler(w2, req2)
+	// Age is optional, but checked once given
+	if code, domande := post("/api/questionnaire/next?ordine=euro", `{}`); code != http.StatusOK || len(domande) == 0 {
+		t.Errorf("empty profile: status %d", code)
+	} else {
+		for i := 1; i < len(domande); i++ {
+			if domande[i].Euro > domande[i-1].Euro {
+				t.Errorf("ordine=euro: %s before %s", domande[i-1].Campo, domande[i].Campo)
+			}
+		}
+	}
+	if code, _ := post("/api/questionnaire/next", `{"eta":12}`); code != http.StatusBadRequest {
+		t.Errorf("invalid age: status %d", code)
+	}
+}
 
-	if w2.Code != http.StatusOK {
-		t.Fatalf("Decode: expected 200, got %d: %s", w2.Code, w2.Body.String())
+func TestQuestionnaireHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/questionnaire?lang=en", nil)
+	w := httptest.NewRecorder()
+	QuestionnaireHandler(w, req)
+	var q questionario.Schema
+	if err := json.Unmarshal(w.Body.Bytes(), &q); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("status %d: %v", w.Code, err)
+	}
+	if len(q.Passi) != 4 || len(q.Domande) != len(questionario.Domande) || len(q.Regole) == 0 {
+		t.Fatalf("%d steps, %d questions, %d rules", len(q.Passi), len(q.Domande), len(q.Regole))
+	}
+	domande := map[string]questionario.SchemaDomanda{}
+	for _, d := range q.Domande {
+		domande[d.Campo] = d
+		if d.Etichetta == "" || (d.Errore != "" && d.Messaggio == "") {
+			t.Errorf("%s: missing label or message", d.Campo)
+		}
+		for _, o := range d.Opzioni {
+			if o.Etichetta == "" {
+				t.Errorf("%s: option %q without label", d.Campo, o.Valore)
+			}
+		}
+	}
+	if d := domande["eta"]; d.Etichetta != "Age" || d.Messaggio != "Invalid age (18-120)" || d.Mancante == "" {
+		t.Errorf("eta: %+v", d)
+	}
+	if d := domande["disabilita_figli"]; len(d.Se) != 1 || d.Se[0].Campo != "figli_disabili" {
+		t.Errorf("disabilita_figli shown: %+v", d.Se)
+	}
+	if d := domande["residenza"]; len(d.Opzioni) != 21 {
+		t.Errorf("residenza: %d regions", len(d.Opzioni))
 	}
 
-	var profile map[string]interface{}
-	json.Unmarshal(w2.Body.Bytes(), &profile)
-	if profile["eta"].(float64) != 30 {
-		t.Errorf("Expected eta 30, got %v", profile["eta"])
+	// validateProfile accepts exactly the listed answers
+	for _, o := range domande["stato_civile"].Opzioni {
+		if msg, ok := validateProfile(models.UserProfile{Eta: 30, StatoCivile: o.Valore}); !ok {
+			t.Errorf("stato_civile %q rejected: %s", o.Valore, msg.Code)
+		}
 	}
-	if profile["residenza"] != "Lombardia" {
-		t.Errorf("Expected residenza Lombardia, got %v", profile["residenza"])
+	if msg, ok := validateProfile(models.UserProfile{Eta: 30, StatoCivile: "sposato"}); ok || msg.Code != "profilo.stato_civile" {
+		t.Errorf("stato_civile sposato: %v %s", ok, msg.Code)
+	}
+	if msg, ok := validateProfile(models.UserProfile{Eta: 30, NumeroFigli: 1, FigliMinorenni: 1, FigliUnder3: 2}); ok || msg.Code != "profilo.under3_oltre_minorenni" {
+		t.Errorf("under3 over minorenni: %v %s", ok, msg.Code)
+	}
+}
+
+func TestReportHandler_Lang(t *testing.T) {
+	body := `{"eta":34,"numero_figli":2,"figli_minorenni":2,"isee":12000,"residenza":"Lombardia","occupazione":"dipendente"}`
+	for _, lang := range []string{"it", "ro", "ar", "sq", "xx"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/report?lang="+lang, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		ReportHandler(w, req)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/pdf" {
+			t.Fatalf("%s: got %d %s", lang, w.Code, w.Body.String())
+		}
+		// Unicode TTF embedded instead of the Latin-1 core fonts
+		if !strings.Contains(w.Body.String(), "/BaseFont /utf8dejavu") {
+			t.Errorf("%s: Unicode font not embedded", lang)
+		}
 	}
 }
 
@@ -144,3 +631,348 @@ func TestBonusListHandler(t *testing.T) {
 		t.Errorf("Expected 40+ bonuses, got %d", len(bonuses))
 	}
 }
+
+func TestAssegnoUnicoCalcHandler(t *testing.T) {
+	body := `{"eta":35,"numero_figli":3,"figli_minorenni":3,"figli_under3":2,"figli_under1":1,"isee":15000,"anno":2026}`
+	req := httptest.NewRequest(http.MethodPost, "/api/calc/assegno-unico", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	AssegnoUnicoCalcHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var res calc.RisultatoAU
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if res.Anno != 2026 || res.FasciaISEE != "massima" {
+		t.Errorf("Expected anno 2026 fascia massima, got %d %s", res.Anno, res.FasciaISEE)
+	}
+	voci := map[string]float64{}
+	for _, v := range res.Voci {
+		voci[v.Codice] = v.Mensile
+	}
+	for codice, atteso := range map[string]float64{
+		"base_minorenni": 611.40, "magg_under1": 101.90, "magg_1_3": 101.90, "magg_terzo_figlio": 99.10,
+	} {
+		if voci[codice] != atteso {
+			t.Errorf("%s: expected %.2f, got %.2f", codice, atteso, voci[codice])
+		}
+	}
+	if res.Mensile != 914.30 {
+		t.Errorf("Expected 914.30/mese, got %.2f", res.Mensile)
+	}
+
+	// Same household with 2025 parameters must be lower
+	req2 := httptest.NewRequest(http.MethodPost, "/api/calc/assegno-unico", strings.NewReader(strings.Replace(body, "2026", "2025", 1)))
+	w2 := httptest.NewRecorder()
+	AssegnoUnicoCalcHandler(w2, req2)
+	var res2025 calc.RisultatoAU
+	json.Unmarshal(w2.Body.Bytes(), &res2025)
+	if res2025.Anno != 2025 || res2025.Mensile >= res.Mensile {
+		t.Errorf("Expected lower 2025 amount, got %d %.2f", res2025.Anno, res2025.Mensile)
+	}
+
+	req3 := httptest.NewRequest(http.MethodPost, "/api/calc/assegno-unico", strings.NewReader(strings.Replace(body, "2026", "1999", 1)))
+	w3 := httptest.NewRecorder()
+	AssegnoUnicoCalcHandler(w3, req3)
+	if w3.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown year, got %d", w3.Code)
+	}
+
+	// The year defaults to the clock's, and the household is derived on
+	// the same day of the requested year: a child under 1 today is 1 then
+	defer clock.Set(clock.Fixed(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)))()
+	nato := `{"eta":35,"isee":15000,"nucleo":[{"relazione":"figlio","data_nascita":"2025-12-01"}]`
+	for _, tc := range []struct {
+		body   string
+		anno   int
+		under1 bool
+	}{
+		{nato + `}`, 2026, true},
+		{nato + `,"anno":2027}`, 2027, false},
+		{nato + `,"anno":2025}`, 2025, false},
+	} {
+		w := httptest.NewRecorder()
+		AssegnoUnicoCalcHandler(w, httptest.NewRequest(http.MethodPost, "/api/calc/assegno-unico", strings.NewReader(tc.body)))
+		var res calc.RisultatoAU
+		json.Unmarshal(w.Body.Bytes(), &res)
+		under1 := false
+		for _, v := range res.Voci {
+			under1 = under1 || v.Codice == "magg_under1"
+		}
+		if w.Code != http.StatusOK || res.Anno != tc.anno || under1 != tc.under1 {
+			t.Errorf("%s: got %d anno %d under1 %v, want anno %d under1 %v", tc.body, w.Code, res.Anno, under1, tc.anno, tc.under1)
+		}
+	}
+
+	// A working child of 19 listed in the household is not counted as a minor
+	w4 := httptest.NewRecorder()
+	AssegnoUnicoCalcHandler(w4, httptest.NewRequest(http.MethodPost, "/api/calc/assegno-unico", strings.NewReader(
+		`{"eta":50,"isee":15000,"nucleo":[{"relazione":"figlio","data_nascita":"2007-05-01","reddito":20000}]}`)))
+	var res4 calc.RisultatoAU
+	json.Unmarshal(w4.Body.Bytes(), &res4)
+	if w4.Code != http.StatusOK || res4.Mensile != 0 {
+		t.Errorf("Expected no amount for a child earning over the limit, got %d %+v", w4.Code, res4.Voci)
+	}
+	if got := nellAnno(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), 2027); got.Format("2006-01-02") != "2027-02-28" {
+		t.Errorf("29 February in a common year: %s", got)
+	}
+}
+
+func TestStimaISEEHandler(t *testing.T) {
+	// 2 genitori lavoratori + 2 figli minorenni, affitto, casa con mutuo
+	body := `{
+		"componenti":[{"reddito_dipendente":25000},{"reddito_dipendente":15000},{},{}],
+		"numero_figli":2,"figli_minorenni":2,"genitori_lavoratori":true,
+		"canone_affitto":6000,
+		"immobili":[{"valore_imu":150000,"mutuo_residuo":80000,"casa_abitazione":true}],
+		"patrimonio_mobiliare":20000,
+		"profilo":{"eta":35,"numero_figli":2,"figli_minorenni":2}
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/api/calc/isee", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	StimaISEEHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var res struct {
+		calc.StimaISEE
+		Profilo *models.UserProfile `json:"profilo"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	// ISR 40.000 - 6.000 - 6.000 = 28.000; ISP 11.666,67 + 10.000; scala 2,46 + 0,2
+	if res.ISR != 28000 || res.ScalaEquivalenza != 2.66 {
+		t.Errorf("Expected ISR 28000 and scala 2.66, got %.2f %.2f", res.ISR, res.ScalaEquivalenza)
+	}
+	if res.ISEE < 12155 || res.ISEE > 12156 {
+		t.Errorf("Expected ISEE ~12155.39, got %.2f", res.ISEE)
+	}
+	if res.Profilo == nil || res.Profilo.ISEE != res.ISEE {
+		t.Errorf("Expected profilo with ISEE %.2f, got %+v", res.ISEE, res.Profilo)
+	}
+
+	req2 := httptest.NewRequest(http.MethodPost, "/api/calc/isee", strings.NewReader(`{"componenti":[]}`))
+	w2 := httptest.NewRecorder()
+	StimaISEEHandler(w2, req2)
+	if w2.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for empty household, got %d", w2.Code)
+	}
+}
+
+func uploadPDF(t *testing.T, lines []string) *httptest.ResponseRecorder {
+	return uploadPDFTo(t, ParseISEEHandler, lines, nil)
+}
+
+func uploadPDFTo(t *testing.T, h http.HandlerFunc, lines []string, fields map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "", 10)
+	for _, l := range lines {
+		pdf.Cell(0, 6, l)
+		pdf.Ln(6)
+	}
+	var doc bytes.Buffer
+	if err := pdf.Output(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "attestazione.pdf")
+	fw.Write(doc.Bytes())
+	for k, v := range fields {
+		mw.WriteField(k, v)
+	}
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestParseISEEHandler_Attestazione(t *testing.T) {
+	w := uploadPDF(t, []string{
+		"ATTESTAZIONE I.S.E.E. - Protocollo DSU INPS-ISEE-2026-01234567A-00",
+		"Nucleo familiare: numero componenti 3",
+		"Indicatore della Situazione Reddituale (ISR): 24.000,00",
+		"Indicatore della Situazione Patrimoniale (ISP): 15.000,00",
+		"Indicatore della Situazione Economica (ISE): 27.000,00",
+		"Parametro scala di equivalenza: 2,04",
+		"ISEE ordinario: 13.235,29",
+		"ISEE per prestazioni agevolate rivolte a minorenni: 12.100,50",
+		"Nella DSU sono state rilevate le seguenti omissioni/difformita",
+	})
+
+	var res iseeResponse
+	json.Unmarshal(w.Body.Bytes(), &res)
+	a := res.Attestazione
+	if !res.Found || res.ISEE != 13235.29 || res.TipoISEE != "ordinario" || a == nil {
+		t.Fatalf("Expected ordinario 13235.29, got %+v", res)
+	}
+	if a.ISEEMinorenni != 12100.50 || a.ISR != 24000 || a.ISP != 15000 || a.ISE != 27000 || a.ScalaEquivalenza != 2.04 || a.Componenti != 3 {
+		t.Errorf("Unexpected attestation values: %+v", a)
+	}
+	if a.Protocollo != "INPS-ISEE-2026-01234567A-00" || a.Scadenza != "2026-12-31" {
+		t.Errorf("Expected protocol and inferred expiry, got %q %q", a.Protocollo, a.Scadenza)
+	}
+	tipi := map[string]bool{}
+	for _, av := range res.Avvisi {
+		tipi[av.Tipo] = true
+	}
+	if !a.Difformita || !tipi["omissioni_difformita"] || tipi["valori_incoerenti"] {
+		t.Errorf("Expected only the omissioni warning, got %+v", res.Avvisi)
+	}
+}
+
+func TestParseISEEHandler_Scansionato(t *testing.T) {
+	w := uploadPDF(t, nil)
+
+	var res iseeResponse
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if res.Found || !res.Scansionato || res.Attestazione != nil || len(res.Avvisi) != 1 {
+		t.Errorf("Expected scanned PDF warning, got %+v", res)
+	}
+}
+
+func TestParseRedditiHandler_730(t *testing.T) {
+	w := uploadPDFTo(t, ParseRedditiHandler, []string{
+		"MODELLO 730-3 - Prospetto di liquidazione - Periodo d'imposta 2025",
+		"Redditi di lavoro dipendente e assimilati (rigo C1) 28.500,00",
+		"Reddito complessivo (rigo 11) 29.200,00",
+		"Reddito imponibile 27.900,00",
+		"E1 Spese sanitarie 1.329,11",
+		"E7 Interessi passivi per mutui ipotecari 3.000,00",
+		"E41 Spese per recupero del patrimonio edilizio 20.000,00",
+	}, map[string]string{"profilo": `{"eta":40,"residenza":"Lazio","prima_abitazione":true}`})
+
+	var res redditiResponse
+	json.Unmarshal(w.Body.Bytes(), &res)
+	d := res.Dichiarazione
+	if !res.Found || d == nil || d.Modello != "730" || d.AnnoImposta != 2025 {
+		t.Fatalf("Expected a 730 for 2025, got %+v", res)
+	}
+	if d.RedditoLordo != 29200 || d.RedditoImponibile != 27900 || d.Occupazione != "dipendente" {
+		t.Errorf("Unexpected income values: %+v", d)
+	}
+	if d.SpeseMediche != 1329.11 || d.InteressiMutuo != 3000 || d.SpeseRistrutturazione != 20000 {
+		t.Errorf("Unexpected expenses: %+v", d)
+	}
+	p := res.Profilo
+	if p == nil || p.RedditoAnnuo != 27900 || p.Occupazione != "dipendente" || p.SpeseMediche != 1329.11 || !p.PrimaAbitazione {
+		t.Errorf("Expected profile filled from the 730, got %+v", p)
+	}
+}
+
+func TestSimulateHandler_Sweep(t *testing.T) {
+	body := `{"eta":35,"residenza":"Lombardia","numero_figli":2,"figli_minorenni":2,"isee":15000,
+		"sweep":{"da":0,"a":30000,"passo":1000}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/simulate", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	SimulateHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var res models.SimulateResult
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if res.Sweep == nil || len(res.Sweep.Punti) != 30 || res.Sweep.Punti[0].ISEE != 1000 {
+		t.Fatalf("Expected 30 points from ISEE 1000, got %+v", res.Sweep)
+	}
+	found := false
+	for _, s := range res.Sweep.Soglie {
+		if s.BonusID == "adi" {
+			found = true
+			if s.Evento != models.SweepScompare || s.ISEE != 9360.01 {
+				t.Errorf("Expected ADI to disappear at 9360.01, got %+v", s)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected an ADI breakpoint, got %+v", res.Sweep.Soglie)
+	}
+
+	req2 := httptest.NewRequest(http.MethodPost, "/api/simulate", strings.NewReader(
+		`{"eta":35,"sweep":{"da":0,"a":500000,"passo":10}}`))
+	w2 := httptest.NewRecorder()
+	SimulateHandler(w2, req2)
+	if w2.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for too many steps, got %d", w2.Code)
+	}
+}
+
+func TestSimulateHandler_Scenari(t *testing.T) {
+	body := `{"eta":35,"residenza":"Lazio","numero_figli":1,"figli_minorenni":1,"isee":12000,
+		"scenari":[
+			{"nome":"Secondo figlio","modifiche":{"numero_figli":2,"figli_minorenni":2,"figli_under3":1,"figli_under1":1,"nuovo_nato_2026":true}},
+			{"nome":"Trasferimento","modifiche":{"residenza":"Lombardia"}}
+		]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/simulate", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	SimulateHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var res models.SimulateResult
+	json.Unmarshal(w.Body.Bytes(), &res)
+	if len(res.Scenari) != 2 {
+		t.Fatalf("Expected 2 scenarios, got %d", len(res.Scenari))
+	}
+
+	figlio := res.Scenari[0].Diff
+	gained := map[string]bool{}
+	for _, b := range figlio.Guadagnati {
+		gained[b.BonusID] = true
+	}
+	if !gained["bonus-nascita"] || figlio.DeltaRisparmioEuro <= 0 {
+		t.Errorf("Expected bonus-nascita gained and a positive delta, got %+v", figlio)
+	}
+	for _, b := range figlio.Variati {
+		if b.BonusID == "assegno-unico" && b.AnnuoDopo <= b.AnnuoPrima {
+			t.Errorf("Expected a higher Assegno Unico, got %+v", b)
+		}
+	}
+
+	trasf := res.Scenari[1].Diff
+	if len(trasf.Guadagnati) == 0 && len(trasf.Persi) == 0 {
+		t.Errorf("Expected regional bonuses to change when moving region, got %+v", trasf)
+	}
+
+	req2 := httptest.NewRequest(http.MethodPost, "/api/simulate", strings.NewReader(`{"eta":35,"modifiche":{"campo_inesistente":1}}`))
+	w2 := httptest.NewRecorder()
+	SimulateHandler(w2, req2)
+	if w2.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown field, got %d", w2.Code)
+	}
+
+	// With household members the counters are derived: a counter override
+	// is rejected, changing the members works
+	nucleo := `{"eta":35,"isee":12000,"nucleo":[{"relazione":"figlio","data_nascita":"2020-05-01"}],"scenari":[%s]}`
+	for _, tc := range []struct {
+		scenario string
+		code     int
+	}{
+		{`{"nome":"Secondo figlio","modifiche":{"numero_figli":2}}`, http.StatusBadRequest},
+		{`{"nome":"Secondo figlio","modifiche":{"nucleo":[{"relazione":"figlio","data_nascita":"2020-05-01"},{"relazione":"figlio","data_nascita":"2026-01-10"}]}}`, http.StatusOK},
+		{`{"nome":"Senza componenti","modifiche":{"nucleo":null,"numero_figli":2}}`, http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		SimulateHandler(w, httptest.NewRequest(http.MethodPost, "/api/simulate", strings.NewReader(fmt.Sprintf(nucleo, tc.scenario))))
+		if w.Code != tc.code {
+			t.Errorf("%s: expected %d, got %d: %s", tc.scenario, tc.code, w.Code, w.Body.String())
+		}
+		if tc.code == http.StatusBadRequest && !strings.Contains(w.Body.String(), "simulazione.contatore_nucleo") {
+			t.Errorf("Expected the nucleo counter error, got %s", w.Body.String())
+		}
+	}
+}
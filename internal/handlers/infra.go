@@ -3,6 +3,7 @@ package handlers
 import (
 	"bonusperme/internal/blog"
 	"bonusperme/internal/config"
+	"bonusperme/internal/i18n"
 	"bonusperme/internal/matcher"
 	"bonusperme/internal/scraper"
 	"encoding/json"
@@ -172,23 +173,33 @@ func BonusPageHandler(w http.ResponseWriter, r *http.Request) {
 	// Extract bonus ID from path: /bonus/{id}
 	path := strings.TrimPrefix(r.URL.Path, "/bonus/")
 	bonusID := strings.TrimSuffix(path, "/")
+	lang := i18n.FromRequest(r)
 	if bonusID == "" {
-		http.Error(w, "Bonus non trovato", http.StatusNotFound)
+		http.Error(w, i18n.M("bonus.non_trovato").Text(lang), http.StatusNotFound)
 		return
 	}
 
 	allBonuses := scraper.GetCachedBonus()
 	for _, b := range allBonuses {
 		if b.ID == bonusID {
-			serveBonusPage(w, b)
+			alternates := []string{i18n.Default}
+			for _, l := range i18n.Languages {
+				if _, ok := b.Traduzioni[l]; ok {
+					alternates = append(alternates, l)
+				}
+			}
+			w.Header().Set("Vary", "Accept-Language")
+			serveBonusPage(w, b.Localized(lang), lang, alternates)
 			return
 		}
 	}
 
-	http.Error(w, "Bonus non trovato", http.StatusNotFound)
+	http.Error(w, i18n.M("bonus.non_trovato").Text(lang), http.StatusNotFound)
 }
 
-func serveBonusPage(w http.ResponseWriter, b interface{}) {
+// serveBonusPage renders a bonus already localized in lang. alternates are
+// the languages the bonus is translated in, linked with hreflang.
+func serveBonusPage(w http.ResponseWriter, b interface{}, lang string, alternates []string) {
 	type bonusLike struct {
 		ID                  string
 		Nome                string
@@ -212,11 +223,30 @@ func serveBonusPage(w http.ResponseWriter, b interface{}) {
 	var bonus bonusLike
 	json.Unmarshal(data, &bonus)
 
+	tr := getTranslations(lang)
+	trIT := getTranslations(i18n.Default)
+	t := func(key string) string {
+		if s := tr[key]; s != "" {
+			return s
+		}
+		return trIT[key]
+	}
+	pageURL := func(l string) string {
+		if l == i18n.Default {
+			return "/bonus/" + bonus.ID
+		}
+		return "/bonus/" + bonus.ID + "?lang=" + l
+	}
+	dir := "ltr"
+	if i18n.IsRTL(lang) {
+		dir = "rtl"
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
 	var sb strings.Builder
 	sb.WriteString(`<!DOCTYPE html>
-<html lang="it">
+<html lang="` + lang + `" dir="` + dir + `">
 <head>
 <meta charset="UTF-8">
 <meta name="viewport" content="width=device-width, initial-scale=1.0">
@@ -224,7 +254,12 @@ func serveBonusPage(w http.ResponseWriter, b interface{}) {
 <meta name="description" content="` + htmlEscape(truncate(bonus.Descrizione, 160)) + `">
 <meta property="og:title" content="` + htmlEscape(bonus.Nome) + ` - BonusPerMe">
 <meta property="og:description" content="` + htmlEscape(truncate(bonus.Descrizione, 160)) + `">
-<link rel="canonical" href="/bonus/` + htmlEscape(bonus.ID) + `">
+<link rel="canonical" href="` + htmlEscape(pageURL(lang)) + `">`)
+	for _, l := range alternates {
+		sb.WriteString(`
+<link rel="alternate" hreflang="` + l + `" href="` + htmlEscape(pageURL(l)) + `">`)
+	}
+	sb.WriteString(`
 <style>
 body{font-family:system-ui,sans-serif;max-width:800px;margin:0 auto;padding:20px;color:#333;line-height:1.6}
 h1{color:#003366;border-bottom:2px solid #0066cc;padding-bottom:10px}
@@ -242,33 +277,33 @@ footer{margin-top:40px;padding-top:20px;border-top:1px solid #ddd;color:#999;fon
 </style>
 </head>
 <body>
-<a href="/" class="back">← Torna a BonusPerMe</a>
+<a href="/" class="back">` + htmlEscape(t("page.back")) + `</a>
 <h1>` + htmlEscape(bonus.Nome) + `</h1>
 <div class="meta">`)
 
 	if bonus.Ente != "" {
-		sb.WriteString(`<strong>Ente:</strong> ` + htmlEscape(bonus.Ente))
+		sb.WriteString(`<strong>` + htmlEscape(t("page.ente")) + `:</strong> ` + htmlEscape(bonus.Ente))
 	}
 	if bonus.Stato != "" {
 		sb.WriteString(` <span class="badge badge-` + htmlEscape(bonus.Stato) + `">` + htmlEscape(strings.ToUpper(bonus.Stato[:1])+bonus.Stato[1:]) + `</span>`)
 	}
 	if bonus.UltimoAggiornamento != "" {
-		sb.WriteString(`<br><strong>Aggiornato:</strong> ` + htmlEscape(bonus.UltimoAggiornamento))
+		sb.WriteString(`<br><strong>` + htmlEscape(t("results.last_update")) + `</strong> ` + htmlEscape(bonus.UltimoAggiornamento))
 	}
 	sb.WriteString(`</div>`)
 
 	if bonus.Importo != "" {
-		sb.WriteString(`<p class="importo">Importo: ` + htmlEscape(bonus.Importo) + `</p>`)
+		sb.WriteString(`<p class="importo">` + htmlEscape(t("results.importo")) + `: ` + htmlEscape(bonus.Importo) + `</p>`)
 	}
 
 	sb.WriteString(`<div class="section"><p>` + htmlEscape(bonus.Descrizione) + `</p></div>`)
 
 	if bonus.Scadenza != "" {
-		sb.WriteString(`<div class="section"><h2>Scadenza</h2><p>` + htmlEscape(bonus.Scadenza) + `</p></div>`)
+		sb.WriteString(`<div class="section"><h2>` + htmlEscape(t("page.scadenza")) + `</h2><p>` + htmlEscape(bonus.Scadenza) + `</p></div>`)
 	}
 
 	if len(bonus.Requisiti) > 0 {
-		sb.WriteString(`<div class="section"><h2>Requisiti</h2><ul>`)
+		sb.WriteString(`<div class="section"><h2>` + htmlEscape(t("results.requisiti")) + `</h2><ul>`)
 		for _, r := range bonus.Requisiti {
 			sb.WriteString(`<li>` + htmlEscape(r) + `</li>`)
 		}
@@ -276,7 +311,7 @@ footer{margin-top:40px;padding-top:20px;border-top:1px solid #ddd;color:#999;fon
 	}
 
 	if len(bonus.ComeRichiederlo) > 0 {
-		sb.WriteString(`<div class="section"><h2>Come Richiederlo</h2><ol>`)
+		sb.WriteString(`<div class="section"><h2>` + htmlEscape(t("results.come_fare")) + `</h2><ol>`)
 		for _, s := range bonus.ComeRichiederlo {
 			sb.WriteString(`<li>` + htmlEscape(s) + `</li>`)
 		}
@@ -284,7 +319,7 @@ footer{margin-top:40px;padding-top:20px;border-top:1px solid #ddd;color:#999;fon
 	}
 
 	if len(bonus.Documenti) > 0 {
-		sb.WriteString(`<div class="section"><h2>Documenti Necessari</h2><ul>`)
+		sb.WriteString(`<div class="section"><h2>` + htmlEscape(t("results.documenti")) + `</h2><ul>`)
 		for _, d := range bonus.Documenti {
 			sb.WriteString(`<li>` + htmlEscape(d) + `</li>`)
 		}
@@ -292,27 +327,27 @@ footer{margin-top:40px;padding-top:20px;border-top:1px solid #ddd;color:#999;fon
 	}
 
 	if bonus.FonteURL != "" || bonus.FonteNome != "" || len(bonus.RiferimentiNormativi) > 0 {
-		sb.WriteString(`<div class="fonte"><strong>Fonti e riferimenti</strong><br>`)
+		sb.WriteString(`<div class="fonte"><strong>` + htmlEscape(t("results.fonti")) + `</strong><br>`)
 		if bonus.FonteNome != "" {
-			sb.WriteString(`Fonte: ` + htmlEscape(bonus.FonteNome))
+			sb.WriteString(htmlEscape(t("results.fonte_ist")) + ` ` + htmlEscape(bonus.FonteNome))
 		}
 		if bonus.FonteURL != "" {
-			sb.WriteString(` — <a href="` + htmlEscape(bonus.FonteURL) + `" target="_blank" rel="noopener">Sito ufficiale</a>`)
+			sb.WriteString(` — <a href="` + htmlEscape(bonus.FonteURL) + `" target="_blank" rel="noopener">` + htmlEscape(t("results.link_ufficiale")) + `</a>`)
 		}
 		if len(bonus.RiferimentiNormativi) > 0 {
-			sb.WriteString(`<br>Riferimenti: ` + htmlEscape(strings.Join(bonus.RiferimentiNormativi, "; ")))
+			sb.WriteString(`<br>` + htmlEscape(t("results.fonte_edit")) + ` ` + htmlEscape(strings.Join(bonus.RiferimentiNormativi, "; ")))
 		}
 		sb.WriteString(`</div>`)
 	}
 
 	if bonus.LinkUfficiale != "" {
-		sb.WriteString(`<p><a href="` + htmlEscape(bonus.LinkUfficiale) + `" target="_blank" rel="noopener">Vai al sito ufficiale →</a></p>`)
+		sb.WriteString(`<p><a href="` + htmlEscape(bonus.LinkUfficiale) + `" target="_blank" rel="noopener">` + htmlEscape(t("results.link_ufficiale")) + ` →</a></p>`)
 	}
 
 	sb.WriteString(`
 <footer>
-<p>BonusPerMe — Servizio gratuito e indipendente. Le informazioni sono a scopo orientativo.</p>
-<p><a href="/">Verifica i tuoi bonus →</a></p>
+<p>` + htmlEscape(t("page.footer")) + `</p>
+<p><a href="/">` + htmlEscape(t("page.cta")) + `</a></p>
 </footer>
 </body>
 </html>`)
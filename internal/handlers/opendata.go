@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/istat"
 	"bonusperme/internal/linkcheck"
 	"bonusperme/internal/matcher"
 	"bonusperme/internal/scraper"
 	"bonusperme/internal/validity"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -17,8 +20,8 @@ func setCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Max-Age", "86400")
 }
 
-// BonusListHandler returns the full list of all bonuses (national + regional) as JSON.
-// GET /api/bonus
+// BonusListHandler returns the full list of all bonuses (national, regional and local) as JSON.
+// GET /api/bonus[?lang=en]
 func BonusListHandler(w http.ResponseWriter, r *http.Request) {
 	setCORSHeaders(w)
 	if r.Method == http.MethodOptions {
@@ -35,21 +38,25 @@ func BonusListHandler(w http.ResponseWriter, r *http.Request) {
 	if len(allBonus) == 0 {
 		allBonus = matcher.GetAllBonusWithRegional()
 	} else {
-		// Append regional bonuses if not already present
-		regionals := matcher.GetRegionalBonus()
-		allBonus = append(allBonus, regionals...)
+		// Append regional and local bonuses if not already present
+		allBonus = append(allBonus, matcher.GetTerritorialBonus()...)
 	}
 
 	linkcheck.ApplyStatus(allBonus)
 	validity.ApplyStatus(allBonus)
+	lang := i18n.FromRequest(r)
+	for i := range allBonus {
+		allBonus[i] = allBonus[i].Localized(lang)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("Vary", "Accept-Language")
 	json.NewEncoder(w).Encode(allBonus)
 }
 
 // BonusDetailHandler returns a single bonus by ID.
-// GET /api/bonus/{id}
+// GET /api/bonus/{id}[?lang=en]
 func BonusDetailHandler(w http.ResponseWriter, r *http.Request) {
 	setCORSHeaders(w)
 	if r.Method == http.MethodOptions {
@@ -65,7 +72,7 @@ func BonusDetailHandler(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/bonus/")
 	bonusID := strings.TrimSuffix(path, "/")
 	if bonusID == "" {
-		http.Error(w, "Bonus ID richiesto", http.StatusBadRequest)
+		i18n.Error(w, r, i18n.M("bonus.id_richiesto"), http.StatusBadRequest)
 		return
 	}
 
@@ -76,10 +83,48 @@ func BonusDetailHandler(w http.ResponseWriter, r *http.Request) {
 		if b.ID == bonusID {
 			w.Header().Set("Content-Type", "application/json")
 			w.Header().Set("Cache-Control", "public, max-age=3600")
-			json.NewEncoder(w).Encode(b)
+			w.Header().Set("Vary", "Accept-Language")
+			json.NewEncoder(w).Encode(b.Localized(i18n.FromRequest(r)))
+			return
+		}
+	}
+
+	i18n.Error(w, r, i18n.M("bonus.non_trovato"), http.StatusNotFound)
+}
+
+// ComuniHandler searches the ISTAT list of comuni, for the residence field
+// of the wizard and for open data clients.
+// GET /api/comuni?q=<nome o CAP>[&limit=10]
+// GET /api/comuni?codice=<codice ISTAT>
+func ComuniHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	out := []istat.Comune{}
+	if codice := q.Get("codice"); codice != "" {
+		c, ok := istat.Lookup(codice)
+		if !ok {
+			i18n.Error(w, r, i18n.M("profilo.comune"), http.StatusNotFound)
 			return
 		}
+		out = append(out, c)
+	} else {
+		limit := 10
+		if n, err := strconv.Atoi(q.Get("limit")); err == nil && n > 0 && n <= 50 {
+			limit = n
+		}
+		out = append(out, istat.Search(q.Get("q"), limit)...)
 	}
 
-	http.Error(w, "Bonus non trovato", http.StatusNotFound)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	json.NewEncoder(w).Encode(out)
 }
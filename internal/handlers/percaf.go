@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bonusperme/internal/config"
+	"bonusperme/internal/i18n"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -217,15 +218,13 @@ func CAFSignupHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !verifyTurnstile(getTurnstileToken(r)) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Verifica di sicurezza non superata"})
+		formError(w, r, i18n.M("richiesta.verifica_sicurezza"))
 		return
 	}
 
 	var req cafSignupRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Dati non validi"})
+		formError(w, r, i18n.M("modulo.dati"))
 		return
 	}
 	defer r.Body.Close()
@@ -235,13 +234,11 @@ func CAFSignupHandler(w http.ResponseWriter, r *http.Request) {
 	req.Provincia = strings.TrimSpace(req.Provincia)
 
 	if req.Nome == "" || req.Email == "" || req.Provincia == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Compila tutti i campi obbligatori"})
+		formError(w, r, i18n.M("modulo.campi_obbligatori"))
 		return
 	}
 	if !strings.Contains(req.Email, "@") {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "Email non valida"})
+		formError(w, r, i18n.M("modulo.email"))
 		return
 	}
 
@@ -1,14 +1,19 @@
 package handlers
 
 import (
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/istat"
 	"bonusperme/internal/models"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
-// compactProfile holds only non-identifying fields for the profile code.
+// compactProfile holds only non-identifying fields for the profile code
+// (see profilecode.go for the wire format).
 type compactProfile struct {
 	Eta                        int     `json:"e,omitempty"`
 	NumeroFigli                int     `json:"f,omitempty"`
@@ -32,20 +37,42 @@ type compactProfile struct {
 	DisabilitaFigli            string  `json:"df,omitempty"`
 	FigliDisabili              int     `json:"fd,omitempty"`
 	MadreUnder21               bool    `json:"mu,omitempty"`
+	SpeseMediche               float64 `json:"sm,omitempty"`
+	InteressiMutuo             float64 `json:"im,omitempty"`
+	SpeseRistrutturazione      float64 `json:"sr,omitempty"`
+	DataNascitaFiglio          string  `json:"dn,omitempty"`
+	Comune                     int     `json:"co,omitempty"` // ISTAT code
+	Nucleo                     []compactComponente `json:"nu,omitempty"`
+	ComuneNome                 string  `json:"cn,omitempty"` // comune without an ISTAT code
+}
+
+// compactComponente is a household member in the profile code.
+type compactComponente struct {
+	Relazione   string  `json:"r,omitempty"`
+	DataNascita string  `json:"d,omitempty"`
+	Disabilita  string  `json:"di,omitempty"`
+	Occupazione string  `json:"o,omitempty"`
+	Reddito     float64 `json:"re,omitempty"`
 }
 
 func toCompact(p models.UserProfile) compactProfile {
+	comune, nome := comuneCode(p.Comune)
 	return compactProfile{
 		Eta: p.Eta, NumeroFigli: p.NumeroFigli, FigliMinorenni: p.FigliMinorenni,
 		FigliUnder3: p.FigliUnder3, FigliUnder1: p.FigliUnder1, FigliMaggiorenni: p.FigliMaggiorenni,
 		Over65: p.Over65, ISEE: p.ISEE,
-		RedditoAnnuo: p.RedditoAnnuo, Residenza: p.Residenza, StatoCivile: p.StatoCivile,
+		RedditoAnnuo: p.RedditoAnnuo, Residenza: residenzaCode(p.Residenza), StatoCivile: p.StatoCivile,
 		Occupazione: p.Occupazione, Disabilita: p.Disabilita, Affittuario: p.Affittuario,
 		PrimaAbitazione: p.PrimaAbitazione, RistrutturazCasa: p.RistrutturazCasa,
 		Studente: p.Studente, NuovoNato2026: p.NuovoNato2026,
 		EntrambiGenitoriLavoratori: p.EntrambiGenitoriLavoratori,
 		DisabilitaFigli: p.DisabilitaFigli, FigliDisabili: p.FigliDisabili,
 		MadreUnder21: p.MadreUnder21,
+		SpeseMediche: p.SpeseMediche, InteressiMutuo: p.InteressiMutuo,
+		SpeseRistrutturazione: p.SpeseRistrutturazione,
+		DataNascitaFiglio: p.DataNascitaFiglio,
+		Comune: comune, ComuneNome: nome,
+		Nucleo: compactNucleo(p.Nucleo),
 	}
 }
 
@@ -61,10 +88,72 @@ func fromCompact(c compactProfile) models.UserProfile {
 		EntrambiGenitoriLavoratori: c.EntrambiGenitoriLavoratori,
 		DisabilitaFigli: c.DisabilitaFigli, FigliDisabili: c.FigliDisabili,
 		MadreUnder21: c.MadreUnder21,
+		SpeseMediche: c.SpeseMediche, InteressiMutuo: c.InteressiMutuo,
+		SpeseRistrutturazione: c.SpeseRistrutturazione,
+		DataNascitaFiglio: c.DataNascitaFiglio,
+		Comune: comuneString(c.Comune, c.ComuneNome),
+		Nucleo: fromCompactNucleo(c.Nucleo),
 	}
 }
 
-const codePrefix = "BPM-"
+func compactNucleo(nucleo []models.Componente) []compactComponente {
+	if len(nucleo) == 0 {
+		return nil
+	}
+	out := make([]compactComponente, len(nucleo))
+	for i, m := range nucleo {
+		out[i] = compactComponente(m)
+	}
+	return out
+}
+
+func fromCompactNucleo(nucleo []compactComponente) []models.Componente {
+	if len(nucleo) == 0 {
+		return nil
+	}
+	out := make([]models.Componente, len(nucleo))
+	for i, m := range nucleo {
+		out[i] = models.Componente(m)
+	}
+	return out
+}
+
+// residenzaCode keeps a residenza the code tables list and replaces other
+// accepted spellings ("Alto Adige", "Friuli Venezia Giulia") with their
+// ISTAT code, so the residenza never takes more than a byte of the code.
+func residenzaCode(s string) string {
+	for _, r := range codeResidenze {
+		if r == s {
+			return s
+		}
+	}
+	if c := istat.CodiceRegione(s); c != "" {
+		return c
+	}
+	return s
+}
+
+// comuneCode returns the ISTAT code of the profile comune as a number.
+// A comune the ISTAT list does not know is returned as its name, so the
+// code keeps it as a literal string.
+func comuneCode(s string) (int, string) {
+	if s == "" {
+		return 0, ""
+	}
+	c, ok := istat.Find(s)
+	if !ok {
+		return 0, s
+	}
+	n, _ := strconv.Atoi(c.Codice)
+	return n, ""
+}
+
+func comuneString(n int, nome string) string {
+	if n == 0 {
+		return nome
+	}
+	return fmt.Sprintf("%06d", n)
+}
 
 // EncodeProfileHandler encodes a profile into a shareable code.
 func EncodeProfileHandler(w http.ResponseWriter, r *http.Request) {
@@ -75,22 +164,21 @@ func EncodeProfileHandler(w http.ResponseWriter, r *http.Request) {
 
 	var profile models.UserProfile
 	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
 		return
 	}
 	defer r.Body.Close()
 
-	compact := toCompact(profile)
-	data, err := json.Marshal(compact)
-	if err != nil {
-		http.Error(w, "Encoding error", http.StatusInternalServerError)
+	// A code that would not decode is of no use to share
+	if msg, ok := validateProfile(profile); !ok {
+		i18n.Error(w, r, msg, http.StatusBadRequest)
 		return
 	}
 
-	encoded := base64.RawURLEncoding.EncodeToString(data)
-	code := codePrefix + encoded
-	if len(code) > 64 {
-		code = code[:64]
+	code, err := encodeProfileCode(profile)
+	if err != nil {
+		i18n.Error(w, r, i18n.M("server.errore"), http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -105,40 +193,64 @@ func DecodeProfileHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	code := r.URL.Query().Get("code")
-	if code == "" || !strings.HasPrefix(code, codePrefix) {
-		http.Error(w, "Codice non valido", http.StatusBadRequest)
+	profile, msg, ok := decodeProfileCode(r.URL.Query().Get("code"))
+	if !ok {
+		i18n.Error(w, r, msg, http.StatusBadRequest)
 		return
 	}
 
-	// Max length check to prevent abuse
-	if len(code) > 256 {
-		http.Error(w, "Codice troppo lungo", http.StatusBadRequest)
-		return
-	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(profile)
+}
 
-	encoded := strings.TrimPrefix(code, codePrefix)
-	data, err := base64.RawURLEncoding.DecodeString(encoded)
-	if err != nil {
-		http.Error(w, "Codice malformato", http.StatusBadRequest)
-		return
+// decodeProfileCode decodes and validates a profile code, v2 or legacy
+// v1. On failure it returns the message for the user.
+func decodeProfileCode(code string) (models.UserProfile, i18n.Message, bool) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return models.UserProfile{}, i18n.M("codice.non_valido"), false
 	}
 
-	var compact compactProfile
-	if err := json.Unmarshal(data, &compact); err != nil {
-		http.Error(w, "Codice non decodificabile", http.StatusBadRequest)
-		return
+	// Max length check to prevent abuse: no valid profile, v1 or v2, has
+	// a longer code
+	if len(code) > max(maxCodeLen, 256) {
+		return models.UserProfile{}, i18n.M("codice.troppo_lungo"), false
 	}
 
-	profile := fromCompact(compact)
+	var profile models.UserProfile
+	switch {
+	case len(code) > len(codePrefix) && strings.EqualFold(code[:len(codePrefix)], codePrefix):
+		p, err := parseProfileCode(code[len(codePrefix):])
+		switch err {
+		case nil:
+			profile = p
+		case errCodeChecksum:
+			return models.UserProfile{}, i18n.M("codice.controllo"), false
+		case errCodeVersion:
+			return models.UserProfile{}, i18n.M("codice.versione"), false
+		default:
+			return models.UserProfile{}, i18n.M("codice.malformato"), false
+		}
+
+	case strings.HasPrefix(code, legacyCodePrefix):
+		data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(code, legacyCodePrefix))
+		if err != nil {
+			return models.UserProfile{}, i18n.M("codice.malformato"), false
+		}
+		var compact compactProfile
+		if err := json.Unmarshal(data, &compact); err != nil {
+			return models.UserProfile{}, i18n.M("codice.non_decodificabile"), false
+		}
+		profile = fromCompact(compact)
+
+	default:
+		return models.UserProfile{}, i18n.M("codice.non_valido"), false
+	}
 
 	// Validate decoded profile
 	if msg, ok := validateProfile(profile); !ok {
-		http.Error(w, msg, http.StatusBadRequest)
-		return
+		return models.UserProfile{}, msg, false
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", "no-store")
-	json.NewEncoder(w).Encode(profile)
+	return profile, i18n.Message{}, true
 }
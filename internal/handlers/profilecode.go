@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"bonusperme/internal/models"
+	"bonusperme/internal/nucleo"
+	"bonusperme/internal/questionario"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+	"strings"
+	"time"
+)
+
+// Profile codes.
+//
+// v1 ("BPM-...") is the base64url JSON of compactProfile. It is still
+// accepted, but the wizard profile often did not fit and codes got cut.
+//
+// v2 ("BPM2-XXXX-XXXX-...") is a binary encoding of the same fields:
+//
+//	version byte (2) | uvarint presence mask | field values | crc32[:2]
+//
+// Ints and money (in cents) are zigzag varints, flags are presence only,
+// enums are an index+1 in an append-only table (0 = literal string
+// follows; the comuni missing from the ISTAT list are such a literal), dates are days since 2000-01-01 and the household members are
+// a count followed by the fields of each member. The bytes are written
+// in Crockford base32, in groups of 4, so a code can be read aloud or
+// typed from the PDF and is stored in the QR alphanumeric mode.
+//
+// The field list and the enum tables are append-only: reordering them
+// breaks every code already shared.
+
+const (
+	legacyCodePrefix = "BPM-"
+	codePrefix       = "BPM2-"
+	codeVersion      = 2
+	codeGroup        = 4
+)
+
+var (
+	crockford = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+	// Typing mistakes Crockford base32 tolerates.
+	codeNormalizer = strings.NewReplacer("-", "", " ", "", "I", "1", "L", "1", "O", "0")
+
+	codeEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	errCodeChecksum = errors.New("profile code: checksum mismatch")
+	errCodeVersion  = errors.New("profile code: unknown version")
+	errCodeFormat   = errors.New("profile code: malformed payload")
+)
+
+var (
+	codeResidenze = []string{
+		"Abruzzo", "Basilicata", "Calabria", "Campania", "Emilia-Romagna",
+		"Friuli-Venezia Giulia", "Lazio", "Liguria", "Lombardia", "Marche",
+		"Molise", "Piemonte", "Puglia", "Sardegna", "Sicilia", "Toscana",
+		"Trentino-Alto Adige", "Umbria", "Valle d'Aosta", "Veneto",
+		// ISTAT codes of the regions and autonomous provinces, the
+		// canonical residenza; the names above decode codes shared before
+		"01", "02", "03", "04", "05", "06", "07", "08", "09", "10",
+		"11", "12", "13", "14", "15", "16", "17", "18", "19", "20",
+		"021", "022",
+	}
+	codeStatiCivili = []string{
+		"celibe/nubile", "coniugato/a", "convivente", "separato/a",
+		"divorziato/a", "vedovo/a", "unione civile",
+	}
+	codeOccupazioni = []string{
+		"dipendente", "autonomo", "disoccupato", "pensionato", "studente",
+		"casalinga", "inoccupato",
+	}
+	codeDisabilita = []string{"media", "grave", "non_autosufficienza"}
+	codeRelazioni  = []string{"figlio", "coniuge", "genitore", "altro"}
+)
+
+// codeField points at one compactProfile field; exactly one pointer is set.
+type codeField struct {
+	num   *int
+	money *float64
+	flag  *bool
+	enum  *string
+	table []string
+	date  *string
+	// nucleo is the list of household members: a count, then for each
+	// member relazione, birth date, disabilita, occupazione and reddito
+	nucleo *[]compactComponente
+}
+
+// codeFields lists the encoded fields in wire order (append-only).
+func codeFields(c *compactProfile) []codeField {
+	return []codeField{
+		{num: &c.Eta},
+		{num: &c.NumeroFigli},
+		{num: &c.FigliMinorenni},
+		{num: &c.FigliUnder3},
+		{num: &c.FigliUnder1},
+		{num: &c.FigliMaggiorenni},
+		{num: &c.Over65},
+		{money: &c.ISEE},
+		{money: &c.RedditoAnnuo},
+		{enum: &c.Residenza, table: codeResidenze},
+		{enum: &c.StatoCivile, table: codeStatiCivili},
+		{enum: &c.Occupazione, table: codeOccupazioni},
+		{flag: &c.Disabilita},
+		{flag: &c.Affittuario},
+		{flag: &c.PrimaAbitazione},
+		{flag: &c.RistrutturazCasa},
+		{flag: &c.Studente},
+		{flag: &c.NuovoNato2026},
+		{flag: &c.EntrambiGenitoriLavoratori},
+		{enum: &c.DisabilitaFigli, table: codeDisabilita},
+		{num: &c.FigliDisabili},
+		{flag: &c.MadreUnder21},
+		{money: &c.SpeseMediche},
+		{money: &c.InteressiMutuo},
+		{money: &c.SpeseRistrutturazione},
+		{date: &c.DataNascitaFiglio},
+		{num: &c.Comune},
+		{nucleo: &c.Nucleo},
+		{enum: &c.ComuneNome},
+	}
+}
+
+func (f codeField) present() bool {
+	switch {
+	case f.num != nil:
+		return *f.num != 0
+	case f.money != nil:
+		return math.Round(*f.money*100) != 0
+	case f.flag != nil:
+		return *f.flag
+	case f.enum != nil:
+		return *f.enum != ""
+	case f.nucleo != nil:
+		return len(*f.nucleo) > 0
+	default:
+		return *f.date != ""
+	}
+}
+
+// maxCodeLen is the length of the code of the largest profile
+// validateProfile accepts: every field at its widest value and
+// nucleo.MaxComponenti household members. decodeProfileCode rejects longer
+// codes before decoding them.
+var maxCodeLen = func() int {
+	membro := compactComponente{
+		Relazione: codeRelazioni[len(codeRelazioni)-1], DataNascita: "1900-01-01",
+		Disabilita: codeDisabilita[len(codeDisabilita)-1], Occupazione: codeOccupazioni[len(codeOccupazioni)-1],
+		Reddito: 1000000,
+	}
+	c := compactProfile{
+		Eta: 120, NumeroFigli: 20, FigliMinorenni: 20, FigliUnder3: 20, FigliUnder1: 20,
+		FigliMaggiorenni: 20, Over65: 10, ISEE: 500000, RedditoAnnuo: 1000000,
+		Residenza: codeResidenze[len(codeResidenze)-1], StatoCivile: codeStatiCivili[len(codeStatiCivili)-1],
+		Occupazione: codeOccupazioni[len(codeOccupazioni)-1], DisabilitaFigli: codeDisabilita[len(codeDisabilita)-1],
+		FigliDisabili: 20, SpeseMediche: 1000000, InteressiMutuo: 1000000, SpeseRistrutturazione: 1000000,
+		DataNascitaFiglio: "1900-01-01", Comune: 999999,
+		ComuneNome: strings.Repeat("x", questionario.MaxComune),
+	}
+	for i := 0; i < nucleo.MaxComponenti; i++ {
+		c.Nucleo = append(c.Nucleo, membro)
+	}
+	c.Disabilita, c.Affittuario, c.PrimaAbitazione, c.RistrutturazCasa = true, true, true, true
+	c.Studente, c.NuovoNato2026, c.EntrambiGenitoriLavoratori, c.MadreUnder21 = true, true, true, true
+	code, err := encodeCompact(c)
+	if err != nil {
+		panic(err)
+	}
+	return len(code)
+}()
+
+// encodeProfileCode returns the v2 code of a profile.
+func encodeProfileCode(p models.UserProfile) (string, error) {
+	return encodeCompact(toCompact(p))
+}
+
+func encodeCompact(c compactProfile) (string, error) {
+	fields := codeFields(&c)
+
+	var mask uint64
+	for i, f := range fields {
+		if f.present() {
+			mask |= 1 << uint(i)
+		}
+	}
+	buf := []byte{codeVersion}
+	buf = binary.AppendUvarint(buf, mask)
+	for i, f := range fields {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		switch {
+		case f.num != nil:
+			buf = binary.AppendVarint(buf, int64(*f.num))
+		case f.money != nil:
+			buf = binary.AppendVarint(buf, int64(math.Round(*f.money*100)))
+		case f.enum != nil:
+			buf = appendEnum(buf, *f.enum, f.table)
+		case f.date != nil:
+			var err error
+			if buf, err = appendDate(buf, *f.date); err != nil {
+				return "", err
+			}
+		case f.nucleo != nil:
+			buf = binary.AppendUvarint(buf, uint64(len(*f.nucleo)))
+			for _, m := range *f.nucleo {
+				var err error
+				buf = appendEnum(buf, m.Relazione, codeRelazioni)
+				if buf, err = appendDate(buf, m.DataNascita); err != nil {
+					return "", err
+				}
+				buf = appendEnum(buf, m.Disabilita, codeDisabilita)
+				buf = appendEnum(buf, m.Occupazione, codeOccupazioni)
+				buf = binary.AppendVarint(buf, int64(math.Round(m.Reddito*100)))
+			}
+		}
+	}
+	sum := crc32.ChecksumIEEE(buf)
+	buf = append(buf, byte(sum>>24), byte(sum>>16))
+
+	enc := crockford.EncodeToString(buf)
+	var sb strings.Builder
+	sb.WriteString(codePrefix)
+	for i := 0; i < len(enc); i += codeGroup {
+		if i > 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteString(enc[i:min(i+codeGroup, len(enc))])
+	}
+	return sb.String(), nil
+}
+
+func appendEnum(buf []byte, v string, table []string) []byte {
+	for i, s := range table {
+		if s == v {
+			return binary.AppendUvarint(buf, uint64(i+1))
+		}
+	}
+	buf = binary.AppendUvarint(buf, 0)
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func appendDate(buf []byte, v string) ([]byte, error) {
+	d, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return buf, err
+	}
+	return binary.AppendVarint(buf, int64(d.Sub(codeEpoch).Hours()/24)), nil
+}
+
+// parseProfileCode decodes the body of a v2 code (after the prefix).
+func parseProfileCode(body string) (models.UserProfile, error) {
+	data, err := crockford.DecodeString(codeNormalizer.Replace(strings.ToUpper(body)))
+	if err != nil {
+		return models.UserProfile{}, err
+	}
+	if len(data) < 3 {
+		return models.UserProfile{}, errCodeFormat
+	}
+	payload, tail := data[:len(data)-2], data[len(data)-2:]
+	if sum := crc32.ChecksumIEEE(payload); tail[0] != byte(sum>>24) || tail[1] != byte(sum>>16) {
+		return models.UserProfile{}, errCodeChecksum
+	}
+	if payload[0] != codeVersion {
+		return models.UserProfile{}, errCodeVersion
+	}
+
+	r := codeReader{data: payload[1:]}
+	mask := r.uvarint()
+	var c compactProfile
+	fields := codeFields(&c)
+	if mask>>uint(len(fields)) != 0 {
+		return models.UserProfile{}, errCodeFormat
+	}
+	for i, f := range fields {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		switch {
+		case f.num != nil:
+			*f.num = int(r.varint())
+		case f.money != nil:
+			*f.money = float64(r.varint()) / 100
+		case f.flag != nil:
+			*f.flag = true
+		case f.enum != nil:
+			*f.enum = r.enum(f.table)
+		case f.date != nil:
+			*f.date = r.date()
+		case f.nucleo != nil:
+			n := r.uvarint()
+			if n > nucleo.MaxComponenti {
+				return models.UserProfile{}, errCodeFormat
+			}
+			for ; n > 0 && r.err == nil; n-- {
+				*f.nucleo = append(*f.nucleo, compactComponente{
+					Relazione:   r.enum(codeRelazioni),
+					DataNascita: r.date(),
+					Disabilita:  r.enum(codeDisabilita),
+					Occupazione: r.enum(codeOccupazioni),
+					Reddito:     float64(r.varint()) / 100,
+				})
+			}
+		}
+	}
+	if r.err != nil || len(r.data) != 0 {
+		return models.UserProfile{}, errCodeFormat
+	}
+	return fromCompact(c), nil
+}
+
+// codeReader reads varints, remembering the first error.
+type codeReader struct {
+	data []byte
+	err  error
+}
+
+func (r *codeReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err, r.data = errCodeFormat, nil
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *codeReader) varint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err, r.data = errCodeFormat, nil
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *codeReader) date() string {
+	return codeEpoch.AddDate(0, 0, int(r.varint())).Format("2006-01-02")
+}
+
+func (r *codeReader) enum(table []string) string {
+	i := r.uvarint()
+	if i > 0 {
+		if i > uint64(len(table)) {
+			r.err = errCodeFormat
+			return ""
+		}
+		return table[i-1]
+	}
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		r.err, r.data = errCodeFormat, nil
+		return ""
+	}
+	s := string(r.data[:n])
+	r.data = r.data[n:]
+	return s
+}
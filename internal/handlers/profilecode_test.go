@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"bonusperme/internal/models"
+	"bonusperme/internal/nucleo"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeProfile(t *testing.T) {
+	// Encode
+	body := `{"eta":30,"residenza":"Lombardia","numero_figli":2,"isee":15000}`
+	req := httptest.NewRequest(http.MethodPost, "/api/encode-profile", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	EncodeProfileHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Encode: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var encResult map[string]string
+	json.Unmarshal(w.Body.Bytes(), &encResult)
+	code := encResult["code"]
+	if code == "" || !strings.HasPrefix(code, "BPM2-") {
+		t.Fatalf("Expected code with BPM2- prefix, got: %s", code)
+	}
+
+	// Decode
+	req2 := httptest.NewRequest(http.MethodGet, "/api/decode-profile?code="+code, nil)
+	w2 := httptest.NewRecorder()
+
+	DecodeProfileHandler(w2, req2)
+
+	if w2.Code != http.StatusOK {
+		t.Fatalf("Decode: expected 200, got %d: %s", w2.Code, w2.Body.String())
+	}
+
+	var profile map[string]interface{}
+	json.Unmarshal(w2.Body.Bytes(), &profile)
+	if profile["eta"].(float64) != 30 {
+		t.Errorf("Expected eta 30, got %v", profile["eta"])
+	}
+	if profile["residenza"] != "Lombardia" {
+		t.Errorf("Expected residenza Lombardia, got %v", profile["residenza"])
+	}
+}
+
+func TestProfileCode(t *testing.T) {
+	full := models.UserProfile{
+		Eta: 34, NumeroFigli: 3, FigliMinorenni: 2, FigliUnder3: 1, FigliUnder1: 1,
+		FigliMaggiorenni: 1, Over65: 1, ISEE: 12345.67, RedditoAnnuo: 28000,
+		Residenza: "06", StatoCivile: "coniugato/a", Occupazione: "dipendente",
+		Disabilita: true, Affittuario: true, PrimaAbitazione: true, RistrutturazCasa: true,
+		Studente: true, NuovoNato2026: true, EntrambiGenitoriLavoratori: true,
+		DisabilitaFigli: "grave", FigliDisabili: 1, MadreUnder21: true,
+		SpeseMediche: 1200.5, InteressiMutuo: 3000, SpeseRistrutturazione: 45000,
+		DataNascitaFiglio: "2026-03-15", Comune: "032006",
+		Nucleo: []models.Componente{
+			{Relazione: "figlio", DataNascita: "2026-03-15", Disabilita: "grave"},
+			{Relazione: "coniuge", DataNascita: "1990-01-01", Occupazione: "autonomo", Reddito: 21000.5},
+		},
+	}
+	code, err := encodeProfileCode(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, msg, ok := decodeProfileCode(code)
+	if !ok {
+		t.Fatalf("decode %s: %s", code, msg)
+	}
+	if !reflect.DeepEqual(got, full) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", got, full)
+	}
+
+	// Typed by hand: lower case, no dashes, O for 0 and I/L for 1
+	typed := strings.ToLower(strings.ReplaceAll(code[len(codePrefix):], "-", ""))
+	typed = strings.NewReplacer("0", "o", "1", "l").Replace(typed)
+	if got, _, ok := decodeProfileCode("bpm2-" + typed); !ok || !reflect.DeepEqual(got, full) {
+		t.Errorf("hand typed code not decoded")
+	}
+
+	// One wrong character fails the checksum
+	i := strings.LastIndexAny(code, "0123456789ABCDEFGHJKMNPQRSTVWXYZ") - 3
+	c := byte('Z')
+	if code[i] == 'Z' {
+		c = 'Y'
+	}
+	bad := code[:i] + string(c) + code[i+1:]
+	req := httptest.NewRequest(http.MethodGet, "/api/decode-profile?code="+bad, nil)
+	w := httptest.NewRecorder()
+	DecodeProfileHandler(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "controllo fallito") {
+		t.Errorf("tampered code: got %d %q", w.Code, w.Body.String())
+	}
+
+	// A comune missing from the ISTAT list is kept by name
+	altrove := models.UserProfile{Eta: 40, Residenza: "01", Comune: "Sauze d'Oulx"}
+	if msg, ok := validateProfile(altrove); !ok {
+		t.Fatalf("profile not valid: %s", msg.Code)
+	}
+	code, err = encodeProfileCode(altrove)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, msg, ok := decodeProfileCode(code); !ok || got.Comune != altrove.Comune {
+		t.Errorf("unknown comune: got %q (%s)", got.Comune, msg)
+	}
+
+	// Legacy v1 codes still decode
+	short := models.UserProfile{Eta: 30, Residenza: "Lombardia", NumeroFigli: 2, ISEE: 15000}
+	data, _ := json.Marshal(toCompact(short))
+	if got, msg, ok := decodeProfileCode(legacyCodePrefix + base64.RawURLEncoding.EncodeToString(data)); !ok || !reflect.DeepEqual(got, short) {
+		t.Errorf("legacy code: %s", msg)
+	}
+}
+
+func TestProfileCode_NucleoMassimo(t *testing.T) {
+	p := models.UserProfile{Eta: 45, ISEE: 499999.99, RedditoAnnuo: 999999.99, Residenza: "Alto Adige",
+		StatoCivile: "unione civile", Occupazione: "inoccupato"}
+	for i := 0; i < nucleo.MaxComponenti; i++ {
+		c := models.Componente{Relazione: "figlio", DataNascita: fmt.Sprintf("%d-12-31", 2000+i%20), Disabilita: "non_autosufficienza"}
+		if i >= 18 {
+			c = models.Componente{Relazione: "genitore", DataNascita: "1940-02-29", Occupazione: "pensionato", Reddito: 999999.99}
+		}
+		p.Nucleo = append(p.Nucleo, c)
+	}
+	if msg, ok := validateProfile(p); !ok {
+		t.Fatalf("profile not valid: %s", msg.Code)
+	}
+	code, err := encodeProfileCode(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) > maxCodeLen {
+		t.Errorf("code of %d characters, limit %d", len(code), maxCodeLen)
+	}
+	got, msg, ok := decodeProfileCode(code)
+	if !ok {
+		t.Fatalf("decode %s: %s", code, msg.Code)
+	}
+	p.Residenza = "021"
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", got, p)
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/matcher"
+	"bonusperme/internal/models"
+	"bonusperme/internal/questionario"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// QuestionnaireHandler serves the questionnaire the wizard renders: steps,
+// questions with their type, allowed values, visibility conditions and
+// bounds, and the rules across answers, with labels and error messages in
+// the language of the request. validateProfile checks profiles against the
+// same definition.
+// GET /api/questionnaire[?lang=xx]
+func QuestionnaireHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("Vary", "Accept-Language")
+	json.NewEncoder(w).Encode(questionario.Localizzato(i18n.FromRequest(r)))
+}
+
+// NextQuestionsHandler ranks the questions a partial profile has not
+// answered yet by how many bonuses and euros their answer can change, so
+// the questionnaire asks only what matters. A field counts as answered when
+// its key is in the body, even with a zero value; without "eta" the age is
+// not validated.
+// POST /api/questionnaire/next[?ordine=euro][&as_of=AAAA-MM-GG]
+func NextQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	defer r.Body.Close()
+	var risposte map[string]json.RawMessage
+	var profile models.UserProfile
+	if err != nil || json.Unmarshal(body, &risposte) != nil || json.Unmarshal(body, &profile) != nil {
+		i18n.Error(w, r, i18n.M("richiesta.non_valida"), http.StatusBadRequest)
+		return
+	}
+
+	check := profile
+	if _, ok := risposte["eta"]; !ok {
+		check.Eta = 18
+	}
+	if msg, ok := validateProfile(check); !ok {
+		i18n.Error(w, r, msg, http.StatusBadRequest)
+		return
+	}
+	asOf, _, ok := parseAsOf(r)
+	if !ok {
+		i18n.Error(w, r, i18n.M("richiesta.as_of"), http.StatusBadRequest)
+		return
+	}
+
+	risposto := make(map[string]bool, len(risposte))
+	for k := range risposte {
+		risposto[k] = true
+	}
+	domande := matcher.ProssimeDomande(profile, risposto, asOf, matchableBonus())
+	if r.URL.Query().Get("ordine") == "euro" {
+		sort.SliceStable(domande, func(i, j int) bool { return domande[i].Euro > domande[j].Euro })
+	}
+	if domande == nil {
+		domande = []models.Domanda{}
+	}
+	lang := i18n.FromRequest(r)
+	for i := range domande {
+		if e := questionario.Etichetta(domande[i].Campo, lang); e != "" {
+			domande[i].Etichetta = e
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"domande":          domande,
+		"data_valutazione": asOf.Format("2006-01-02"),
+	})
+}
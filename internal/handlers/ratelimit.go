@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bonusperme/internal/i18n"
 	"net"
 	"net/http"
 	"strings"
@@ -94,7 +95,7 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 		}
 
 		if !rl.allow(ip) {
-			http.Error(w, "Troppe richieste. Riprova tra poco.", http.StatusTooManyRequests)
+			i18n.Error(w, r, i18n.M("richiesta.troppe"), http.StatusTooManyRequests)
 			return
 		}
 		next.ServeHTTP(w, r)
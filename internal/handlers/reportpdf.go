@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"bonusperme/internal/i18n"
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Unicode fonts of the PDF report (DejaVu, see fonts/LICENSE), embedded
+// in the binary so every language can be rendered.
+//
+//go:embed fonts/DejaVuSans.ttf fonts/DejaVuSans-Bold.ttf fonts/DejaVuSansMono.ttf fonts/DejaVuSansMono-Bold.ttf
+var fontFS embed.FS
+
+var reportFonts = map[string]string{
+	"DejaVu|":      "DejaVuSans.ttf",
+	"DejaVu|B":     "DejaVuSans-Bold.ttf",
+	"DejaVuMono|":  "DejaVuSansMono.ttf",
+	"DejaVuMono|B": "DejaVuSansMono-Bold.ttf",
+}
+
+var reportFontData = func() map[string][]byte {
+	data := make(map[string][]byte, len(reportFonts))
+	for key, file := range reportFonts {
+		b, err := fontFS.ReadFile("fonts/" + file)
+		if err != nil {
+			panic(err)
+		}
+		data[key] = b
+	}
+	return data
+}()
+
+// reportPDF wraps the PDF with the language of the report. Callers lay out
+// pages left to right; for right-to-left languages every drawing call is
+// mirrored around the vertical axis of the page and text is shaped and
+// reordered, so the same layout code serves both directions.
+type reportPDF struct {
+	*gofpdf.Fpdf
+	lang string
+	rtl  bool
+	tr   map[string]string
+	trIT map[string]string
+}
+
+// newReportPDF returns an A4 report in lang, or in Italian if lang is not
+// supported.
+func newReportPDF(lang string) *reportPDF {
+	if !i18n.Supported(lang) {
+		lang = "it"
+	}
+	p := &reportPDF{
+		Fpdf: gofpdf.New("P", "mm", "A4", ""),
+		lang: lang,
+		rtl:  i18n.IsRTL(lang),
+		tr:   getTranslations(lang),
+		trIT: getTranslations("it"),
+	}
+	for key, b := range reportFontData {
+		family, style, _ := strings.Cut(key, "|")
+		p.AddUTF8FontFromBytes(family, style, b)
+	}
+	return p
+}
+
+// T returns the report string for key in the report language, falling
+// back to Italian. Extra arguments are formatted into it.
+func (p *reportPDF) T(key string, args ...interface{}) string {
+	s := p.tr[key]
+	if s == "" {
+		s = p.trIT[key]
+	}
+	if s == "" {
+		s = key
+	}
+	if len(args) > 0 {
+		s = fmt.Sprintf(s, args...)
+	}
+	return s
+}
+
+// opt translates a profile enum value through the wizard option labels.
+func (p *reportPDF) opt(value string) string {
+	if key, ok := reportOptKeys[value]; ok && p.lang != "it" {
+		if s := p.tr[key]; s != "" {
+			return s
+		}
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+var reportOptKeys = map[string]string{
+	"celibe/nubile": "opt.single", "coniugato/a": "opt.married", "convivente": "opt.cohabiting",
+	"separato/a": "opt.separated", "vedovo/a": "opt.widowed",
+	"dipendente": "opt.employee", "autonomo": "opt.selfemployed", "disoccupato": "opt.unemployed",
+	"pensionato": "opt.retired", "studente": "opt.student", "casalinga": "opt.inactive",
+}
+
+// text prepares s for drawing: shaped and in visual order for
+// right-to-left languages.
+func (p *reportPDF) text(s string) string {
+	if p.rtl {
+		return i18n.Visual(s)
+	}
+	return s
+}
+
+// SetFont maps the core font families used by the layout to the Unicode
+// fonts. DejaVu has no oblique face here, so italics become regular.
+func (p *reportPDF) SetFont(family, style string, size float64) {
+	switch family {
+	case "Helvetica":
+		family = "DejaVu"
+	case "Courier":
+		family = "DejaVuMono"
+	}
+	style = strings.ReplaceAll(strings.ReplaceAll(style, "I", ""), "U", "")
+	p.Fpdf.SetFont(family, style, size)
+}
+
+func (p *reportPDF) GetStringWidth(s string) float64 {
+	return p.Fpdf.GetStringWidth(p.text(s))
+}
+
+// mirror returns the page x of a box of width w at layout x.
+func (p *reportPDF) mirror(x, w float64) float64 {
+	if p.rtl {
+		return pageW - x - w
+	}
+	return x
+}
+
+func mirrorAlign(align string) string {
+	switch {
+	case strings.Contains(align, "L"):
+		return strings.Replace(align, "L", "R", 1)
+	case strings.Contains(align, "R"):
+		return strings.Replace(align, "R", "L", 1)
+	case strings.Contains(align, "C"):
+		return align
+	}
+	return align + "R"
+}
+
+func (p *reportPDF) CellFormat(w, h float64, txt, border string, ln int, align string, fill bool, link int, linkStr string) {
+	if !p.rtl {
+		p.Fpdf.CellFormat(w, h, p.text(txt), border, ln, align, fill, link, linkStr)
+		return
+	}
+	x := p.GetX()
+	if w == 0 {
+		_, _, right, _ := p.GetMargins()
+		w = pageW - right - x
+	}
+	p.SetX(p.mirror(x, w))
+	p.Fpdf.CellFormat(w, h, p.text(txt), border, ln, mirrorAlign(align), fill, link, linkStr)
+	switch ln {
+	case 0:
+		p.SetX(x + w)
+	case 2:
+		p.SetX(x)
+	}
+}
+
+// MultiCell wraps in logical order and draws each line right-aligned for
+// right-to-left languages.
+func (p *reportPDF) MultiCell(w, h float64, txt, border, align string, fill bool) {
+	if !p.rtl {
+		p.Fpdf.MultiCell(w, h, p.text(txt), border, align, fill)
+		return
+	}
+	x := p.GetX()
+	if w == 0 {
+		_, _, right, _ := p.GetMargins()
+		w = pageW - right - x
+	}
+	for _, line := range p.SplitText(i18n.Shape(txt), w) {
+		p.SetX(x)
+		p.CellFormat(w, h, line, border, 2, align, fill, 0, "")
+	}
+	left, _, _, _ := p.GetMargins()
+	p.SetX(left)
+}
+
+// verbatimCell draws Italian text as is, also in a right-to-left report:
+// only its position and alignment are mirrored.
+func (p *reportPDF) verbatimCell(w, h float64, txt, align string) {
+	if p.rtl {
+		align = mirrorAlign(align)
+	}
+	x := p.GetX()
+	p.SetX(p.mirror(x, w))
+	p.Fpdf.CellFormat(w, h, txt, "", 2, align, false, 0, "")
+	p.SetX(x)
+}
+
+func (p *reportPDF) WriteLinkString(h float64, txt, link string) {
+	if !p.rtl {
+		p.Fpdf.WriteLinkString(h, p.text(txt), link)
+		return
+	}
+	p.CellFormat(p.GetStringWidth(txt), h, txt, "", 0, "L", false, 0, link)
+}
+
+func (p *reportPDF) Rect(x, y, w, h float64, style string) {
+	p.Fpdf.Rect(p.mirror(x, w), y, w, h, style)
+}
+
+var cornersMirror = strings.NewReplacer("1", "2", "2", "1", "3", "4", "4", "3")
+
+func (p *reportPDF) RoundedRect(x, y, w, h, r float64, corners, style string) {
+	if p.rtl {
+		corners = cornersMirror.Replace(corners)
+	}
+	p.Fpdf.RoundedRect(p.mirror(x, w), y, w, h, r, corners, style)
+}
+
+func (p *reportPDF) Circle(x, y, r float64, style string) {
+	p.Fpdf.Circle(p.mirror(x, 0), y, r, style)
+}
+
+func (p *reportPDF) Line(x1, y1, x2, y2 float64) {
+	p.Fpdf.Line(p.mirror(x1, 0), y1, p.mirror(x2, 0), y2)
+}
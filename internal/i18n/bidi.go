@@ -0,0 +1,192 @@
+package i18n
+
+import "unicode"
+
+// Right-to-left support for renderers without a shaping engine (the PDF
+// report): Arabic letters are replaced by their contextual presentation
+// forms and each line is reordered from logical to visual order, so it can
+// be drawn left to right with a font that has the presentation forms.
+
+// IsRTL reports whether lang is written right to left.
+func IsRTL(lang string) bool {
+	return lang == "ar"
+}
+
+// arabicForms holds isolated, final, initial and medial forms. Letters that
+// only join to the preceding one have no initial/medial form.
+var arabicForms = map[rune][4]rune{
+	0x0621: {0xFE80, 0, 0, 0},
+	0x0622: {0xFE81, 0xFE82, 0, 0},
+	0x0623: {0xFE83, 0xFE84, 0, 0},
+	0x0624: {0xFE85, 0xFE86, 0, 0},
+	0x0625: {0xFE87, 0xFE88, 0, 0},
+	0x0626: {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	0x0627: {0xFE8D, 0xFE8E, 0, 0},
+	0x0628: {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	0x0629: {0xFE93, 0xFE94, 0, 0},
+	0x062A: {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	0x062B: {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	0x062C: {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	0x062D: {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	0x062E: {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	0x062F: {0xFEA9, 0xFEAA, 0, 0},
+	0x0630: {0xFEAB, 0xFEAC, 0, 0},
+	0x0631: {0xFEAD, 0xFEAE, 0, 0},
+	0x0632: {0xFEAF, 0xFEB0, 0, 0},
+	0x0633: {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	0x0634: {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	0x0635: {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	0x0636: {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	0x0637: {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	0x0638: {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	0x0639: {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	0x063A: {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	0x0640: {0x0640, 0x0640, 0x0640, 0x0640}, // tatweel
+	0x0641: {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	0x0642: {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	0x0643: {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	0x0644: {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	0x0645: {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	0x0646: {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	0x0647: {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	0x0648: {0xFEED, 0xFEEE, 0, 0},
+	0x0649: {0xFEEF, 0xFEF0, 0, 0},
+	0x064A: {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4},
+}
+
+// lamAlef maps the alef following a lam to the isolated and final forms of
+// the mandatory ligature.
+var lamAlef = map[rune][2]rune{
+	0x0622: {0xFEF5, 0xFEF6},
+	0x0623: {0xFEF7, 0xFEF8},
+	0x0625: {0xFEF9, 0xFEFA},
+	0x0627: {0xFEFB, 0xFEFC},
+}
+
+// transparent marks (harakat) do not break joining.
+func transparent(r rune) bool {
+	return (r >= 0x064B && r <= 0x065F) || r == 0x0670
+}
+
+func joinsBefore(r rune) bool { _, ok := arabicForms[r]; return ok && r != 0x0621 }
+func joinsAfter(r rune) bool  { f, ok := arabicForms[r]; return ok && f[2] != 0 }
+
+// Shape replaces Arabic letters with their contextual presentation forms.
+// Text without Arabic is returned unchanged.
+func Shape(s string) string {
+	in := []rune(s)
+	out := make([]rune, 0, len(in))
+	neighbour := func(i, step int) rune {
+		for j := i + step; j >= 0 && j < len(in); j += step {
+			if !transparent(in[j]) {
+				return in[j]
+			}
+		}
+		return 0
+	}
+	for i := 0; i < len(in); i++ {
+		r := in[i]
+		forms, ok := arabicForms[r]
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+		prev := joinsAfter(neighbour(i, -1)) && joinsBefore(r)
+		if r == 0x0644 && i+1 < len(in) {
+			if lig, ok := lamAlef[in[i+1]]; ok {
+				if prev {
+					out = append(out, lig[1])
+				} else {
+					out = append(out, lig[0])
+				}
+				i++
+				continue
+			}
+		}
+		next := joinsAfter(r) && joinsBefore(neighbour(i, 1))
+		switch {
+		case prev && next:
+			out = append(out, forms[3])
+		case prev:
+			out = append(out, forms[1])
+		case next:
+			out = append(out, forms[2])
+		default:
+			out = append(out, forms[0])
+		}
+	}
+	return string(out)
+}
+
+func isRTLRune(r rune) bool {
+	return (r >= 0x0590 && r <= 0x08FF) || (r >= 0xFB1D && r <= 0xFDFF) || (r >= 0xFE70 && r <= 0xFEFF)
+}
+
+// class returns 'R' for right-to-left letters, 'L' for left-to-right
+// letters and digits, 'N' for neutrals (spaces, punctuation).
+func class(r rune) byte {
+	switch {
+	case isRTLRune(r):
+		if transparent(r) {
+			return 'N'
+		}
+		return 'R'
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return 'L'
+	}
+	return 'N'
+}
+
+var mirrored = map[rune]rune{'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<', '«': '»', '»': '«'}
+
+// Visual shapes a single line of a right-to-left paragraph and returns it
+// in visual order, ready to be drawn left to right: left-to-right runs
+// (Latin words, numbers) keep their order, everything else is reversed and
+// brackets are mirrored. Neutrals between two left-to-right runs join them;
+// the others follow the paragraph direction.
+func Visual(s string) string {
+	rs := []rune(Shape(s))
+	cls := make([]byte, len(rs))
+	for i, r := range rs {
+		cls[i] = class(r)
+	}
+	for i := 0; i < len(rs); {
+		if cls[i] != 'N' {
+			i++
+			continue
+		}
+		j := i
+		for j < len(rs) && cls[j] == 'N' {
+			j++
+		}
+		c := byte('R')
+		if i > 0 && j < len(rs) && cls[i-1] == 'L' && cls[j] == 'L' {
+			c = 'L'
+		}
+		for k := i; k < j; k++ {
+			cls[k] = c
+		}
+		i = j
+	}
+
+	out := make([]rune, 0, len(rs))
+	for end := len(rs); end > 0; {
+		start := end - 1
+		for start > 0 && cls[start-1] == cls[end-1] {
+			start--
+		}
+		if cls[end-1] == 'L' {
+			out = append(out, rs[start:end]...)
+		} else {
+			for k := end - 1; k >= start; k-- {
+				r := rs[k]
+				if m, ok := mirrored[r]; ok {
+					r = m
+				}
+				out = append(out, r)
+			}
+		}
+		end = start
+	}
+	return string(out)
+}
@@ -0,0 +1,25 @@
+package i18n
+
+import "testing"
+
+func TestVisual(t *testing.T) {
+	// Arabic is shaped into presentation forms and reordered for drawing
+	// left to right; numbers keep their order.
+	if got, want := Visual("بونص 500"), "500 \uFEBA\uFEE7\uFEEE\uFE91"; got != want {
+		t.Errorf("Visual: got %q, want %q", got, want)
+	}
+	if got := Shape("لا"); got != "\uFEFB" {
+		t.Errorf("lam-alef: got %q", got)
+	}
+	if got := Visual("Legge 207/2024"); got != "Legge 207/2024" {
+		t.Errorf("Latin text reordered: %q", got)
+	}
+}
+
+func TestIsRTL(t *testing.T) {
+	for lang, want := range map[string]bool{"ar": true, "it": false, "ro": false, "": false} {
+		if got := IsRTL(lang); got != want {
+			t.Errorf("IsRTL(%q) = %v, want %v", lang, got, want)
+		}
+	}
+}
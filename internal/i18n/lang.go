@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the language of the catalog and the fallback of every
+// translation.
+const Default = "it"
+
+// Languages lists the supported languages, Italian first.
+var Languages = []string{"it", "en", "fr", "es", "ro", "ar", "sq"}
+
+// Names are the languages in their own script, as shown in the switcher.
+var Names = map[string]string{
+	"it": "Italiano", "en": "English", "fr": "Français", "es": "Español",
+	"ro": "Română", "ar": "العربية", "sq": "Shqip",
+}
+
+// Supported reports whether lang is one of Languages.
+func Supported(lang string) bool {
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate picks the supported language preferred by an Accept-Language
+// header ("fr-CH, fr;q=0.9, en;q=0.8"). Region subtags are ignored. It
+// returns "" when no listed language is supported.
+func Negotiate(header string) string {
+	type pref struct {
+		lang string
+		q    float64
+	}
+	var prefs []pref
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > 0 && Supported(base) {
+			prefs = append(prefs, pref{base, q})
+		}
+	}
+	// Stable: equal weights keep the order of the header.
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+	if len(prefs) == 0 {
+		return ""
+	}
+	return prefs[0].lang
+}
+
+// FromRequest returns the language of a request: the lang query parameter,
+// else the preferred supported Accept-Language, else Italian.
+func FromRequest(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); Supported(lang) {
+		return lang
+	}
+	if lang := Negotiate(r.Header.Get("Accept-Language")); lang != "" {
+		return lang
+	}
+	return Default
+}
+
+// PluralCategories lists the plural categories PluralCategory can return
+// for lang, "other" last. A plural message needs one form for each.
+func PluralCategories(lang string) []string {
+	switch lang {
+	case "ro":
+		return []string{"one", "few", "other"}
+	case "ar":
+		return []string{"zero", "one", "two", "few", "many", "other"}
+	}
+	return []string{"one", "other"}
+}
+
+// PluralCategory returns the CLDR plural category of the count n in lang:
+// "zero", "one", "two", "few", "many" or "other".
+func PluralCategory(lang string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case "fr":
+		if n <= 1 {
+			return "one"
+		}
+	case "ro":
+		switch {
+		case n == 1:
+			return "one"
+		case n == 0 || (n%100 >= 1 && n%100 <= 19):
+			return "few" // 0, 2-19, 101-119, ...
+		}
+	case "ar":
+		switch {
+		case n == 0:
+			return "zero"
+		case n == 1:
+			return "one"
+		case n == 2:
+			return "two"
+		case n%100 >= 3 && n%100 <= 10:
+			return "few"
+		case n%100 >= 11:
+			return "many"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,79 @@
+package i18n
+
+import "testing"
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{"it", 0, "other"},
+		{"it", 1, "one"},
+		{"it", 2, "other"},
+		{"en", 1, "one"},
+		{"en", 21, "other"},
+		{"fr", 0, "one"},
+		{"fr", 1, "one"},
+		{"fr", 2, "other"},
+		{"ro", 0, "few"},
+		{"ro", 1, "one"},
+		{"ro", 2, "few"},
+		{"ro", 19, "few"},
+		{"ro", 20, "other"},
+		{"ro", 100, "other"},
+		{"ro", 101, "few"},
+		{"ro", 119, "few"},
+		{"ro", 120, "other"},
+		{"ro", -5, "few"},
+		{"ar", 0, "zero"},
+		{"ar", 1, "one"},
+		{"ar", 2, "two"},
+		{"ar", 3, "few"},
+		{"ar", 10, "few"},
+		{"ar", 11, "many"},
+		{"ar", 99, "many"},
+		{"ar", 100, "other"},
+		{"ar", 102, "other"},
+		{"ar", 103, "few"},
+		{"ar", 111, "many"},
+		{"sq", 1, "one"},
+		{"sq", 3, "other"},
+	}
+	for _, tt := range tests {
+		if got := PluralCategory(tt.lang, tt.n); got != tt.want {
+			t.Errorf("PluralCategory(%q, %d) = %q, want %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPluralCategories(t *testing.T) {
+	// Every category PluralCategory returns is listed
+	for _, lang := range Languages {
+		listed := map[string]bool{}
+		for _, c := range PluralCategories(lang) {
+			listed[c] = true
+		}
+		for n := 0; n < 300; n++ {
+			if c := PluralCategory(lang, n); !listed[c] {
+				t.Errorf("%s: category %q of %d not in PluralCategories", lang, c, n)
+			}
+		}
+	}
+}
+
+func TestNegotiateLang(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                       "",
+		"de":                     "",
+		"en-US,en;q=0.9":         "en",
+		"es;q=0.2, ar;q=0.7":     "ar",
+		"fr;q=0, sq":             "sq",
+		"RO-ro, it;q=0.9":        "ro",
+		"*;q=0.5, it-IT;q=bogus": "",
+	} {
+		if got := Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"bonusperme/internal/logger"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the format of the UI translation files.
+const SchemaVersion = 1
+
+// File is one language of the UI catalog, data/i18n/<lang>.yaml. Origine
+// maps each translated key to the Fingerprint of the Italian text it was
+// translated from, so a translation whose source has changed since can be
+// flagged as stale. The Italian file has no Origine.
+type File struct {
+	Schema  int               `yaml:"schema"`
+	Lingua  string            `yaml:"lingua"`
+	Testi   map[string]string `yaml:"testi"`
+	Origine map[string]string `yaml:"origine,omitempty"`
+}
+
+// Path returns the file of lang under dir.
+func Path(dir, lang string) string {
+	return filepath.Join(dir, lang+".yaml")
+}
+
+// ReadFile reads and validates the file of lang under dir.
+func ReadFile(dir, lang string) (File, error) {
+	path := Path(dir, lang)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, err
+	}
+	var f File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return File{}, fmt.Errorf("%s: %w", path, err)
+	}
+	switch {
+	case f.Schema != SchemaVersion:
+		return File{}, fmt.Errorf("%s: unsupported schema %d (expected %d)", path, f.Schema, SchemaVersion)
+	case f.Lingua != lang:
+		return File{}, fmt.Errorf("%s: lingua %q does not match the file name", path, f.Lingua)
+	case len(f.Testi) == 0:
+		return File{}, fmt.Errorf("%s: testi is empty", path)
+	case lang == Default && len(f.Origine) > 0:
+		return File{}, fmt.Errorf("%s: the Italian file is the source and has no origine", path)
+	}
+	return f, nil
+}
+
+// WriteFile writes f to its file under dir. Keys are sorted, so an import
+// that changes one text changes one line.
+func WriteFile(dir string, f File) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Testi dell'interfaccia — %s\n", Names[f.Lingua])
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(f); err != nil {
+		return err
+	}
+	enc.Close()
+	return os.WriteFile(Path(dir, f.Lingua), buf.Bytes(), 0o644)
+}
+
+// Load reads the UI catalog from dir, one file per supported language, and
+// replaces T. It is called once at startup, before the server accepts
+// requests.
+func Load(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		lang, ok := strings.CutSuffix(e.Name(), ".yaml")
+		if !e.IsDir() && ok && !Supported(lang) {
+			return fmt.Errorf("%s: unsupported language %q", dir, lang)
+		}
+	}
+
+	t := make(map[string]map[string]string, len(Languages))
+	keys := 0
+	for _, lang := range Languages {
+		f, err := ReadFile(dir, lang)
+		if err != nil {
+			return err
+		}
+		t[lang] = f.Testi
+		keys += len(f.Testi)
+	}
+	T = t
+
+	logger.Info("i18n: loaded", map[string]interface{}{"dir": dir, "languages": len(t), "keys": keys})
+	return nil
+}
+
+// Fingerprint identifies a source text in Origine: the first 8 hex digits
+// of its SHA-256.
+func Fingerprint(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:4])
+}
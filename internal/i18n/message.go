@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Message is a text generated by the server (an error, a warning): a
+// stable code clients can switch on and the parameters interpolated into
+// its translation. Translations are stored under "msg.<code>"; a message
+// with an integer parameter "n" is looked up as "msg.<code>#<category>"
+// first, with the plural category of n in the target language.
+type Message struct {
+	Code   string                 `json:"codice"`
+	Params map[string]interface{} `json:"parametri,omitempty"`
+}
+
+// M builds a message from a code and name/value parameter pairs.
+func M(code string, kv ...interface{}) Message {
+	m := Message{Code: code}
+	if len(kv) > 0 {
+		m.Params = make(map[string]interface{}, len(kv)/2)
+		for i := 0; i+1 < len(kv); i += 2 {
+			m.Params[fmt.Sprint(kv[i])] = kv[i+1]
+		}
+	}
+	return m
+}
+
+// Text renders the message in lang, falling back to Italian and, for an
+// unknown code, to the code itself. {name} placeholders are replaced by
+// the parameters.
+func (m Message) Text(lang string) string {
+	s := m.lookup(lang)
+	if s == "" && lang != Default {
+		s = m.lookup(Default)
+	}
+	if s == "" {
+		return m.Code
+	}
+	if len(m.Params) == 0 {
+		return s
+	}
+	pairs := make([]string, 0, 2*len(m.Params))
+	for k, v := range m.Params {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+func (m Message) lookup(lang string) string {
+	t := T[lang]
+	key := "msg." + m.Code
+	if n, ok := m.count(); ok {
+		if s := t[key+"#"+PluralCategory(lang, n)]; s != "" {
+			return s
+		}
+		if s := t[key+"#other"]; s != "" {
+			return s
+		}
+	}
+	return t[key]
+}
+
+// count returns the "n" parameter; it is a float64 once the message has
+// been through JSON.
+func (m Message) count() (int, bool) {
+	switch n := m.Params["n"].(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// Error replies to the request with status and the message as JSON, in the
+// language of the request:
+//
+//	{"error": "Eta non valida (18-120)", "codice": "profilo.eta", "parametri": {"min": 18, "max": 120}}
+func Error(w http.ResponseWriter, r *http.Request, m Message, status int) {
+	body := struct {
+		Error string `json:"error"`
+		Message
+	}{m.Text(FromRequest(r)), m}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Vary", "Accept-Language")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,37 @@
+package i18n
+
+import "testing"
+
+func init() {
+	if err := Load("../../data/i18n"); err != nil {
+		panic(err)
+	}
+}
+
+func TestMessagePlurals(t *testing.T) {
+	for _, tc := range []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{"it", 1, "Scade tra 1 giorno — Fai domanda subito"},
+		{"it", 5, "Scade tra 5 giorni — Fai domanda subito"},
+		{"fr", 0, "Expire dans 0 jour — Faites la demande maintenant"},
+		{"ro", 1, "Expiră în 1 zi — Depune cererea acum"},
+		{"ro", 19, "Expiră în 19 zile — Depune cererea acum"},
+		{"ro", 20, "Expiră în 20 de zile — Depune cererea acum"},
+		{"ar", 2, "تنتهي خلال يومين — قدّم الطلب الآن"},
+		{"ar", 7, "تنتهي خلال 7 أيام — قدّم الطلب الآن"},
+		{"ar", 25, "تنتهي خلال 25 يومًا — قدّم الطلب الآن"},
+	} {
+		if got := M("avviso.scade_tra", "n", tc.n).Text(tc.lang); got != tc.want {
+			t.Errorf("%s n=%d: got %q, want %q", tc.lang, tc.n, got, tc.want)
+		}
+	}
+	if got := M("modulo.email").Text("de"); got != "Email non valida" {
+		t.Errorf("unsupported language should fall back to Italian, got %q", got)
+	}
+	if got := M("codice.inesistente").Text("en"); got != "codice.inesistente" {
+		t.Errorf("unknown code should render as itself, got %q", got)
+	}
+}
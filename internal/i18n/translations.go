@@ -1,1086 +1,8 @@
 package i18n
 
-// T holds all translations: language code -> key -> value
-var T = map[string]map[string]string{
-	"it": {
-		"hero.pretitle":            "Verifica gratuita",
-		"hero.title":               "Scopri in 2 minuti a quali bonus hai diritto",
-		"hero.subtitle":            "Ogni anno migliaia di euro di bonus restano non richiesti. Rispondi a poche domande e ti diciamo esattamente quali puoi ottenere — gratis, senza registrazione.",
-		"hero.cta":                 "Inizia la verifica",
-		"hero.counter_label":       "famiglie aiutate",
-		"hero.impact":              "€2,1 miliardi di bonus non richiesti ogni anno in Italia",
-		"how.title":                "Come funziona",
-		"how.step1.title":          "Inserisci i dati",
-		"how.step1.desc":           "Età, famiglia, ISEE e situazione abitativa. Solo l'essenziale, niente di più.",
-		"how.step2.title":          "Analisi istantanea",
-		"how.step2.desc":           "Confrontiamo la tua situazione con tutti i bonus attivi, nazionali e regionali.",
-		"how.step3.title":          "Risultati chiari",
-		"how.step3.desc":           "Lista personalizzata con importi, requisiti e istruzioni passo per passo.",
-		"novita.title":             "Novità bonus 2026",
-		"privacy.title":            "I tuoi dati restano tuoi",
-		"privacy.text":             "Nessun database, nessun cookie, nessun tracking. I tuoi dati restano solo nella tua sessione. Al refresh della pagina, tutto viene cancellato. Non chiediamo nome, email, né telefono. Mai.",
-		"privacy.refresh_note":     "I tuoi dati verranno cancellati al refresh",
-		"step1.title":              "Dati personali",
-		"step1.subtitle":           "Informazioni di base per la verifica.",
-		"step2.title":              "Nucleo familiare",
-		"step2.subtitle":           "Composizione della tua famiglia.",
-		"step3.title":              "Situazione economica",
-		"step3.subtitle":           "ISEE e reddito. Se non hai l'ISEE lascia 0.",
-		"step4.title":              "Situazione abitativa",
-		"step4.subtitle":           "Residenza e condizione casa.",
-		"label.eta":                "Età",
-		"label.regione":            "Regione di residenza",
-		"label.stato_civile":       "Stato civile",
-		"label.occupazione":        "Occupazione",
-		"label.numero_figli":       "Numero figli",
-		"label.figli_minorenni":    "Figli minorenni",
-		"label.figli_under3":       "Figli under 3 anni",
-		"label.over65":             "Over 65 nel nucleo",
-		"label.isee":               "ISEE annuo (EUR)",
-		"label.reddito":            "Reddito annuo (EUR)",
-		"label.disabilita":         "Componente con disabilità nel nucleo",
-		"label.nuovo_nato":         "Nuovo nato nel 2026",
-		"label.affittuario":        "Sono in affitto",
-		"label.prima_casa":         "Prima abitazione di proprietà",
-		"label.ristrutturazione":   "Ristrutturazione casa in corso / prevista",
-		"label.studente":           "Studente universitario",
-		"opt.select":               "— Seleziona —",
-		"opt.single":               "Celibe / Nubile",
-		"opt.married":              "Coniugato/a",
-		"opt.cohabiting":           "Convivente",
-		"opt.separated":            "Separato/a",
-		"opt.widowed":              "Vedovo/a",
-		"opt.employee":             "Dipendente",
-		"opt.selfemployed":         "Autonomo",
-		"opt.unemployed":           "Disoccupato",
-		"opt.retired":              "Pensionato",
-		"opt.student":              "Studente",
-		"opt.inactive":             "Casalinga",
-		"btn.next":                 "Avanti",
-		"btn.prev":                 "Indietro",
-		"btn.submit":               "Verifica bonus",
-		"btn.reset":                "Cancella dati e ricomincia",
-		"btn.modify":               "Modifica dati",
-		"isee.upload":              "Hai il PDF dell'ISEE? Trascinalo qui o clicca per caricarlo",
-		"isee.upload_desc":         "Elaborato localmente, poi cancellato",
-		"isee.or":                  "oppure inserisci manualmente",
-		"loading.analyzing":        "Stiamo analizzando la tua situazione...",
-		"results.title":            "I tuoi bonus",
-		"results.subtitle":         "di risparmio stimato",
-		"results.no_results":       "Nessun bonus trovato",
-		"results.no_results_desc":  "Con i dati che hai inserito non risultano bonus compatibili.",
-		"results.importo":          "Importo",
-		"results.importo_reale":    "Importo stimato per te",
-		"results.requisiti":        "Requisiti",
-		"results.come_fare":        "Come fare domanda",
-		"results.documenti":        "Documenti necessari",
-		"results.faq":              "Domande frequenti",
-		"results.fonti":            "Fonti e riferimenti",
-		"results.fonte_ist":        "Fonte:",
-		"results.fonte_edit":       "Rif. normativi:",
-		"results.last_update":      "Aggiornato:",
-		"results.link_ufficiale":   "Sito ufficiale",
-		"results.details":          "Vedi come richiederlo",
-		"results.calendar":         "Scadenze",
-		"results.pdf":              "PDF",
-		"results.print":            "Stampa",
-		"results.expand":           "Espandi tutto",
-		"results.share":            "Condividi",
-		"results.share_bonus":      "Condividi",
-		"sim.title":                "Simulatore ISEE",
-		"sim.label":                "Inserisci un valore ISEE ipotetico per scoprire se avresti diritto a bonus aggiuntivi.",
-		"sim.button":               "Simula",
-		"widget.note":              "Stima veloce: quanto potresti ricevere?",
-		"widget.figli_label":       "Numero figli",
-		"widget.isee_label":        "Fascia ISEE",
-		"widget.isee_under15":      "Sotto €15.000",
-		"widget.isee_15_25":        "€15.000 - €25.000",
-		"widget.isee_25_40":        "€25.000 - €40.000",
-		"widget.isee_over40":       "Oltre €40.000",
-		"widget.result":            "Stima indicativa basata su dati medi nazionali",
-		"testimonials.title":       "Cosa dicono le famiglie",
-		"coming.title":             "Prossimamente su BonusPerMe",
-		"coming.telegram":          "Notifiche Telegram",
-		"coming.whatsapp":          "Aggiornamenti WhatsApp",
-		"coming.desc":              "Lascia la tua email per essere avvisato al lancio",
-		"coming.email_placeholder": "La tua email...",
-		"coming.email_button":      "Avvisami",
-		"coming.thanks":            "Grazie! Ti avviseremo al lancio.",
-		"caf.find":                 "Trova CAF vicino a te",
-		"footer.gdpr":              "GDPR Compliant",
-		"footer.no_cookie":         "Zero Cookie",
-		"footer.no_tracking":       "Zero Tracking",
-		"footer.eu":                "Server EU",
-		"footer.green":             "Green Hosting",
-		"footer.open":              "Open Source",
-		"footer.disclaimer":        "Questo servizio è a scopo orientativo e non sostituisce la consulenza di un professionista, CAF o patronato.",
-		"nav.home":            "Home",
-		"nav.guide":           "Guide",
-		"nav.caf":             "Per i CAF",
-		"nav.contatti":        "Contatti",
-		"results.collapse":    "Nascondi dettagli",
-		"a11y.skip":                "Vai al contenuto principale",
-		"topbar.free":              "Servizio gratuito",
-		"topbar.nodata":            "Nessun dato salvato",
-		"topbar.updated":           "Dati aggiornati al ...",
-		"how.subtitle":             "Dalla verifica al CAF in 4 passaggi.",
-		"how.step4.title":          "Porta al CAF",
-		"how.step4.desc":           "Stampa il report con documenti e fonti ufficiali. Il patronato fa il resto.",
-		"privacy.db_title":         "Nessun database",
-		"privacy.db_desc":          "I dati esistono solo nella tua sessione browser. Al refresh della pagina, spariscono. Non salviamo nulla.",
-		"privacy.track_title":      "Privacy first",
-		"privacy.track_desc":       "Usiamo solo Google Analytics anonimo, e solo con il tuo consenso. Nessun cookie di profilazione.",
-		"privacy.code_title":       "Codice aperto",
-		"privacy.code_desc":        "Il sorgente è su GitHub sotto licenza AGPL-3.0. Chiunque può verificare cosa fa il codice.",
-		"cta.title":                "Scopri quanto potresti risparmiare",
-		"cta.subtitle":             "In 2 minuti sai esattamente a quali bonus hai diritto e come fare domanda.",
-		"isee_warn.has_isee":       "Ricorda: l'attestazione ISEE ha validità fino al 31 dicembre dell'anno in corso.",
-		"isee_warn.no_isee":        "Non hai inserito l'ISEE. Con un ISEE valido potresti sbloccare fino a 12 bonus aggiuntivi.",
-		"share.title":              "I miei bonus — BonusPerMe",
-		"share.whatsapp":           "Condividi su WhatsApp",
-		"share.copy":               "Copia riepilogo",
-		"share.copied":             "Copiato!",
-		"share.native":             "Altre opzioni...",
-		"turnstile.note":           "Verifica di sicurezza",
-		"contact.title":            "Contattaci",
-		"contact.subtitle":         "Hai domande o suggerimenti? Scrivici.",
-		"contact.nome":             "Nome",
-		"contact.email":            "Email",
-		"contact.oggetto":          "Oggetto",
-		"contact.messaggio":        "Messaggio",
-		"contact.privacy":          "Ho letto e accetto la Privacy Policy",
-		"contact.submit":           "Invia messaggio",
-		"contact.thanks":           "Grazie! Il tuo messaggio è stato inviato.",
-		"contact.opt_info":         "Informazioni generali",
-		"contact.opt_bug":          "Segnalazione errore",
-		"contact.opt_partner":      "Partnership / CAF",
-		"contact.opt_other":        "Altro",
-	},
-	"en": {
-		"hero.pretitle":            "Free check",
-		"hero.title":               "Find out in 2 minutes which bonuses you're entitled to",
-		"hero.subtitle":            "Every year, thousands of euros in bonuses go unclaimed. Answer a few questions and we'll tell you exactly which ones you can get — free, no sign-up required.",
-		"hero.cta":                 "Start the check",
-		"hero.counter_label":       "families helped",
-		"hero.impact":              "€2.1 billion in unclaimed bonuses every year in Italy",
-		"how.title":                "How it works",
-		"how.step1.title":          "Enter your data",
-		"how.step1.desc":           "Age, family, ISEE and housing. Only the essentials, nothing more.",
-		"how.step2.title":          "Instant analysis",
-		"how.step2.desc":           "We compare your situation with all active bonuses, national and regional.",
-		"how.step3.title":          "Clear results",
-		"how.step3.desc":           "Personalised list with amounts, requirements and step-by-step instructions.",
-		"novita.title":             "Bonus news 2026",
-		"privacy.title":            "Your data stays yours",
-		"privacy.text":             "No database, no cookies, no tracking. Your data stays only in your session. When you refresh the page, everything is deleted. We never ask for your name, email, or phone number. Ever.",
-		"privacy.refresh_note":     "Your data will be deleted on refresh",
-		"step1.title":              "Personal data",
-		"step1.subtitle":           "Basic information for the check.",
-		"step2.title":              "Household",
-		"step2.subtitle":           "Your family composition.",
-		"step3.title":              "Financial situation",
-		"step3.subtitle":           "ISEE and income. If you don't have ISEE, leave 0.",
-		"step4.title":              "Housing situation",
-		"step4.subtitle":           "Residence and home details.",
-		"label.eta":                "Age",
-		"label.regione":            "Region of residence",
-		"label.stato_civile":       "Marital status",
-		"label.occupazione":        "Occupation",
-		"label.numero_figli":       "Number of children",
-		"label.figli_minorenni":    "Minor children",
-		"label.figli_under3":       "Children under 3",
-		"label.over65":             "Over 65 in the household",
-		"label.isee":               "Annual ISEE (EUR)",
-		"label.reddito":            "Annual income (EUR)",
-		"label.disabilita":         "Household member with disability",
-		"label.nuovo_nato":         "Newborn in 2026",
-		"label.affittuario":        "I am renting",
-		"label.prima_casa":         "Owner-occupied first home",
-		"label.ristrutturazione":   "Home renovation in progress / planned",
-		"label.studente":           "University student",
-		"opt.select":               "— Select —",
-		"opt.single":               "Single",
-		"opt.married":              "Married",
-		"opt.cohabiting":           "Cohabiting",
-		"opt.separated":            "Separated / Divorced",
-		"opt.widowed":              "Widowed",
-		"opt.employee":             "Employee",
-		"opt.selfemployed":         "Self-employed / Freelancer",
-		"opt.unemployed":           "Unemployed",
-		"opt.retired":              "Retired",
-		"opt.student":              "Student",
-		"opt.inactive":             "Inactive",
-		"btn.next":                 "Next",
-		"btn.prev":                 "Back",
-		"btn.submit":               "Check bonuses",
-		"btn.reset":                "Clear data and start over",
-		"btn.modify":               "Edit data",
-		"isee.upload":              "Have the ISEE PDF? Drag it here or click to upload",
-		"isee.upload_desc":         "Processed locally, then deleted",
-		"isee.or":                  "or enter manually",
-		"loading.analyzing":        "We are analysing your situation...",
-		"results.title":            "Your bonuses",
-		"results.subtitle":         "estimated savings",
-		"results.no_results":       "No bonuses found",
-		"results.no_results_desc":  "Based on the data you entered, no compatible bonuses were found.",
-		"results.importo":          "Amount",
-		"results.importo_reale":    "Estimated amount for you",
-		"results.requisiti":        "Requirements",
-		"results.come_fare":        "How to apply",
-		"results.documenti":        "Required documents",
-		"results.faq":              "Frequently asked questions",
-		"results.fonti":            "Sources and references",
-		"results.fonte_ist":        "Source:",
-		"results.fonte_edit":       "Legal references:",
-		"results.last_update":      "Updated:",
-		"results.link_ufficiale":   "Official website",
-		"results.details":          "See how to claim it",
-		"results.calendar":         "Deadlines",
-		"results.pdf":              "PDF",
-		"results.print":            "Print",
-		"results.expand":           "Expand all",
-		"results.share":            "Share",
-		"results.share_bonus":      "Share",
-		"sim.title":                "ISEE Simulator",
-		"sim.label":                "Enter a hypothetical ISEE value to find out if you would be entitled to additional bonuses.",
-		"sim.button":               "Simulate",
-		"widget.note":              "Quick estimate: how much could you receive?",
-		"widget.figli_label":       "Number of children",
-		"widget.isee_label":        "ISEE bracket",
-		"widget.isee_under15":      "Under €15,000",
-		"widget.isee_15_25":        "€15,000 - €25,000",
-		"widget.isee_25_40":        "€25,000 - €40,000",
-		"widget.isee_over40":       "Over €40,000",
-		"widget.result":            "Indicative estimate based on national average data",
-		"testimonials.title":       "What families are saying",
-		"coming.title":             "Coming soon to BonusPerMe",
-		"coming.telegram":          "Telegram notifications",
-		"coming.whatsapp":          "WhatsApp updates",
-		"coming.desc":              "Leave your email to be notified at launch",
-		"coming.email_placeholder": "Your email...",
-		"coming.email_button":      "Notify me",
-		"coming.thanks":            "Thank you! We'll notify you at launch.",
-		"caf.find":                 "Find a CAF near you",
-		"footer.gdpr":              "GDPR Compliant",
-		"footer.no_cookie":         "Zero Cookies",
-		"footer.no_tracking":       "Zero Tracking",
-		"footer.eu":                "EU Server",
-		"footer.green":             "Green Hosting",
-		"footer.open":              "Open Source",
-		"footer.disclaimer":        "This service is for guidance purposes only and does not replace the advice of a professional, CAF or patronato.",
-		"nav.home":            "Home",
-		"nav.guide":           "Guides",
-		"nav.caf":             "For CAFs",
-		"nav.contatti":        "Contact",
-		"results.collapse":    "Hide details",
-		"a11y.skip":                "Skip to main content",
-		"topbar.free":              "Free service",
-		"topbar.nodata":            "No data saved",
-		"topbar.updated":           "Data updated on ...",
-		"how.subtitle":             "From check to CAF in 4 steps.",
-		"how.step4.title":          "Take it to the CAF",
-		"how.step4.desc":           "Print the report with documents and official sources. The patronato does the rest.",
-		"privacy.db_title":         "No database",
-		"privacy.db_desc":          "Your data only exists in your browser session. On page refresh, it disappears. We save nothing.",
-		"privacy.track_title":      "Privacy first",
-		"privacy.track_desc":       "We only use anonymous Google Analytics, and only with your consent. No profiling cookies.",
-		"privacy.code_title":       "Open source",
-		"privacy.code_desc":        "The source code is on GitHub under the AGPL-3.0 licence. Anyone can verify what the code does.",
-		"cta.title":                "Find out how much you could save",
-		"cta.subtitle":             "In 2 minutes you'll know exactly which bonuses you're entitled to and how to apply.",
-		"isee_warn.has_isee":       "Remember: the ISEE certificate is valid until 31 December of the current year.",
-		"isee_warn.no_isee":        "You have not entered your ISEE. With a valid ISEE you could unlock up to 12 additional bonuses.",
-		"share.title":              "My bonuses — BonusPerMe",
-		"share.whatsapp":           "Share on WhatsApp",
-		"share.copy":               "Copy summary",
-		"share.copied":             "Copied!",
-		"share.native":             "More options...",
-		"turnstile.note":           "Security check",
-		"contact.title":            "Contact us",
-		"contact.subtitle":         "Questions or suggestions? Write to us.",
-		"contact.nome":             "Name",
-		"contact.email":            "Email",
-		"contact.oggetto":          "Subject",
-		"contact.messaggio":        "Message",
-		"contact.privacy":          "I have read and accept the Privacy Policy",
-		"contact.submit":           "Send message",
-		"contact.thanks":           "Thank you! Your message has been sent.",
-		"contact.opt_info":         "General information",
-		"contact.opt_bug":          "Bug report",
-		"contact.opt_partner":      "Partnership / CAF",
-		"contact.opt_other":        "Other",
-	},
-	"fr": {
-		"hero.pretitle":            "Vérification gratuite",
-		"hero.title":               "Découvrez en 2 minutes à quels bonus vous avez droit",
-		"hero.subtitle":            "Chaque année, des milliers d'euros de bonus restent non réclamés. Répondez à quelques questions et nous vous dirons exactement lesquels vous pouvez obtenir — gratuitement, sans inscription.",
-		"hero.cta":                 "Commencer la vérification",
-		"hero.counter_label":       "familles aidées",
-		"hero.impact":              "2,1 milliards € de bonus non réclamés chaque année en Italie",
-		"how.title":                "Comment ça marche",
-		"how.step1.title":          "Entrez vos données",
-		"how.step1.desc":           "Âge, famille, ISEE et logement. Que l'essentiel, rien de plus.",
-		"how.step2.title":          "Analyse instantanée",
-		"how.step2.desc":           "Nous comparons votre situation avec tous les bonus actifs, nationaux et régionaux.",
-		"how.step3.title":          "Résultats clairs",
-		"how.step3.desc":           "Liste personnalisée avec montants, conditions et instructions étape par étape.",
-		"novita.title":             "Nouveautés bonus 2026",
-		"privacy.title":            "Vos données restent les vôtres",
-		"privacy.text":             "Aucune base de données, aucun cookie, aucun tracking. Vos données restent uniquement dans votre session. Au rafraîchissement de la page, tout est supprimé. Nous ne demandons ni nom, ni email, ni téléphone. Jamais.",
-		"privacy.refresh_note":     "Vos données seront supprimées au rafraîchissement",
-		"step1.title":              "Données personnelles",
-		"step1.subtitle":           "Informations de base pour la vérification.",
-		"step2.title":              "Noyau familial",
-		"step2.subtitle":           "Composition de votre famille.",
-		"step3.title":              "Situation économique",
-		"step3.subtitle":           "ISEE et revenus. Si vous n'avez pas d'ISEE, laissez 0.",
-		"step4.title":              "Situation de logement",
-		"step4.subtitle":           "Résidence et état du logement.",
-		"label.eta":                "Âge",
-		"label.regione":            "Région de résidence",
-		"label.stato_civile":       "État civil",
-		"label.occupazione":        "Profession",
-		"label.numero_figli":       "Nombre d'enfants",
-		"label.figli_minorenni":    "Enfants mineurs",
-		"label.figli_under3":       "Enfants de moins de 3 ans",
-		"label.over65":             "Plus de 65 ans dans le foyer",
-		"label.isee":               "ISEE annuel (EUR)",
-		"label.reddito":            "Revenu annuel (EUR)",
-		"label.disabilita":         "Membre du foyer en situation de handicap",
-		"label.nuovo_nato":         "Nouveau-né en 2026",
-		"label.affittuario":        "Je suis locataire",
-		"label.prima_casa":         "Résidence principale en propriété",
-		"label.ristrutturazione":   "Rénovation en cours / prévue",
-		"label.studente":           "Étudiant universitaire",
-		"opt.select":               "— Sélectionnez —",
-		"opt.single":               "Célibataire",
-		"opt.married":              "Marié(e)",
-		"opt.cohabiting":           "En concubinage",
-		"opt.separated":            "Séparé(e) / Divorcé(e)",
-		"opt.widowed":              "Veuf / Veuve",
-		"opt.employee":             "Salarié(e)",
-		"opt.selfemployed":         "Indépendant(e) / Auto-entrepreneur",
-		"opt.unemployed":           "Au chômage",
-		"opt.retired":              "Retraité(e)",
-		"opt.student":              "Étudiant(e)",
-		"opt.inactive":             "Inactif / Inactive",
-		"btn.next":                 "Suivant",
-		"btn.prev":                 "Précédent",
-		"btn.submit":               "Vérifier les bonus",
-		"btn.reset":                "Effacer les données et recommencer",
-		"btn.modify":               "Modifier les données",
-		"isee.upload":              "Vous avez le PDF ISEE ? Glissez-le ici ou cliquez pour le charger",
-		"isee.upload_desc":         "Traité localement, puis supprimé",
-		"isee.or":                  "ou saisir manuellement",
-		"loading.analyzing":        "Nous analysons votre situation...",
-		"results.title":            "Vos bonus",
-		"results.subtitle":         "d'économies estimées",
-		"results.no_results":       "Aucun bonus trouvé",
-		"results.no_results_desc":  "Avec les données que vous avez saisies, aucun bonus compatible n'a été trouvé.",
-		"results.importo":          "Montant",
-		"results.importo_reale":    "Montant estimé pour vous",
-		"results.requisiti":        "Conditions requises",
-		"results.come_fare":        "Comment faire la demande",
-		"results.documenti":        "Documents nécessaires",
-		"results.faq":              "Questions fréquentes",
-		"results.fonti":            "Sources et références",
-		"results.fonte_ist":        "Source :",
-		"results.fonte_edit":       "Réf. juridiques :",
-		"results.last_update":      "Mis à jour :",
-		"results.link_ufficiale":   "Site officiel",
-		"results.details":          "Voir comment le demander",
-		"results.calendar":         "Échéances",
-		"results.pdf":              "PDF",
-		"results.print":            "Imprimer",
-		"results.expand":           "Tout développer",
-		"results.share":            "Partager",
-		"results.share_bonus":      "Partager",
-		"sim.title":                "Simulateur ISEE",
-		"sim.label":                "Saisissez une valeur ISEE hypothétique pour découvrir si vous auriez droit à des bonus supplémentaires.",
-		"sim.button":               "Simuler",
-		"widget.note":              "Estimation rapide : combien pourriez-vous recevoir ?",
-		"widget.figli_label":       "Nombre d'enfants",
-		"widget.isee_label":        "Tranche ISEE",
-		"widget.isee_under15":      "Moins de 15 000 €",
-		"widget.isee_15_25":        "15 000 € - 25 000 €",
-		"widget.isee_25_40":        "25 000 € - 40 000 €",
-		"widget.isee_over40":       "Plus de 40 000 €",
-		"widget.result":            "Estimation indicative basée sur les données moyennes nationales",
-		"testimonials.title":       "Ce que disent les familles",
-		"coming.title":             "Bientôt sur BonusPerMe",
-		"coming.telegram":          "Notifications Telegram",
-		"coming.whatsapp":          "Mises à jour WhatsApp",
-		"coming.desc":              "Laissez votre email pour être prévenu au lancement",
-		"coming.email_placeholder": "Votre email...",
-		"coming.email_button":      "Prévenez-moi",
-		"coming.thanks":            "Merci ! Nous vous préviendrons au lancement.",
-		"caf.find":                 "Trouver un CAF près de chez vous",
-		"footer.gdpr":              "Conforme GDPR",
-		"footer.no_cookie":         "Zéro Cookie",
-		"footer.no_tracking":       "Zéro Tracking",
-		"footer.eu":                "Serveur EU",
-		"footer.green":             "Green Hosting",
-		"footer.open":              "Open Source",
-		"footer.disclaimer":        "Ce service est à titre indicatif et ne remplace pas le conseil d'un professionnel, CAF ou patronato.",
-		"nav.home":            "Accueil",
-		"nav.guide":           "Guides",
-		"nav.caf":             "Pour les CAF",
-		"nav.contatti":        "Contact",
-		"results.collapse":    "Masquer les détails",
-		"a11y.skip":                "Aller au contenu principal",
-		"topbar.free":              "Service gratuit",
-		"topbar.nodata":            "Aucune donnée sauvegardée",
-		"topbar.updated":           "Données mises à jour le ...",
-		"how.subtitle":             "De la vérification au CAF en 4 étapes.",
-		"how.step4.title":          "Apportez au CAF",
-		"how.step4.desc":           "Imprimez le rapport avec les documents et les sources officielles. Le patronato fait le reste.",
-		"privacy.db_title":         "Aucune base de données",
-		"privacy.db_desc":          "Vos données n'existent que dans votre session navigateur. Au rafraîchissement, elles disparaissent.",
-		"privacy.track_title":      "Privacy first",
-		"privacy.track_desc":       "Nous utilisons uniquement Google Analytics anonyme, et uniquement avec votre consentement. Aucun cookie de profilage.",
-		"privacy.code_title":       "Code ouvert",
-		"privacy.code_desc":        "Le code source est sur GitHub sous licence AGPL-3.0. Chacun peut vérifier ce que fait le code.",
-		"cta.title":                "Découvrez combien vous pourriez économiser",
-		"cta.subtitle":             "En 2 minutes, vous savez exactement à quels bonus vous avez droit et comment les demander.",
-		"isee_warn.has_isee":       "Rappel : l'attestation ISEE est valable jusqu'au 31 décembre de l'année en cours.",
-		"isee_warn.no_isee":        "Vous n'avez pas saisi votre ISEE. Avec un ISEE valide, vous pourriez débloquer jusqu'à 12 bonus supplémentaires.",
-		"share.title":              "Mes bonus — BonusPerMe",
-		"share.whatsapp":           "Partager sur WhatsApp",
-		"share.copy":               "Copier le résumé",
-		"share.copied":             "Copié !",
-		"share.native":             "Autres options...",
-		"turnstile.note":           "Vérification de sécurité",
-		"contact.title":            "Contactez-nous",
-		"contact.subtitle":         "Des questions ou des suggestions ? Écrivez-nous.",
-		"contact.nome":             "Nom",
-		"contact.email":            "Email",
-		"contact.oggetto":          "Objet",
-		"contact.messaggio":        "Message",
-		"contact.privacy":          "J'ai lu et j'accepte la Politique de confidentialité",
-		"contact.submit":           "Envoyer le message",
-		"contact.thanks":           "Merci ! Votre message a été envoyé.",
-		"contact.opt_info":         "Informations générales",
-		"contact.opt_bug":          "Signalement de bug",
-		"contact.opt_partner":      "Partenariat / CAF",
-		"contact.opt_other":        "Autre",
-	},
-	"es": {
-		"hero.pretitle":            "Verificación gratuita",
-		"hero.title":               "Descubre en 2 minutos a qué bonos tienes derecho",
-		"hero.subtitle":            "Cada año, miles de euros en bonos quedan sin reclamar. Responde unas pocas preguntas y te diremos exactamente cuáles puedes obtener — gratis, sin registro.",
-		"hero.cta":                 "Iniciar la verificación",
-		"hero.counter_label":       "familias ayudadas",
-		"hero.impact":              "2.100 millones € en bonos no reclamados cada año en Italia",
-		"how.title":                "Cómo funciona",
-		"how.step1.title":          "Introduce tus datos",
-		"how.step1.desc":           "Edad, familia, ISEE y vivienda. Solo lo esencial, nada más.",
-		"how.step2.title":          "Análisis instantáneo",
-		"how.step2.desc":           "Comparamos tu situación con todos los bonos activos, nacionales y regionales.",
-		"how.step3.title":          "Resultados claros",
-		"how.step3.desc":           "Lista personalizada con importes, requisitos e instrucciones paso a paso.",
-		"novita.title":             "Novedades bonos 2026",
-		"privacy.title":            "Tus datos son tuyos",
-		"privacy.text":             "Sin base de datos, sin cookies, sin tracking. Tus datos permanecen solo en tu sesión. Al actualizar la página, todo se borra. No pedimos nombre, email ni teléfono. Nunca.",
-		"privacy.refresh_note":     "Tus datos se borrarán al actualizar",
-		"step1.title":              "Datos personales",
-		"step1.subtitle":           "Información básica para la verificación.",
-		"step2.title":              "Núcleo familiar",
-		"step2.subtitle":           "Composición de tu familia.",
-		"step3.title":              "Situación económica",
-		"step3.subtitle":           "ISEE e ingresos. Si no tienes ISEE, deja 0.",
-		"step4.title":              "Situación habitacional",
-		"step4.subtitle":           "Residencia y estado de la vivienda.",
-		"label.eta":                "Edad",
-		"label.regione":            "Región de residencia",
-		"label.stato_civile":       "Estado civil",
-		"label.occupazione":        "Ocupación",
-		"label.numero_figli":       "Número de hijos",
-		"label.figli_minorenni":    "Hijos menores",
-		"label.figli_under3":       "Hijos menores de 3 años",
-		"label.over65":             "Mayores de 65 en el hogar",
-		"label.isee":               "ISEE anual (EUR)",
-		"label.reddito":            "Ingresos anuales (EUR)",
-		"label.disabilita":         "Miembro del hogar con discapacidad",
-		"label.nuovo_nato":         "Recién nacido en 2026",
-		"label.affittuario":        "Soy inquilino",
-		"label.prima_casa":         "Primera vivienda en propiedad",
-		"label.ristrutturazione":   "Reforma en curso / prevista",
-		"label.studente":           "Estudiante universitario",
-		"opt.select":               "— Selecciona —",
-		"opt.single":               "Soltero/a",
-		"opt.married":              "Casado/a",
-		"opt.cohabiting":           "Conviviente",
-		"opt.separated":            "Separado/a · Divorciado/a",
-		"opt.widowed":              "Viudo/a",
-		"opt.employee":             "Empleado/a",
-		"opt.selfemployed":         "Autónomo/a",
-		"opt.unemployed":           "Desempleado/a",
-		"opt.retired":              "Jubilado/a",
-		"opt.student":              "Estudiante",
-		"opt.inactive":             "Inactivo/a",
-		"btn.next":                 "Siguiente",
-		"btn.prev":                 "Atrás",
-		"btn.submit":               "Verificar bonos",
-		"btn.reset":                "Borrar datos y empezar de nuevo",
-		"btn.modify":               "Modificar datos",
-		"isee.upload":              "¿Tienes el PDF del ISEE? Arrástralo aquí o haz clic para cargarlo",
-		"isee.upload_desc":         "Procesado localmente, luego eliminado",
-		"isee.or":                  "o introduce manualmente",
-		"loading.analyzing":        "Estamos analizando tu situación...",
-		"results.title":            "Tus bonos",
-		"results.subtitle":         "de ahorro estimado",
-		"results.no_results":       "No se encontraron bonos",
-		"results.no_results_desc":  "Con los datos que has introducido no se encontraron bonos compatibles.",
-		"results.importo":          "Importe",
-		"results.importo_reale":    "Importe estimado para ti",
-		"results.requisiti":        "Requisitos",
-		"results.come_fare":        "Cómo solicitarlo",
-		"results.documenti":        "Documentos necesarios",
-		"results.faq":              "Preguntas frecuentes",
-		"results.fonti":            "Fuentes y referencias",
-		"results.fonte_ist":        "Fuente:",
-		"results.fonte_edit":       "Ref. normativas:",
-		"results.last_update":      "Actualizado:",
-		"results.link_ufficiale":   "Sitio oficial",
-		"results.details":          "Ver cómo solicitarlo",
-		"results.calendar":         "Plazos",
-		"results.pdf":              "PDF",
-		"results.print":            "Imprimir",
-		"results.expand":           "Expandir todo",
-		"results.share":            "Compartir",
-		"results.share_bonus":      "Compartir",
-		"sim.title":                "Simulador ISEE",
-		"sim.label":                "Introduce un valor ISEE hipotético para descubrir si tendrías derecho a bonos adicionales.",
-		"sim.button":               "Simular",
-		"widget.note":              "Estimación rápida: ¿cuánto podrías recibir?",
-		"widget.figli_label":       "Número de hijos",
-		"widget.isee_label":        "Franja ISEE",
-		"widget.isee_under15":      "Menos de 15.000 €",
-		"widget.isee_15_25":        "15.000 € - 25.000 €",
-		"widget.isee_25_40":        "25.000 € - 40.000 €",
-		"widget.isee_over40":       "Más de 40.000 €",
-		"widget.result":            "Estimación indicativa basada en datos medios nacionales",
-		"testimonials.title":       "Lo que dicen las familias",
-		"coming.title":             "Próximamente en BonusPerMe",
-		"coming.telegram":          "Notificaciones de Telegram",
-		"coming.whatsapp":          "Actualizaciones de WhatsApp",
-		"coming.desc":              "Deja tu email para ser avisado en el lanzamiento",
-		"coming.email_placeholder": "Tu email...",
-		"coming.email_button":      "Avísame",
-		"coming.thanks":            "¡Gracias! Te avisaremos en el lanzamiento.",
-		"caf.find":                 "Encuentra un CAF cerca de ti",
-		"footer.gdpr":              "Conforme con GDPR",
-		"footer.no_cookie":         "Cero Cookies",
-		"footer.no_tracking":       "Cero Tracking",
-		"footer.eu":                "Servidor EU",
-		"footer.green":             "Green Hosting",
-		"footer.open":              "Open Source",
-		"footer.disclaimer":        "Este servicio es orientativo y no sustituye el asesoramiento de un profesional, CAF o patronato.",
-		"nav.home":            "Inicio",
-		"nav.guide":           "Guías",
-		"nav.caf":             "Para CAF",
-		"nav.contatti":        "Contacto",
-		"results.collapse":    "Ocultar detalles",
-		"a11y.skip":                "Ir al contenido principal",
-		"topbar.free":              "Servicio gratuito",
-		"topbar.nodata":            "Ningún dato guardado",
-		"topbar.updated":           "Datos actualizados el ...",
-		"how.subtitle":             "De la verificación al CAF en 4 pasos.",
-		"how.step4.title":          "Llévalo al CAF",
-		"how.step4.desc":           "Imprime el informe con los documentos y las fuentes oficiales. El patronato hace el resto.",
-		"privacy.db_title":         "Sin base de datos",
-		"privacy.db_desc":          "Tus datos solo existen en tu sesión del navegador. Al actualizar, desaparecen.",
-		"privacy.track_title":      "Privacy first",
-		"privacy.track_desc":       "Usamos solo Google Analytics anónimo, y solo con tu consentimiento. Ninguna cookie de perfilado.",
-		"privacy.code_title":       "Código abierto",
-		"privacy.code_desc":        "El código fuente está en GitHub bajo licencia AGPL-3.0. Cualquiera puede verificar lo que hace el código.",
-		"cta.title":                "Descubre cuánto podrías ahorrar",
-		"cta.subtitle":             "En 2 minutos sabes exactamente a qué bonos tienes derecho y cómo solicitarlos.",
-		"isee_warn.has_isee":       "Recuerda: la certificación ISEE tiene validez hasta el 31 de diciembre del año en curso.",
-		"isee_warn.no_isee":        "No has introducido el ISEE. Con un ISEE válido podrías desbloquear hasta 12 bonos adicionales.",
-		"share.title":              "Mis bonos — BonusPerMe",
-		"share.whatsapp":           "Compartir en WhatsApp",
-		"share.copy":               "Copiar resumen",
-		"share.copied":             "¡Copiado!",
-		"share.native":             "Más opciones...",
-		"turnstile.note":           "Verificación de seguridad",
-		"contact.title":            "Contáctanos",
-		"contact.subtitle":         "¿Preguntas o sugerencias? Escríbenos.",
-		"contact.nome":             "Nombre",
-		"contact.email":            "Email",
-		"contact.oggetto":          "Asunto",
-		"contact.messaggio":        "Mensaje",
-		"contact.privacy":          "He leído y acepto la Política de Privacidad",
-		"contact.submit":           "Enviar mensaje",
-		"contact.thanks":           "¡Gracias! Tu mensaje ha sido enviado.",
-		"contact.opt_info":         "Información general",
-		"contact.opt_bug":          "Reporte de error",
-		"contact.opt_partner":      "Asociación / CAF",
-		"contact.opt_other":        "Otro",
-	},
-	"ro": {
-		"hero.pretitle":            "Verificare gratuită",
-		"hero.title":               "Descoperă în 2 minute la ce bonusuri ai dreptul",
-		"hero.subtitle":            "În fiecare an, mii de euro în bonusuri rămân nerevendicate. Răspunde la câteva întrebări și îți spunem exact ce poți obține — gratuit, fără înregistrare.",
-		"hero.cta":                 "Începe verificarea",
-		"hero.counter_label":       "familii ajutate",
-		"hero.impact":              "2,1 miliarde € în bonusuri nerevendicate în fiecare an în Italia",
-		"how.title":                "Cum funcționează",
-		"how.step1.title":          "Introdu datele",
-		"how.step1.desc":           "Vârstă, familie, ISEE și locuință. Doar esențialul, nimic mai mult.",
-		"how.step2.title":          "Analiză instantanee",
-		"how.step2.desc":           "Comparăm situația ta cu toate bonusurile active, naționale și regionale.",
-		"how.step3.title":          "Rezultate clare",
-		"how.step3.desc":           "Listă personalizată cu sume, cerințe și instrucțiuni pas cu pas.",
-		"novita.title":             "Noutăți bonusuri 2026",
-		"privacy.title":            "Datele tale rămân ale tale",
-		"privacy.text":             "Nicio bază de date, niciun cookie, niciun tracking. Datele tale rămân doar în sesiunea ta. La reîmprospătarea paginii, totul se șterge. Nu cerem nume, email sau telefon. Niciodată.",
-		"privacy.refresh_note":     "Datele tale vor fi șterse la reîmprospătare",
-		"step1.title":              "Date personale",
-		"step1.subtitle":           "Informații de bază pentru verificare.",
-		"step2.title":              "Nucleul familial",
-		"step2.subtitle":           "Componența familiei tale.",
-		"step3.title":              "Situația economică",
-		"step3.subtitle":           "ISEE și venituri. Dacă nu ai ISEE, lasă 0.",
-		"step4.title":              "Situația locativă",
-		"step4.subtitle":           "Reședința și starea locuinței.",
-		"label.eta":                "Vârstă",
-		"label.regione":            "Regiunea de reședință",
-		"label.stato_civile":       "Stare civilă",
-		"label.occupazione":        "Ocupație",
-		"label.numero_figli":       "Număr de copii",
-		"label.figli_minorenni":    "Copii minori",
-		"label.figli_under3":       "Copii sub 3 ani",
-		"label.over65":             "Peste 65 de ani în gospodărie",
-		"label.isee":               "ISEE anual (EUR)",
-		"label.reddito":            "Venit anual (EUR)",
-		"label.disabilita":         "Membru al familiei cu dizabilitate",
-		"label.nuovo_nato":         "Nou-născut în 2026",
-		"label.affittuario":        "Sunt chiriaș",
-		"label.prima_casa":         "Prima casă în proprietate",
-		"label.ristrutturazione":   "Renovare în curs / planificată",
-		"label.studente":           "Student universitar",
-		"opt.select":               "— Selectează —",
-		"opt.single":               "Necăsătorit(ă)",
-		"opt.married":              "Căsătorit(ă)",
-		"opt.cohabiting":           "Concubin(ă)",
-		"opt.separated":            "Separat(ă) / Divorțat(ă)",
-		"opt.widowed":              "Văduv(ă)",
-		"opt.employee":             "Angajat(ă)",
-		"opt.selfemployed":         "Liber profesionist / P.IVA",
-		"opt.unemployed":           "Șomer(ă)",
-		"opt.retired":              "Pensionar(ă)",
-		"opt.student":              "Student(ă)",
-		"opt.inactive":             "Inactiv(ă)",
-		"btn.next":                 "Înainte",
-		"btn.prev":                 "Înapoi",
-		"btn.submit":               "Verifică bonusurile",
-		"btn.reset":                "Șterge datele și reîncepe",
-		"btn.modify":               "Modifică datele",
-		"isee.upload":              "Ai PDF-ul ISEE? Trage-l aici sau click pentru a-l încărca",
-		"isee.upload_desc":         "Procesat local, apoi șters",
-		"isee.or":                  "sau introdu manual",
-		"loading.analyzing":        "Analizăm situația ta...",
-		"results.title":            "Bonusurile tale",
-		"results.subtitle":         "economii estimate",
-		"results.no_results":       "Niciun bonus găsit",
-		"results.no_results_desc":  "Cu datele introduse nu au fost găsite bonusuri compatibile.",
-		"results.importo":          "Sumă",
-		"results.importo_reale":    "Sumă estimată pentru tine",
-		"results.requisiti":        "Cerințe",
-		"results.come_fare":        "Cum aplici",
-		"results.documenti":        "Documente necesare",
-		"results.faq":              "Întrebări frecvente",
-		"results.fonti":            "Surse și referințe",
-		"results.fonte_ist":        "Sursă:",
-		"results.fonte_edit":       "Ref. normative:",
-		"results.last_update":      "Actualizat:",
-		"results.link_ufficiale":   "Site oficial",
-		"results.details":          "Vezi cum să îl soliciți",
-		"results.calendar":         "Termene",
-		"results.pdf":              "PDF",
-		"results.print":            "Tipărește",
-		"results.expand":           "Extinde tot",
-		"results.share":            "Distribuie",
-		"results.share_bonus":      "Distribuie",
-		"sim.title":                "Simulator ISEE",
-		"sim.label":                "Introdu o valoare ISEE ipotetică pentru a descoperi dacă ai avea dreptul la bonusuri suplimentare.",
-		"sim.button":               "Simulează",
-		"widget.note":              "Estimare rapidă: cât ai putea primi?",
-		"widget.figli_label":       "Număr de copii",
-		"widget.isee_label":        "Interval ISEE",
-		"widget.isee_under15":      "Sub 15.000 €",
-		"widget.isee_15_25":        "15.000 € - 25.000 €",
-		"widget.isee_25_40":        "25.000 € - 40.000 €",
-		"widget.isee_over40":       "Peste 40.000 €",
-		"widget.result":            "Estimare indicativă bazată pe date medii naționale",
-		"testimonials.title":       "Ce spun familiile",
-		"coming.title":             "În curând pe BonusPerMe",
-		"coming.telegram":          "Notificări Telegram",
-		"coming.whatsapp":          "Actualizări WhatsApp",
-		"coming.desc":              "Lasă emailul tău pentru a fi notificat la lansare",
-		"coming.email_placeholder": "Emailul tău...",
-		"coming.email_button":      "Anunță-mă",
-		"coming.thanks":            "Mulțumim! Te vom anunța la lansare.",
-		"caf.find":                 "Găsește un CAF în apropiere",
-		"footer.gdpr":              "Conform GDPR",
-		"footer.no_cookie":         "Zero Cookie",
-		"footer.no_tracking":       "Zero Tracking",
-		"footer.eu":                "Server EU",
-		"footer.green":             "Green Hosting",
-		"footer.open":              "Open Source",
-		"footer.disclaimer":        "Acest serviciu are caracter orientativ și nu înlocuiește consultanța unui profesionist, CAF sau patronato.",
-		"nav.home":            "Acasă",
-		"nav.guide":           "Ghiduri",
-		"nav.caf":             "Pentru CAF",
-		"nav.contatti":        "Contact",
-		"results.collapse":    "Ascunde detalii",
-		"a11y.skip":                "Salt la conținutul principal",
-		"topbar.free":              "Serviciu gratuit",
-		"topbar.nodata":            "Nicio dată salvată",
-		"topbar.updated":           "Date actualizate la ...",
-		"how.subtitle":             "De la verificare la CAF în 4 pași.",
-		"how.step4.title":          "Du-l la CAF",
-		"how.step4.desc":           "Tipărește raportul cu documentele și sursele oficiale. Patronatul face restul.",
-		"privacy.db_title":         "Nicio bază de date",
-		"privacy.db_desc":          "Datele tale există doar în sesiunea browserului. La reîmprospătare, dispar.",
-		"privacy.track_title":      "Privacy first",
-		"privacy.track_desc":       "Folosim doar Google Analytics anonim, și doar cu acordul tău. Niciun cookie de profilare.",
-		"privacy.code_title":       "Cod deschis",
-		"privacy.code_desc":        "Codul sursă este pe GitHub sub licența AGPL-3.0. Oricine poate verifica ce face codul.",
-		"cta.title":                "Descoperă cât ai putea economisi",
-		"cta.subtitle":             "În 2 minute știi exact la ce bonusuri ai dreptul și cum să aplici.",
-		"isee_warn.has_isee":       "Atenție: atestarea ISEE este valabilă până la 31 decembrie a anului în curs.",
-		"isee_warn.no_isee":        "Nu ai introdus ISEE-ul. Cu un ISEE valid ai putea debloca până la 12 bonusuri suplimentare.",
-		"share.title":              "Bonusurile mele — BonusPerMe",
-		"share.whatsapp":           "Distribuie pe WhatsApp",
-		"share.copy":               "Copiază rezumatul",
-		"share.copied":             "Copiat!",
-		"share.native":             "Alte opțiuni...",
-		"turnstile.note":           "Verificare de securitate",
-		"contact.title":            "Contactează-ne",
-		"contact.subtitle":         "Ai întrebări sau sugestii? Scrie-ne.",
-		"contact.nome":             "Nume",
-		"contact.email":            "Email",
-		"contact.oggetto":          "Subiect",
-		"contact.messaggio":        "Mesaj",
-		"contact.privacy":          "Am citit și accept Politica de confidențialitate",
-		"contact.submit":           "Trimite mesajul",
-		"contact.thanks":           "Mulțumim! Mesajul tău a fost trimis.",
-		"contact.opt_info":         "Informații generale",
-		"contact.opt_bug":          "Raportare eroare",
-		"contact.opt_partner":      "Parteneriat / CAF",
-		"contact.opt_other":        "Altele",
-	},
-	"ar": {
-		"hero.pretitle":            "تحقّق مجاني",
-		"hero.title":               "اكتشف في دقيقتين المكافآت التي يحق لك الحصول عليها",
-		"hero.subtitle":            "كل عام، تبقى آلاف اليوروهات من المكافآت دون مطالبة. أجب عن بضعة أسئلة وسنخبرك بالضبط بما يمكنك الحصول عليه — مجاناً، بدون تسجيل.",
-		"hero.cta":                 "ابدأ التحقق",
-		"hero.counter_label":       "عائلة تمّت مساعدتها",
-		"hero.impact":              "2.1 مليار يورو من المكافآت غير المطالب بها كل عام في إيطاليا",
-		"how.title":                "كيف يعمل",
-		"how.step1.title":          "أدخل بياناتك",
-		"how.step1.desc":           "العمر، العائلة، ISEE والسكن. الأساسيات فقط، لا أكثر.",
-		"how.step2.title":          "تحليل فوري",
-		"how.step2.desc":           "نقارن وضعك مع جميع المكافآت النشطة، الوطنية والإقليمية.",
-		"how.step3.title":          "نتائج واضحة",
-		"how.step3.desc":           "قائمة مخصّصة بالمبالغ والمتطلبات والتعليمات خطوة بخطوة.",
-		"novita.title":             "جديد المكافآت 2026",
-		"privacy.title":            "بياناتك تبقى ملكك",
-		"privacy.text":             "لا قاعدة بيانات، لا ملفات تعريف ارتباط، لا تتبّع. بياناتك تبقى في جلستك فقط. عند تحديث الصفحة، يُحذف كل شيء. لا نطلب الاسم أو البريد الإلكتروني أو رقم الهاتف. أبداً.",
-		"privacy.refresh_note":     "سيتم حذف بياناتك عند تحديث الصفحة",
-		"step1.title":              "البيانات الشخصية",
-		"step1.subtitle":           "معلومات أساسية للتحقق.",
-		"step2.title":              "الأسرة",
-		"step2.subtitle":           "تكوين عائلتك.",
-		"step3.title":              "الوضع الاقتصادي",
-		"step3.subtitle":           "ISEE والدخل. إذا لم يكن لديك ISEE، اترك 0.",
-		"step4.title":              "الوضع السكني",
-		"step4.subtitle":           "الإقامة وحالة المسكن.",
-		"label.eta":                "العمر",
-		"label.regione":            "منطقة الإقامة",
-		"label.stato_civile":       "الحالة الاجتماعية",
-		"label.occupazione":        "المهنة",
-		"label.numero_figli":       "عدد الأبناء",
-		"label.figli_minorenni":    "أطفال قاصرون",
-		"label.figli_under3":       "أطفال دون 3 سنوات",
-		"label.over65":             "فوق 65 سنة في الأسرة",
-		"label.isee":               "ISEE السنوي (EUR)",
-		"label.reddito":            "الدخل السنوي (EUR)",
-		"label.disabilita":         "فرد من الأسرة من ذوي الإعاقة",
-		"label.nuovo_nato":         "مولود جديد في 2026",
-		"label.affittuario":        "أنا مستأجر",
-		"label.prima_casa":         "المسكن الأول ملك",
-		"label.ristrutturazione":   "ترميم جارٍ / مخطط له",
-		"label.studente":           "طالب جامعي",
-		"opt.select":               "— اختر —",
-		"opt.single":               "أعزب / عزباء",
-		"opt.married":              "متزوج / متزوجة",
-		"opt.cohabiting":           "مساكن / مساكنة",
-		"opt.separated":            "منفصل / مطلّق",
-		"opt.widowed":              "أرمل / أرملة",
-		"opt.employee":             "موظف / موظفة",
-		"opt.selfemployed":         "عامل مستقل / P.IVA",
-		"opt.unemployed":           "عاطل عن العمل",
-		"opt.retired":              "متقاعد / متقاعدة",
-		"opt.student":              "طالب / طالبة",
-		"opt.inactive":             "غير عامل",
-		"btn.next":                 "التالي",
-		"btn.prev":                 "السابق",
-		"btn.submit":               "تحقّق من المكافآت",
-		"btn.reset":                "امسح البيانات وابدأ من جديد",
-		"btn.modify":               "عدّل البيانات",
-		"isee.upload":              "هل لديك ملف ISEE بصيغة PDF؟ اسحبه هنا أو انقر لتحميله",
-		"isee.upload_desc":         "تتم المعالجة محلياً ثم يُحذف الملف",
-		"isee.or":                  "أو أدخل يدوياً",
-		"loading.analyzing":        "نحن نحلّل وضعك...",
-		"results.title":            "مكافآتك",
-		"results.subtitle":         "توفير تقديري",
-		"results.no_results":       "لم يتم العثور على مكافآت",
-		"results.no_results_desc":  "بناءً على البيانات التي أدخلتها، لم يتم العثور على مكافآت متوافقة.",
-		"results.importo":          "المبلغ",
-		"results.importo_reale":    "المبلغ التقديري لك",
-		"results.requisiti":        "المتطلبات",
-		"results.come_fare":        "كيفية تقديم الطلب",
-		"results.documenti":        "المستندات المطلوبة",
-		"results.faq":              "الأسئلة الشائعة",
-		"results.fonti":            "المصادر والمراجع",
-		"results.fonte_ist":        "المصدر:",
-		"results.fonte_edit":       "المراجع القانونية:",
-		"results.last_update":      "آخر تحديث:",
-		"results.link_ufficiale":   "الموقع الرسمي",
-		"results.details":          "شاهد كيفية طلبه",
-		"results.calendar":         "المواعيد",
-		"results.pdf":              "PDF",
-		"results.print":            "طباعة",
-		"results.expand":           "وسّع الكل",
-		"results.share":            "شارك",
-		"results.share_bonus":      "شارك",
-		"sim.title":                "محاكي ISEE",
-		"sim.label":                "أدخل قيمة ISEE افتراضية لمعرفة ما إذا كان يحق لك الحصول على مكافآت إضافية.",
-		"sim.button":               "محاكاة",
-		"widget.note":              "تقدير سريع: كم يمكنك أن تحصل؟",
-		"widget.figli_label":       "عدد الأبناء",
-		"widget.isee_label":        "شريحة ISEE",
-		"widget.isee_under15":      "أقل من 15,000 €",
-		"widget.isee_15_25":        "15,000 € - 25,000 €",
-		"widget.isee_25_40":        "25,000 € - 40,000 €",
-		"widget.isee_over40":       "أكثر من 40,000 €",
-		"widget.result":            "تقدير إرشادي بناءً على المعدّلات الوطنية",
-		"testimonials.title":       "ماذا تقول العائلات",
-		"coming.title":             "قريباً على BonusPerMe",
-		"coming.telegram":          "إشعارات Telegram",
-		"coming.whatsapp":          "تحديثات WhatsApp",
-		"coming.desc":              "اترك بريدك الإلكتروني ليتم إعلامك عند الإطلاق",
-		"coming.email_placeholder": "بريدك الإلكتروني...",
-		"coming.email_button":      "أعلمني",
-		"coming.thanks":            "شكراً! سنعلمك عند الإطلاق.",
-		"caf.find":                 "ابحث عن CAF بالقرب منك",
-		"footer.gdpr":              "متوافق مع GDPR",
-		"footer.no_cookie":         "بدون ملفات تعريف ارتباط",
-		"footer.no_tracking":       "بدون تتبّع",
-		"footer.eu":                "خادم في الاتحاد الأوروبي",
-		"footer.green":             "استضافة صديقة للبيئة",
-		"footer.open":              "مفتوح المصدر",
-		"footer.disclaimer":        "هذه الخدمة استرشادية ولا تحلّ محلّ استشارة متخصص أو CAF أو patronato.",
-		"nav.home":            "الرئيسية",
-		"nav.guide":           "أدلة",
-		"nav.caf":             "لمراكز المساعدة",
-		"nav.contatti":        "اتصل بنا",
-		"results.collapse":    "إخفاء التفاصيل",
-		"a11y.skip":                "انتقل إلى المحتوى الرئيسي",
-		"topbar.free":              "خدمة مجانية",
-		"topbar.nodata":            "لا بيانات محفوظة",
-		"topbar.updated":           "تم تحديث البيانات في ...",
-		"how.subtitle":             "من التحقق إلى CAF في 4 خطوات.",
-		"how.step4.title":          "خذه إلى CAF",
-		"how.step4.desc":           "اطبع التقرير مع المستندات والمصادر الرسمية. المكتب يقوم بالباقي.",
-		"privacy.db_title":         "لا قاعدة بيانات",
-		"privacy.db_desc":          "بياناتك موجودة فقط في جلسة المتصفح. عند التحديث، تختفي.",
-		"privacy.track_title":      "Privacy first",
-		"privacy.track_desc":       "نستخدم فقط Google Analytics مجهول الهوية، وفقط بموافقتك. لا ملفات تعريف ارتباط للتنميط.",
-		"privacy.code_title":       "كود مفتوح",
-		"privacy.code_desc":        "الكود المصدري على GitHub تحت رخصة AGPL-3.0. يمكن لأي شخص التحقق مما يفعله الكود.",
-		"cta.title":                "اكتشف كم يمكنك توفيره",
-		"cta.subtitle":             "في دقيقتين تعرف بالضبط ما هي المكافآت التي يحق لك الحصول عليها وكيفية التقديم.",
-		"isee_warn.has_isee":       "تذكّر: شهادة ISEE صالحة حتى 31 ديسمبر من العام الحالي.",
-		"isee_warn.no_isee":        "لم تُدخل ISEE. بشهادة ISEE صالحة يمكنك فتح ما يصل إلى 12 مكافأة إضافية.",
-		"share.title":              "مكافآتي — BonusPerMe",
-		"share.whatsapp":           "شارك عبر WhatsApp",
-		"share.copy":               "انسخ الملخّص",
-		"share.copied":             "تم النسخ!",
-		"share.native":             "خيارات أخرى...",
-		"turnstile.note":           "فحص أمني",
-		"contact.title":            "تواصل معنا",
-		"contact.subtitle":         "أسئلة أو اقتراحات؟ اكتب لنا.",
-		"contact.nome":             "الاسم",
-		"contact.email":            "البريد الإلكتروني",
-		"contact.oggetto":          "الموضوع",
-		"contact.messaggio":        "الرسالة",
-		"contact.privacy":          "لقد قرأت وأوافق على سياسة الخصوصية",
-		"contact.submit":           "إرسال الرسالة",
-		"contact.thanks":           "شكراً! تم إرسال رسالتك.",
-		"contact.opt_info":         "معلومات عامة",
-		"contact.opt_bug":          "الإبلاغ عن خطأ",
-		"contact.opt_partner":      "شراكة / CAF",
-		"contact.opt_other":        "أخرى",
-	},
-	"sq": {
-		"hero.pretitle":            "Verifikim falas",
-		"hero.title":               "Zbulo në 2 minuta cilat bonuse ke të drejtë",
-		"hero.subtitle":            "Çdo vit, mijëra euro bonuse mbeten pa u kërkuar. Përgjigju disa pyetjeve dhe ne të tregojmë saktësisht cilat mund të marrësh — falas, pa regjistrim.",
-		"hero.cta":                 "Fillo verifikimin",
-		"hero.counter_label":       "familje të ndihmuar",
-		"hero.impact":              "2,1 miliardë € bonuse të pakërkuara çdo vit në Itali",
-		"how.title":                "Si funksionon",
-		"how.step1.title":          "Vendos të dhënat",
-		"how.step1.desc":           "Mosha, familja, ISEE dhe banesa. Vetëm gjërat thelbësore, asgjë më shumë.",
-		"how.step2.title":          "Analizë e menjëhershme",
-		"how.step2.desc":           "Krahasojmë situatën tënde me të gjitha bonuset aktive, kombëtare dhe rajonale.",
-		"how.step3.title":          "Rezultate të qarta",
-		"how.step3.desc":           "Listë e personalizuar me shuma, kërkesa dhe udhëzime hap pas hapi.",
-		"novita.title":             "Risi bonuset 2026",
-		"privacy.title":            "Të dhënat e tua mbeten të tuat",
-		"privacy.text":             "Asnjë bazë të dhënash, asnjë cookie, asnjë gjurmim. Të dhënat e tua mbeten vetëm në sesionin tënd. Kur rifresko faqen, gjithçka fshihet. Nuk kërkojmë emër, email apo telefon. Asnjëherë.",
-		"privacy.refresh_note":     "Të dhënat e tua do të fshihen kur të rifreskosh faqen",
-		"step1.title":              "Të dhënat personale",
-		"step1.subtitle":           "Informacione bazë për verifikimin.",
-		"step2.title":              "Bërthama familjare",
-		"step2.subtitle":           "Përbërja e familjes tënde.",
-		"step3.title":              "Situata ekonomike",
-		"step3.subtitle":           "ISEE dhe të ardhura. Nëse nuk ke ISEE, lër 0.",
-		"step4.title":              "Situata e banimit",
-		"step4.subtitle":           "Vendbanimi dhe gjendja e shtëpisë.",
-		"label.eta":                "Mosha",
-		"label.regione":            "Rajoni i banimit",
-		"label.stato_civile":       "Gjendja civile",
-		"label.occupazione":        "Punësimi",
-		"label.numero_figli":       "Numri i fëmijëve",
-		"label.figli_minorenni":    "Fëmijë të mitur",
-		"label.figli_under3":       "Fëmijë nën 3 vjeç",
-		"label.over65":             "Mbi 65 vjeç në familje",
-		"label.isee":               "ISEE vjetor (EUR)",
-		"label.reddito":            "Të ardhura vjetore (EUR)",
-		"label.disabilita":         "Anëtar i familjes me aftësi të kufizuar",
-		"label.nuovo_nato":         "Fëmijë i porsalindur në 2026",
-		"label.affittuario":        "Jam me qira",
-		"label.prima_casa":         "Shtëpia e parë në pronësi",
-		"label.ristrutturazione":   "Ristrukturim në vazhdim / i planifikuar",
-		"label.studente":           "Student universitar",
-		"opt.select":               "— Zgjidh —",
-		"opt.single":               "Beqar/e",
-		"opt.married":              "I/e martuar",
-		"opt.cohabiting":           "Bashkëjetues/e",
-		"opt.separated":            "I/e ndarë · I/e divorcuar",
-		"opt.widowed":              "I/e ve",
-		"opt.employee":             "I/e punësuar",
-		"opt.selfemployed":         "I/e pavarur / P.IVA",
-		"opt.unemployed":           "I/e papunë",
-		"opt.retired":              "I/e pensionuar",
-		"opt.student":              "Student/e",
-		"opt.inactive":             "Joaktiv/e",
-		"btn.next":                 "Para",
-		"btn.prev":                 "Prapa",
-		"btn.submit":               "Verifiko bonuset",
-		"btn.reset":                "Fshi të dhënat dhe rifillo",
-		"btn.modify":               "Modifiko të dhënat",
-		"isee.upload":              "Ke PDF-në ISEE? Tërhiqe këtu ose kliko për ta ngarkuar",
-		"isee.upload_desc":         "Përpunohet lokalisht, pastaj fshihet",
-		"isee.or":                  "ose vendos manualisht",
-		"loading.analyzing":        "Po analizojmë situatën tënde...",
-		"results.title":            "Bonuset e tua",
-		"results.subtitle":         "kursim i vlerësuar",
-		"results.no_results":       "Nuk u gjetën bonuse",
-		"results.no_results_desc":  "Me të dhënat që ke vendosur nuk rezultojnë bonuse të përshtatshme.",
-		"results.importo":          "Shuma",
-		"results.importo_reale":    "Shuma e vlerësuar për ty",
-		"results.requisiti":        "Kërkesat",
-		"results.come_fare":        "Si të bësh kërkesën",
-		"results.documenti":        "Dokumentet e nevojshme",
-		"results.faq":              "Pyetjet e shpeshta",
-		"results.fonti":            "Burimet dhe referencat",
-		"results.fonte_ist":        "Burimi:",
-		"results.fonte_edit":       "Ref. ligjore:",
-		"results.last_update":      "Përditësuar:",
-		"results.link_ufficiale":   "Faqja zyrtare",
-		"results.details":          "Shiko si ta kërkosh",
-		"results.calendar":         "Afatet",
-		"results.pdf":              "PDF",
-		"results.print":            "Printo",
-		"results.expand":           "Zgjero të gjitha",
-		"results.share":            "Ndaj",
-		"results.share_bonus":      "Ndaj",
-		"sim.title":                "Simuluesi ISEE",
-		"sim.label":                "Vendos një vlerë hipotetike ISEE për të zbuluar nëse do të kishe të drejtë për bonuse shtesë.",
-		"sim.button":               "Simulo",
-		"widget.note":              "Vlerësim i shpejtë: sa mund të marrësh?",
-		"widget.figli_label":       "Numri i fëmijëve",
-		"widget.isee_label":        "Fashë ISEE",
-		"widget.isee_under15":      "Nën 15.000 €",
-		"widget.isee_15_25":        "15.000 € - 25.000 €",
-		"widget.isee_25_40":        "25.000 € - 40.000 €",
-		"widget.isee_over40":       "Mbi 40.000 €",
-		"widget.result":            "Vlerësim orientues bazuar në të dhëna mesatare kombëtare",
-		"testimonials.title":       "Çfarë thonë familjet",
-		"coming.title":             "Së shpejti në BonusPerMe",
-		"coming.telegram":          "Njoftime Telegram",
-		"coming.whatsapp":          "Përditësime WhatsApp",
-		"coming.desc":              "Lër emailin tënd për t'u njoftuar kur të nisë",
-		"coming.email_placeholder": "Emaili yt...",
-		"coming.email_button":      "Njofto-më",
-		"coming.thanks":            "Faleminderit! Do të të njoftojmë kur të nisë.",
-		"caf.find":                 "Gjej një CAF pranë teje",
-		"footer.gdpr":              "Në përputhje me GDPR",
-		"footer.no_cookie":         "Pa Cookie",
-		"footer.no_tracking":       "Pa Gjurmim",
-		"footer.eu":                "Server në BE",
-		"footer.green":             "Hosting ekologjik",
-		"footer.open":              "Burim i hapur",
-		"footer.disclaimer":        "Ky shërbim ka karakter orientues dhe nuk zëvendëson këshillimin e një profesionisti, CAF ose patronato.",
-		"nav.home":            "Kryefaqja",
-		"nav.guide":           "Udhëzues",
-		"nav.caf":             "Për CAF",
-		"nav.contatti":        "Kontakte",
-		"results.collapse":    "Fshih detajet",
-		"a11y.skip":                "Kalo te përmbajtja kryesore",
-		"topbar.free":              "Shërbim falas",
-		"topbar.nodata":            "Asnjë e dhënë e ruajtur",
-		"topbar.updated":           "Të dhënat përditësuar më ...",
-		"how.subtitle":             "Nga verifikimi te CAF në 4 hapa.",
-		"how.step4.title":          "Çoje në CAF",
-		"how.step4.desc":           "Printo raportin me dokumentet dhe burimet zyrtare. Patronati bën pjesën tjetër.",
-		"privacy.db_title":         "Asnjë bazë të dhënash",
-		"privacy.db_desc":          "Të dhënat e tua ekzistojnë vetëm në sesionin e shfletuesit. Kur rifresko, zhduken.",
-		"privacy.track_title":      "Privacy first",
-		"privacy.track_desc":       "Përdorim vetëm Google Analytics anonim, dhe vetëm me pëlqimin tënd. Asnjë cookie profilimi.",
-		"privacy.code_title":       "Kod i hapur",
-		"privacy.code_desc":        "Kodi burimor është në GitHub nën licencën AGPL-3.0. Kushdo mund të verifikojë çfarë bën kodi.",
-		"cta.title":                "Zbulo sa mund të kursesh",
-		"cta.subtitle":             "Në 2 minuta di saktësisht cilat bonuse ke të drejtë dhe si t'i kërkosh.",
-		"isee_warn.has_isee":       "Kujto: vërtetimi ISEE është i vlefshëm deri më 31 dhjetor të vitit aktual.",
-		"isee_warn.no_isee":        "Nuk ke vendosur ISEE. Me një ISEE të vlefshëm mund të zhbllokosh deri në 12 bonuse shtesë.",
-		"share.title":              "Bonuset e mia — BonusPerMe",
-		"share.whatsapp":           "Ndaj në WhatsApp",
-		"share.copy":               "Kopjo përmbledhjen",
-		"share.copied":             "U kopjua!",
-		"share.native":             "Opsione të tjera...",
-		"turnstile.note":           "Kontroll sigurie",
-		"contact.title":            "Na kontakto",
-		"contact.subtitle":         "Ke pyetje ose sugjerime? Na shkruaj.",
-		"contact.nome":             "Emri",
-		"contact.email":            "Email",
-		"contact.oggetto":          "Subjekti",
-		"contact.messaggio":        "Mesazhi",
-		"contact.privacy":          "Kam lexuar dhe pranoj Politikën e Privatësisë",
-		"contact.submit":           "Dërgo mesazhin",
-		"contact.thanks":           "Faleminderit! Mesazhi yt u dërgua.",
-		"contact.opt_info":         "Informacione të përgjithshme",
-		"contact.opt_bug":          "Raportim gabimi",
-		"contact.opt_partner":      "Partneritet / CAF",
-		"contact.opt_other":        "Tjetër",
-	},
-}
+// T holds all translations: language code -> key -> value. It is filled
+// by Load from data/i18n; server messages are the "msg.<code>" keys.
+var T = map[string]map[string]string{}
 
 // GetAll returns translations for a language
 func GetAll(lang string) map[string]string {
@@ -0,0 +1,191 @@
+package istat
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Columns of the ISTAT file of the comuni (Elenco-comuni-italiani.csv),
+// matched by the start of their header.
+var colonneISTAT = map[string]string{
+	"codice":  "codice comune formato alfanumerico",
+	"nome":    "denominazione in italiano",
+	"sigla":   "sigla automobilistica",
+	"regione": "denominazione regione",
+}
+
+// Importa writes comuni.csv from the ISTAT file of the comuni and a list
+// of postal codes. The ISTAT file is read as published (semicolons,
+// Windows-1252 or UTF-8); the CAP list has the columns codice (ISTAT) and
+// cap, one or more codes per line, possibly over several lines per
+// comune, and may be nil. Provincia names come from province.csv.
+func Importa(elenco, cap io.Reader, w io.Writer) error {
+	caps := map[string][]int{}
+	if cap != nil {
+		if err := leggiCAP(cap, caps); err != nil {
+			return err
+		}
+	}
+
+	rows, err := leggiCSV(elenco)
+	if err != nil {
+		return fmt.Errorf("elenco ISTAT: %w", err)
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("elenco ISTAT: empty file")
+	}
+	idx := map[string]int{}
+	for i, h := range rows[0] {
+		h = strings.Join(strings.Fields(strings.ToLower(h)), " ")
+		for k, prefix := range colonneISTAT {
+			if _, ok := idx[k]; !ok && strings.HasPrefix(h, prefix) {
+				idx[k] = i
+			}
+		}
+	}
+	for k, prefix := range colonneISTAT {
+		if _, ok := idx[k]; !ok {
+			return fmt.Errorf("elenco ISTAT: no column %q", prefix)
+		}
+	}
+
+	var out []Comune
+	for n, row := range rows[1:] {
+		get := func(k string) string {
+			if i := idx[k]; i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		c := Comune{Codice: get("codice"), Nome: get("nome"), Sigla: get("sigla")}
+		if c.Codice == "" {
+			continue
+		}
+		p, ok := province[c.Sigla]
+		switch {
+		case !IsCodice(c.Codice):
+			return fmt.Errorf("elenco ISTAT:%d: invalid codice %q", n+2, c.Codice)
+		case !ok:
+			return fmt.Errorf("elenco ISTAT:%d: unknown sigla %q (add it to province.csv)", n+2, c.Sigla)
+		case p.Codice != c.Codice[:3]:
+			return fmt.Errorf("elenco ISTAT:%d: codice %s is not in provincia %s (%s)", n+2, c.Codice, c.Sigla, p.Codice)
+		case CodiceRegione(get("regione")) == "":
+			return fmt.Errorf("elenco ISTAT:%d: unknown regione %q", n+2, get("regione"))
+		}
+		c.Provincia = p.Nome
+		c.Regione = NomeRegione(get("regione"))
+		c.CAP = intervalli(caps[c.Codice])
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Codice < out[j].Codice })
+
+	fmt.Fprint(w, `# Comuni italiani — elenco ISTAT dei codici dei comuni, delle province e
+# delle regioni, con i CAP di Poste Italiane. Stesse colonne dell'elenco
+# ISTAT ridotte a quelle usate dal match; i CAP sono separati da spazi, un
+# intervallo "primo-ultimo" vale per i comuni multi-CAP. Generato con
+# go run ./cmd/istat: non modificare a mano.
+codice;comune;sigla;provincia;regione;cap
+`)
+	for _, c := range out {
+		if _, err := fmt.Fprintf(w, "%s;%s;%s;%s;%s;%s\n", c.Codice, c.Nome, c.Sigla, c.Provincia, c.Regione, strings.Join(c.CAP, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func leggiCAP(r io.Reader, caps map[string][]int) error {
+	rows, err := leggiCSV(r)
+	if err != nil {
+		return fmt.Errorf("CAP: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	codice, cap := -1, -1
+	for i, h := range rows[0] {
+		switch strings.ToLower(strings.TrimSpace(h)) {
+		case "codice", "codice istat", "istat":
+			codice = i
+		case "cap":
+			cap = i
+		}
+	}
+	if codice < 0 || cap < 0 {
+		return fmt.Errorf("CAP: the header needs the columns codice and cap")
+	}
+	for n, row := range rows[1:] {
+		if codice >= len(row) || cap >= len(row) {
+			continue
+		}
+		for _, s := range strings.FieldsFunc(row[cap], func(r rune) bool { return r == ' ' || r == ',' }) {
+			v, err := strconv.Atoi(s)
+			if err != nil || len(s) != 5 {
+				return fmt.Errorf("CAP:%d: invalid CAP %q", n+2, s)
+			}
+			k := strings.TrimSpace(row[codice])
+			caps[k] = append(caps[k], v)
+		}
+	}
+	return nil
+}
+
+// leggiCSV reads a semicolon separated file, converting Windows-1252 to
+// UTF-8 when the file is not valid UTF-8.
+func leggiCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	if !utf8.ValidString(s) {
+		s = daWindows1252(data)
+	}
+	s = strings.TrimPrefix(s, "\ufeff")
+	cr := csv.NewReader(strings.NewReader(s))
+	cr.Comma = ';'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	return cr.ReadAll()
+}
+
+// cp1252 are the characters of Windows-1252 that differ from Latin-1 and
+// can appear in names.
+var cp1252 = map[byte]rune{0x80: '€', 0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x96: '–', 0x97: '—'}
+
+func daWindows1252(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		if r, ok := cp1252[c]; ok {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(rune(c))
+		}
+	}
+	return sb.String()
+}
+
+// intervalli turns postal codes into the CAP column: single codes and
+// "primo-ultimo" runs of consecutive codes.
+func intervalli(caps []int) []string {
+	sort.Ints(caps)
+	var out []string
+	for i := 0; i < len(caps); {
+		j := i
+		for j+1 < len(caps) && caps[j+1] <= caps[j]+1 {
+			j++
+		}
+		if caps[j] == caps[i] {
+			out = append(out, fmt.Sprintf("%05d", caps[i]))
+		} else {
+			out = append(out, fmt.Sprintf("%05d-%05d", caps[i], caps[j]))
+		}
+		i = j + 1
+	}
+	return out
+}
@@ -0,0 +1,274 @@
+// Package istat is the offline copy of the ISTAT lists of Italian
+// provinces and comuni: codice comune, provincia and regione, with the
+// postal codes (CAP) of each comune. The lists are embedded in the binary
+// (province.csv and comuni.csv, in the column layout of the ISTAT files),
+// so matching a comune never needs the network. comuni.csv is generated
+// from the ISTAT file by Importa (go run ./cmd/istat); callers treat a
+// comune it does not list as unknown, not as invalid.
+package istat
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed province.csv
+var provinceCSV string
+
+//go:embed comuni.csv
+var comuniCSV string
+
+// Provincia is one provincia of the ISTAT list, or the unit that replaced
+// it (citta metropolitana, libero consorzio, ente di decentramento).
+type Provincia struct {
+	Codice  string `json:"codice"` // codice ISTAT, 3 cifre: prefisso dei codici dei suoi comuni
+	Sigla   string `json:"sigla"`
+	Nome    string `json:"nome"`
+	Regione string `json:"regione"`
+}
+
+// Comune is one comune of the ISTAT list.
+type Comune struct {
+	Codice    string   `json:"codice"` // codice ISTAT, 6 cifre
+	Nome      string   `json:"nome"`
+	Sigla     string   `json:"sigla"` // sigla automobilistica della provincia
+	Provincia string   `json:"provincia"`
+	Regione   string   `json:"regione"`
+	CAP       []string `json:"cap"` // CAP or "primo-ultimo" ranges
+	// Residenza is the code of the region or autonomous province the
+	// comune belongs to, the value of residenza for people living there.
+	Residenza string `json:"residenza"`
+}
+
+// capRange is an inclusive range of postal codes of one comune.
+type capRange struct {
+	lo, hi int
+	comune int // index in comuni
+}
+
+var (
+	comuni   []Comune
+	byCodice = map[string]int{}
+	byNome   = map[string][]int{}
+	province = map[string]Provincia{}
+	caps     []capRange
+)
+
+func init() {
+	if err := parseProvince(provinceCSV); err != nil {
+		panic("istat: " + err.Error())
+	}
+	if err := parse(comuniCSV); err != nil {
+		panic("istat: " + err.Error())
+	}
+}
+
+// righe calls fn with the columns of each data line of an embedded CSV,
+// skipping comments and the header.
+func righe(file, data string, cols int, fn func(n int, col []string) error) error {
+	header := true
+	for n, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if header {
+			header = false
+			continue
+		}
+		col := strings.Split(line, ";")
+		if len(col) != cols {
+			return fmt.Errorf("%s:%d: expected %d columns, got %d", file, n+1, cols, len(col))
+		}
+		if err := fn(n+1, col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseProvince(data string) error {
+	return righe("province.csv", data, 4, func(n int, col []string) error {
+		p := Provincia{Codice: col[0], Sigla: col[1], Nome: col[2], Regione: col[3]}
+		switch {
+		case len(p.Codice) != 3 || !digits(p.Codice):
+			return fmt.Errorf("province.csv:%d: invalid codice %q", n, p.Codice)
+		case len(p.Sigla) != 2:
+			return fmt.Errorf("province.csv:%d: invalid sigla %q", n, p.Sigla)
+		case CodiceRegione(p.Regione) == "":
+			return fmt.Errorf("province.csv:%d: unknown regione %q", n, p.Regione)
+		}
+		if _, dup := province[p.Sigla]; dup {
+			return fmt.Errorf("province.csv:%d: duplicate sigla %s", n, p.Sigla)
+		}
+		province[p.Sigla] = p
+		return nil
+	})
+}
+
+func parse(data string) error {
+	return righe("comuni.csv", data, 6, func(n int, col []string) error {
+		c := Comune{Codice: col[0], Nome: col[1], Sigla: col[2], Provincia: col[3], Regione: col[4], CAP: strings.Fields(col[5])}
+		if !IsCodice(c.Codice) {
+			return fmt.Errorf("comuni.csv:%d: invalid codice %q", n, c.Codice)
+		}
+		if p, ok := province[c.Sigla]; !ok || p.Codice != c.Codice[:3] {
+			return fmt.Errorf("comuni.csv:%d: codice %s is not in provincia %q", n, c.Codice, c.Sigla)
+		}
+		c.Residenza = CodiceRegione(c.Regione)
+		if r, ok := LookupRegione(c.Codice[:3]); ok && r.Padre == c.Residenza {
+			c.Residenza = r.Codice
+		}
+		if c.Residenza == "" {
+			return fmt.Errorf("comuni.csv:%d: unknown regione %q", n, c.Regione)
+		}
+		if _, dup := byCodice[c.Codice]; dup {
+			return fmt.Errorf("comuni.csv:%d: duplicate codice %s", n, c.Codice)
+		}
+		i := len(comuni)
+		for _, cap := range c.CAP {
+			lo, hi, ok := strings.Cut(cap, "-")
+			if !ok {
+				hi = lo
+			}
+			l, errLo := strconv.Atoi(lo)
+			h, errHi := strconv.Atoi(hi)
+			if errLo != nil || errHi != nil || len(lo) != 5 || len(hi) != 5 || l > h {
+				return fmt.Errorf("comuni.csv:%d: invalid CAP %q", n, cap)
+			}
+			caps = append(caps, capRange{l, h, i})
+		}
+		comuni = append(comuni, c)
+		byCodice[c.Codice] = i
+		byNome[norm(c.Nome)] = append(byNome[norm(c.Nome)], i)
+		return nil
+	})
+}
+
+// IsCodice reports whether s has the form of an ISTAT codice comune.
+func IsCodice(s string) bool {
+	return len(s) == 6 && digits(s)
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Lookup returns the comune with the given ISTAT code.
+func Lookup(codice string) (Comune, bool) {
+	i, ok := byCodice[codice]
+	if !ok {
+		return Comune{}, false
+	}
+	return comuni[i], true
+}
+
+// Find resolves what a user typed as their comune: an ISTAT code, a name
+// ("Reggio nell'Emilia", case and accents ignored) or, for names shared by
+// comuni of different provinces, a name with the sigla ("Castro (LE)").
+func Find(s string) (Comune, bool) {
+	s = strings.TrimSpace(s)
+	if c, ok := Lookup(s); ok {
+		return c, true
+	}
+	nome, sigla := s, ""
+	if open := strings.LastIndex(s, "("); open > 0 && strings.HasSuffix(s, ")") {
+		nome, sigla = s[:open], strings.ToUpper(strings.TrimSpace(s[open+1:len(s)-1]))
+	}
+	var found []int
+	for _, i := range byNome[norm(nome)] {
+		if sigla == "" || comuni[i].Sigla == sigla {
+			found = append(found, i)
+		}
+	}
+	if len(found) != 1 {
+		return Comune{}, false
+	}
+	return comuni[found[0]], true
+}
+
+// ByCAP returns the comuni served by a postal code; a CAP shared by small
+// comuni returns all of them.
+func ByCAP(cap string) []Comune {
+	if len(cap) != 5 || !digits(cap) {
+		return nil
+	}
+	n, _ := strconv.Atoi(cap)
+	var out []Comune
+	for _, r := range caps {
+		if n >= r.lo && n <= r.hi {
+			out = append(out, comuni[r.comune])
+		}
+	}
+	return out
+}
+
+// Search returns up to limit comuni for an autocomplete: by CAP when q is
+// five digits, otherwise names starting with q and then names containing
+// it, each group in alphabetical order.
+func Search(q string, limit int) []Comune {
+	q = strings.TrimSpace(q)
+	if len(q) == 5 && digits(q) {
+		out := ByCAP(q)
+		if len(out) > limit {
+			out = out[:limit]
+		}
+		return out
+	}
+	key := norm(q)
+	if key == "" {
+		return nil
+	}
+	var prefix, inner []Comune
+	for _, c := range comuni {
+		switch nome := norm(c.Nome); {
+		case strings.HasPrefix(nome, key):
+			prefix = append(prefix, c)
+		case strings.Contains(nome, key):
+			inner = append(inner, c)
+		}
+	}
+	for _, list := range [][]Comune{prefix, inner} {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Nome < list[j].Nome })
+	}
+	out := append(prefix, inner...)
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// HasProvincia reports whether sigla is the sigla of a provincia.
+func HasProvincia(sigla string) bool {
+	_, ok := province[sigla]
+	return ok
+}
+
+// LookupProvincia returns the provincia with the given sigla.
+func LookupProvincia(sigla string) (Provincia, bool) {
+	p, ok := province[sigla]
+	return p, ok
+}
+
+// Len returns the number of comuni in the dataset.
+func Len() int {
+	return len(comuni)
+}
+
+var accents = strings.NewReplacer(
+	"à", "a", "á", "a", "è", "e", "é", "e", "ì", "i", "í", "i",
+	"ò", "o", "ó", "o", "ù", "u", "ú", "u", "’", "'",
+)
+
+// norm folds a comune name for comparison.
+func norm(s string) string {
+	return strings.Join(strings.Fields(accents.Replace(strings.ToLower(s))), " ")
+}
@@ -0,0 +1,153 @@
+package istat
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	if Len() == 0 || Len() != len(byCodice) {
+		t.Fatalf("%d comuni, %d codes", Len(), len(byCodice))
+	}
+	for _, c := range comuni {
+		p, ok := province[c.Sigla]
+		if !ok || p.Codice != c.Codice[:3] || p.Regione != c.Regione {
+			t.Errorf("%s %s: provincia %+v", c.Codice, c.Nome, p)
+		}
+		if _, ok := LookupRegione(c.Residenza); !ok {
+			t.Errorf("%s %s: residenza %q", c.Codice, c.Nome, c.Residenza)
+		}
+	}
+
+	// Rows are checked before they are added
+	header := "codice;comune;sigla;provincia;regione;cap\n"
+	for _, tc := range []struct{ row, err string }{
+		{"58091;Roma;RM;Roma;Lazio;00118", `invalid codice "58091"`},
+		{"058091;Roma;MI;Milano;Lombardia;00118", `codice 058091 is not in provincia "MI"`},
+		{"058091;Roma;RM;Roma;Lazio;00118", "duplicate codice 058091"},
+		{"058999;Nuovo;RM;Roma;Lazio;0011", `invalid CAP "0011"`},
+		{"058999;Nuovo;RM;Roma", "expected 6 columns, got 4"},
+	} {
+		if err := parse(header + tc.row); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: got %v, want %q", tc.row, err, tc.err)
+		}
+	}
+	if Len() != len(byCodice) {
+		t.Errorf("rejected rows were added")
+	}
+}
+
+func TestFind(t *testing.T) {
+	for _, tc := range []struct {
+		in, codice string
+	}{
+		{"058091", "058091"},
+		{"Roma", "058091"},
+		{"  reggio nell'emilia ", "035033"},
+		{"Reggio nell’Emilia", "035033"},
+		{"Roma (rm)", "058091"},
+		{"Roma (MI)", ""},
+		{"Atlantide", ""},
+		{"999999", ""},
+	} {
+		c, ok := Find(tc.in)
+		if ok != (tc.codice != "") || c.Codice != tc.codice {
+			t.Errorf("Find(%q) = %s, %v; want %q", tc.in, c.Codice, ok, tc.codice)
+		}
+	}
+	if c, ok := Lookup("021008"); !ok || c.Residenza != "021" || c.Regione != "Trentino-Alto Adige" {
+		t.Errorf("Bolzano: %+v", c)
+	}
+}
+
+func TestByCAP(t *testing.T) {
+	for _, tc := range []struct {
+		cap  string
+		nomi []string
+	}{
+		{"00118", []string{"Roma"}},
+		{"00144", []string{"Roma"}},
+		{"00199", []string{"Roma"}},
+		{"00117", nil},
+		{"39100", []string{"Bolzano"}},
+		{"0014", nil},
+		{"00l44", nil},
+	} {
+		var nomi []string
+		for _, c := range ByCAP(tc.cap) {
+			nomi = append(nomi, c.Nome)
+		}
+		if !reflect.DeepEqual(nomi, tc.nomi) {
+			t.Errorf("ByCAP(%q) = %v, want %v", tc.cap, nomi, tc.nomi)
+		}
+	}
+	if got := Search("00144", 5); len(got) != 1 || got[0].Codice != "058091" {
+		t.Errorf("Search by CAP: %v", got)
+	}
+	if got := Search("reggio", 5); len(got) == 0 || got[0].Nome != "Reggio nell'Emilia" {
+		t.Errorf("Search by name: %v", got)
+	}
+}
+
+func TestProvince(t *testing.T) {
+	if !HasProvincia("RM") || HasProvincia("XX") || HasProvincia("rm") {
+		t.Errorf("HasProvincia")
+	}
+	p, ok := LookupProvincia("BZ")
+	if !ok || p.Codice != "021" || p.Regione != "Trentino-Alto Adige" {
+		t.Errorf("LookupProvincia(BZ) = %+v, %v", p, ok)
+	}
+}
+
+func TestImporta(t *testing.T) {
+	// As published: Windows-1252, semicolons, long headers
+	elenco := []byte("Codice Regione;Codice Comune formato alfanumerico;Denominazione in italiano;Denominazione regione;Sigla automobilistica\r\n" +
+		"01;001272;Torino;Piemonte;TO\r\n" +
+		"12;058091;Roma;Lazio;RM\r\n" +
+		"04;021008;Bolzano/Bozen;Trentino-Alto Adige/S\xfcdtirol;BZ\r\n")
+	cap := "codice;cap\n001272;10121 10122,10123\n001272;10125\n058091;00118\n"
+	var out bytes.Buffer
+	if err := Importa(bytes.NewReader(elenco), strings.NewReader(cap), &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"001272;Torino;TO;Torino;Piemonte;10121-10123 10125\n",
+		"021008;Bolzano/Bozen;BZ;Bolzano;Trentino-Alto Adige;\n",
+		"058091;Roma;RM;Roma;Lazio;00118\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if i, j := strings.Index(out.String(), "001272"), strings.Index(out.String(), "021008"); i > j {
+		t.Errorf("rows not sorted by codice")
+	}
+
+	for _, tc := range []struct{ elenco, err string }{
+		{"Denominazione in italiano;Sigla automobilistica\n", "no column"},
+		{"Codice Comune formato alfanumerico;Denominazione in italiano;Denominazione regione;Sigla automobilistica\n001272;Torino;Piemonte;XX\n", `unknown sigla "XX"`},
+		{"Codice Comune formato alfanumerico;Denominazione in italiano;Denominazione regione;Sigla automobilistica\n001272;Torino;Piemonte;MI\n", "is not in provincia MI"},
+	} {
+		if err := Importa(strings.NewReader(tc.elenco), nil, &out); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("got %v, want %q", err, tc.err)
+		}
+	}
+}
+
+func TestIntervalli(t *testing.T) {
+	for _, tc := range []struct {
+		caps []int
+		want []string
+	}{
+		{nil, nil},
+		{[]int{118}, []string{"00118"}},
+		{[]int{10125, 10121, 10123, 10122}, []string{"10121-10123", "10125"}},
+		{[]int{38121, 38121, 38122}, []string{"38121-38122"}},
+	} {
+		if got := intervalli(tc.caps); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("intervalli(%v) = %v, want %v", tc.caps, got, tc.want)
+		}
+	}
+}
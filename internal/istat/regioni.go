@@ -0,0 +1,111 @@
+package istat
+
+import "strings"
+
+// Regione is a territory with regional powers: one of the 20 regions or
+// one of the two autonomous provinces of Trentino-Alto Adige, which run
+// their own benefits. Codice is the ISTAT code, the key used for
+// residenza in profiles and for regioni in the catalog: "01".."20" for the
+// regions, the province codes "021" (Bolzano) and "022" (Trento) for the
+// autonomous provinces.
+type Regione struct {
+	Codice string `json:"codice"`
+	Nome   string `json:"nome"`
+	// Padre is the region of an autonomous province, "" for a region.
+	Padre string `json:"padre,omitempty"`
+	alias []string
+}
+
+var regioni = []Regione{
+	{Codice: "01", Nome: "Piemonte"},
+	{Codice: "02", Nome: "Valle d'Aosta", alias: []string{"Valle d'Aosta/Vallée d'Aoste", "Vallée d'Aoste", "Valle Aosta"}},
+	{Codice: "03", Nome: "Lombardia"},
+	{Codice: "04", Nome: "Trentino-Alto Adige", alias: []string{"Trentino-Alto Adige/Südtirol"}},
+	{Codice: "05", Nome: "Veneto"},
+	{Codice: "06", Nome: "Friuli-Venezia Giulia", alias: []string{"FVG"}},
+	{Codice: "07", Nome: "Liguria"},
+	{Codice: "08", Nome: "Emilia-Romagna"},
+	{Codice: "09", Nome: "Toscana"},
+	{Codice: "10", Nome: "Umbria"},
+	{Codice: "11", Nome: "Marche"},
+	{Codice: "12", Nome: "Lazio"},
+	{Codice: "13", Nome: "Abruzzo"},
+	{Codice: "14", Nome: "Molise"},
+	{Codice: "15", Nome: "Campania"},
+	{Codice: "16", Nome: "Puglia"},
+	{Codice: "17", Nome: "Basilicata"},
+	{Codice: "18", Nome: "Calabria"},
+	{Codice: "19", Nome: "Sicilia"},
+	{Codice: "20", Nome: "Sardegna"},
+	{Codice: "021", Nome: "Provincia autonoma di Bolzano", Padre: "04",
+		alias: []string{"Provincia autonoma di Bolzano/Bozen", "Bolzano", "Bozen", "Alto Adige", "Südtirol"}},
+	{Codice: "022", Nome: "Provincia autonoma di Trento", Padre: "04",
+		alias: []string{"Trento", "Trentino"}},
+}
+
+// regioneKey maps codes, names and aliases (folded) to an index in regioni.
+var regioneKey = func() map[string]int {
+	m := make(map[string]int)
+	for i, r := range regioni {
+		m[r.Codice] = i
+		m[foldRegione(r.Nome)] = i
+		for _, a := range r.alias {
+			m[foldRegione(a)] = i
+		}
+	}
+	return m
+}()
+
+// foldRegione ignores case, accents, dashes and slashes, so that "Friuli
+// Venezia Giulia" and "Friuli-Venezia Giulia" are the same region.
+func foldRegione(s string) string {
+	s = strings.NewReplacer("-", " ", "/", " ", "ü", "u").Replace(norm(s))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Regioni returns the regions followed by the autonomous provinces.
+func Regioni() []Regione {
+	out := make([]Regione, len(regioni))
+	copy(out, regioni)
+	return out
+}
+
+// LookupRegione resolves an ISTAT code, a name or an alias ("Friuli
+// Venezia Giulia", "Alto Adige") to its Regione.
+func LookupRegione(s string) (Regione, bool) {
+	i, ok := regioneKey[foldRegione(s)]
+	if !ok {
+		return Regione{}, false
+	}
+	return regioni[i], true
+}
+
+// CodiceRegione returns the canonical code of a region or autonomous
+// province given by code, name or alias, "" if unknown.
+func CodiceRegione(s string) string {
+	r, _ := LookupRegione(s)
+	return r.Codice
+}
+
+// NomeRegione returns the display name of a code, name or alias, or s
+// itself when it is unknown.
+func NomeRegione(s string) string {
+	if r, ok := LookupRegione(s); ok {
+		return r.Nome
+	}
+	return s
+}
+
+// Copre reports whether a benefit of territory key is open to residents
+// of territory t: the same territory, or the region of an autonomous
+// province (a Trentino-Alto Adige benefit covers Trento and Bolzano).
+func Copre(key, t string) bool {
+	if key == "" || t == "" {
+		return false
+	}
+	if key == t {
+		return true
+	}
+	r, ok := LookupRegione(t)
+	return ok && r.Padre == key
+}
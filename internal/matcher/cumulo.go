@@ -0,0 +1,213 @@
+package matcher
+
+import (
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/models"
+	"sort"
+	"strings"
+)
+
+// maxEsatta is the largest group of mutually incompatible bonuses whose
+// best combination is searched exhaustively; larger groups are resolved
+// greedily by value. Catalog groups are pairs and triples.
+const maxEsatta = 16
+
+// incompatibilita returns, for each bonus ID of the list, the IDs it
+// cannot be combined with and the reason, from the relations declared on
+// either side.
+func incompatibilita(bonus []models.Bonus) map[string]map[string]string {
+	g := make(map[string]map[string]string)
+	add := func(a, b, motivo string) {
+		if g[a] == nil {
+			g[a] = make(map[string]string)
+		}
+		if _, ok := g[a][b]; !ok {
+			g[a][b] = motivo
+		}
+	}
+	for _, b := range bonus {
+		for _, r := range b.Incompatibili {
+			add(b.ID, r.Bonus, r.Motivo)
+			add(r.Bonus, b.ID, r.Motivo)
+		}
+	}
+	return g
+}
+
+// scegliCombinazione keeps, among the active bonuses of matched, the
+// combination with no incompatible pair worth the most per year (then the
+// highest total compatibility, then the most bonuses), and returns the
+// other active bonuses it conflicts with as alternatives, most valuable
+// first. Expired bonuses are not counted anyway and are always kept.
+func scegliCombinazione(matched []models.Bonus) ([]models.Bonus, []models.Alternativa) {
+	g := incompatibilita(matched)
+	if len(g) == 0 {
+		return matched, nil
+	}
+
+	// Groups of active bonuses connected by incompatibilities
+	idx := make(map[string]int)
+	for i, b := range matched {
+		if !b.Scaduto {
+			idx[b.ID] = i
+		}
+	}
+	escluso := make([]bool, len(matched))
+	visto := make([]bool, len(matched))
+	for i, b := range matched {
+		if b.Scaduto || visto[i] || len(g[b.ID]) == 0 {
+			continue
+		}
+		var gruppo []int
+		coda := []int{i}
+		visto[i] = true
+		for len(coda) > 0 {
+			n := coda[0]
+			coda = coda[1:]
+			gruppo = append(gruppo, n)
+			for id := range g[matched[n].ID] {
+				if j, ok := idx[id]; ok && !visto[j] {
+					visto[j] = true
+					coda = append(coda, j)
+				}
+			}
+		}
+		sort.Ints(gruppo)
+		for _, n := range scartati(matched, gruppo, g) {
+			escluso[n] = true
+		}
+	}
+
+	var kept []models.Bonus
+	var alt []models.Alternativa
+	for i, b := range matched {
+		if !escluso[i] {
+			kept = append(kept, b)
+			continue
+		}
+		a := models.Alternativa{Bonus: b}
+		nomi := map[string]string{b.ID: b.Nome}
+		for _, k := range matched {
+			if motivo, ok := g[b.ID][k.ID]; ok && !k.Scaduto && !escluso[idx[k.ID]] {
+				if a.Motivo == "" {
+					a.Motivo = motivo
+				}
+				a.Scelti = append(a.Scelti, k.ID)
+				nomi[k.ID] = k.Nome
+			}
+		}
+		m := MessaggioAlternativa(a, func(id string) string { return nomi[id] })
+		a.Messaggio, a.Codice, a.Parametri = m.Text(i18n.Default), m.Code, m.Params
+		alt = append(alt, a)
+	}
+	sort.SliceStable(alt, func(i, j int) bool { return annuo(alt[i].Bonus) > annuo(alt[j].Bonus) })
+	return kept, alt
+}
+
+// MessaggioAlternativa is the message of an alternative, with the names
+// nome gives to its bonus IDs. The IDs are in the parameters too
+// (bonus_id, scelti_id), so the message can be rendered again with the
+// names of another language.
+func MessaggioAlternativa(a models.Alternativa, nome func(id string) string) i18n.Message {
+	nomi := make([]string, len(a.Scelti))
+	for i, id := range a.Scelti {
+		nomi[i] = nome(id)
+	}
+	return i18n.M("alternativa.non_cumulabile", "bonus", nome(a.Bonus.ID), "scelti", strings.Join(nomi, ", "),
+		"bonus_id", a.Bonus.ID, "scelti_id", strings.Join(a.Scelti, ","))
+}
+
+// MotivoAlternativa is the reason an alternative cannot be combined with
+// the first bonus it gives way to, as declared on either side: the
+// bonuses are the localized ones, so the reason is in their language.
+func MotivoAlternativa(a models.Alternativa, scelto models.Bonus) string {
+	for _, r := range a.Bonus.Incompatibili {
+		if r.Bonus == scelto.ID {
+			return r.Motivo
+		}
+	}
+	for _, r := range scelto.Incompatibili {
+		if r.Bonus == a.Bonus.ID {
+			return r.Motivo
+		}
+	}
+	return a.Motivo
+}
+
+// scartati returns the members of a group left out of its best
+// combination.
+func scartati(matched []models.Bonus, gruppo []int, g map[string]map[string]string) []int {
+	conflitto := func(a, b int) bool {
+		_, ok := g[matched[a].ID][matched[b].ID]
+		return ok
+	}
+	type punteggio struct {
+		valore float64
+		compat int
+		n      int
+	}
+	meglio := func(a, b punteggio) bool {
+		if a.valore != b.valore {
+			return a.valore > b.valore
+		}
+		if a.compat != b.compat {
+			return a.compat > b.compat
+		}
+		return a.n > b.n
+	}
+
+	var scelti []bool
+	if len(gruppo) <= maxEsatta {
+		best, bestMask := punteggio{valore: -1}, 0
+	subsets:
+		for mask := 0; mask < 1<<len(gruppo); mask++ {
+			var p punteggio
+			for i, n := range gruppo {
+				if mask&(1<<i) == 0 {
+					continue
+				}
+				for j := i + 1; j < len(gruppo); j++ {
+					if mask&(1<<j) != 0 && conflitto(n, gruppo[j]) {
+						continue subsets
+					}
+				}
+				p.valore += annuo(matched[n])
+				p.compat += matched[n].Compatibilita
+				p.n++
+			}
+			if meglio(p, best) {
+				best, bestMask = p, mask
+			}
+		}
+		for i := range gruppo {
+			scelti = append(scelti, bestMask&(1<<i) != 0)
+		}
+	} else {
+		ordine := make([]int, len(gruppo))
+		for i := range ordine {
+			ordine[i] = i
+		}
+		sort.SliceStable(ordine, func(a, b int) bool {
+			x, y := matched[gruppo[ordine[a]]], matched[gruppo[ordine[b]]]
+			return meglio(punteggio{annuo(x), x.Compatibilita, 1}, punteggio{annuo(y), y.Compatibilita, 1})
+		})
+		scelti = make([]bool, len(gruppo))
+	greedy:
+		for _, i := range ordine {
+			for j, ok := range scelti {
+				if ok && conflitto(gruppo[i], gruppo[j]) {
+					continue greedy
+				}
+			}
+			scelti[i] = true
+		}
+	}
+
+	var out []int
+	for i, n := range gruppo {
+		if !scelti[i] {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+package matcher
+
+import (
+	"bonusperme/internal/eligibility"
+	"bonusperme/internal/istat"
+	"bonusperme/internal/models"
+	"bonusperme/internal/nucleo"
+	"math"
+	"sort"
+	"time"
+)
+
+// ProssimeDomande ranks the profile fields not in risposte by how much
+// their answer can change the match at asOf: for each field every answer
+// that crosses a rule of the catalog (see eligibility.Candidati) is
+// matched like a what-if scenario, and the field reports the bonuses
+// gained, lost, or changed in value or compatibility by some answer, and
+// the largest change of the yearly estimate. Residenza is tried on every
+// region and autonomous province, comune on the comuni with local bonuses.
+// Fields no answer can change are left out; the most influential come
+// first, by number of bonuses and then by euros.
+func ProssimeDomande(profile models.UserProfile, risposte map[string]bool, asOf time.Time, bonusList ...[]models.Bonus) []models.Domanda {
+	var allBonus []models.Bonus
+	if len(bonusList) > 0 && len(bonusList[0]) > 0 {
+		allBonus = bonusList[0]
+	} else {
+		allBonus = GetAllBonusWithRegional()
+	}
+	risposto := make(map[string]bool, len(risposte))
+	for k, v := range risposte {
+		risposto[k] = v
+	}
+	// Household members decide the counters
+	if len(profile.Nucleo) > 0 {
+		for _, k := range nucleo.Contatori {
+			risposto[k] = true
+		}
+	}
+
+	candidati := eligibility.Candidati(allBonus)
+	candidati["residenza"] = residenze()
+	candidati["comune"] = comuniConBonus(allBonus, profile.Residenza)
+
+	campi := make([]string, 0, len(candidati))
+	for campo := range candidati {
+		if profileFields[campo] && !risposto[campo] {
+			campi = append(campi, campo)
+		}
+	}
+	sort.Strings(campi)
+
+	base := MatchBonusAt(profile, asOf, allBonus)
+	var out []models.Domanda
+	for _, campo := range campi {
+		cambiati := make(map[string]bool)
+		euro := 0.0
+		for _, v := range candidati[campo] {
+			p, err := ApplyOverrides(profile, map[string]interface{}{campo: v})
+			if err != nil {
+				continue
+			}
+			sim := MatchBonusAt(p, asOf, allBonus)
+			for id := range bonusCambiati(base, sim) {
+				cambiati[id] = true
+			}
+			euro = math.Max(euro, math.Abs(sim.RisparmioStimatoEuro-base.RisparmioStimatoEuro))
+		}
+		if len(cambiati) == 0 {
+			continue
+		}
+		d := models.Domanda{
+			Campo:     campo,
+			Etichetta: eligibility.Fields[campo].Label,
+			Bonus:     len(cambiati),
+			Euro:      math.Round(euro*100) / 100,
+		}
+		for id := range cambiati {
+			d.BonusID = append(d.BonusID, id)
+		}
+		sort.Strings(d.BonusID)
+		out = append(out, d)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Bonus != out[j].Bonus {
+			return out[i].Bonus > out[j].Bonus
+		}
+		return out[i].Euro > out[j].Euro
+	})
+	return out
+}
+
+// bonusCambiati returns the IDs of the active bonuses gained, lost or
+// changed in yearly value or compatibility from base to sim.
+func bonusCambiati(base, sim models.MatchResult) map[string]bool {
+	out := make(map[string]bool)
+	d := DiffResults(base, sim)
+	for _, l := range [][]models.BonusDiff{d.Guadagnati, d.Persi, d.Variati} {
+		for _, b := range l {
+			out[b.BonusID] = true
+		}
+	}
+	prima, dopo := attiviPerID(base), attiviPerID(sim)
+	for id, b := range dopo {
+		if a, ok := prima[id]; ok && a.Compatibilita != b.Compatibilita {
+			out[id] = true
+		}
+	}
+	return out
+}
+
+// residenze are the values residenza can take: the regions and the
+// autonomous provinces, without Trentino-Alto Adige which is split in two.
+func residenze() []interface{} {
+	padri := make(map[string]bool)
+	for _, r := range istat.Regioni() {
+		padri[r.Padre] = true
+	}
+	var out []interface{}
+	for _, r := range istat.Regioni() {
+		if !padri[r.Codice] {
+			out = append(out, r.Codice)
+		}
+	}
+	return out
+}
+
+// comuniConBonus are the comuni named by local bonuses, in the declared
+// residenza when there is one.
+func comuniConBonus(bonus []models.Bonus, residenza string) []interface{} {
+	regione := istat.CodiceRegione(residenza)
+	visti := make(map[string]bool)
+	var out []interface{}
+	for _, b := range bonus {
+		for _, codice := range b.Comuni {
+			c, ok := istat.Lookup(codice)
+			if !ok || visti[c.Codice] || (regione != "" && !istat.Copre(regione, c.Residenza)) {
+				continue
+			}
+			visti[c.Codice] = true
+			out = append(out, c.Codice)
+		}
+	}
+	return out
+}
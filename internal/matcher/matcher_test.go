@@ -1,12 +1,26 @@
 package matcher
 
 import (
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/clock"
+	"bonusperme/internal/eligibility"
 	"bonusperme/internal/models"
+	"bonusperme/internal/nucleo"
+	"fmt"
 	"math"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 )
 
+func init() {
+	if err := catalog.Load("../../data/catalog"); err != nil {
+		panic(err)
+	}
+}
+
 func TestMatchBonus_FamigliaConFigli(t *testing.T) {
 	profile := models.UserProfile{
 		Eta: 35, NumeroFigli: 2, FigliMinorenni: 2, FigliUnder3: 1,
@@ -110,6 +124,400 @@ func TestMatchBonus_SenzaRegione(t *testing.T) {
 	}
 }
 
+func TestMatchBonus_LivelliTerritoriali(t *testing.T) {
+	idoneo := &models.Idoneita{Punteggi: []models.Fascia{{Punteggio: 50}}}
+	bonus := []models.Bonus{
+		{ID: "nazionale", Idoneita: idoneo},
+		{ID: "lazio", RegioniApplicabili: []string{"Lazio"}, Idoneita: idoneo},
+		{ID: "provincia-roma", Province: []string{"RM"}, Idoneita: idoneo},
+		{ID: "comune-roma", Comuni: []string{"058091"}, Idoneita: idoneo},
+		{ID: "comune-milano", Comuni: []string{"015146"}, Idoneita: idoneo},
+	}
+	ids := func(p models.UserProfile) string {
+		var out []string
+		for _, b := range MatchBonus(p, bonus).Bonus {
+			out = append(out, b.ID)
+		}
+		sort.Strings(out)
+		return strings.Join(out, ",")
+	}
+	for _, tc := range []struct {
+		p    models.UserProfile
+		want string
+	}{
+		{models.UserProfile{Comune: "058091"}, "comune-roma,lazio,nazionale,provincia-roma"},
+		{models.UserProfile{Comune: "Fiumicino", Residenza: "Lazio"}, "lazio,nazionale,provincia-roma"},
+		{models.UserProfile{Residenza: "Lazio"}, "lazio,nazionale"},
+		{models.UserProfile{}, "nazionale"},
+	} {
+		if got := ids(tc.p); got != tc.want {
+			t.Errorf("%+v: got %s, want %s", tc.p, got, tc.want)
+		}
+	}
+
+	// The catalog has municipal bonuses, explained as a residence requirement
+	p := models.UserProfile{Eta: 35, NumeroFigli: 1, FigliMinorenni: 1, ISEE: 15000, Comune: "015146"}
+	found := false
+	for _, b := range MatchBonus(p).Bonus {
+		if b.ID == "refezione-scolastica-milano" {
+			found = len(b.Spiegazione) > 0 && b.Spiegazione[0].Descrizione == "Residenza nel comune di Milano"
+		}
+		if b.ID == "refezione-scolastica-roma" {
+			t.Error("Milano non dovrebbe vedere i bonus di Roma")
+		}
+	}
+	if !found {
+		t.Error("Milano dovrebbe vedere la mensa scolastica agevolata, con il requisito di residenza")
+	}
+}
+
+func TestMatchBonus_CodiciRegione(t *testing.T) {
+	ids := func(p models.UserProfile) map[string]bool {
+		out := map[string]bool{}
+		for _, b := range MatchBonus(p).Bonus {
+			out[b.ID] = true
+		}
+		return out
+	}
+	famiglia := models.UserProfile{Eta: 35, NumeroFigli: 1, FigliMinorenni: 1, ISEE: 15000}
+
+	// Code, display name and the spelling without dashes are the same region
+	for _, r := range []string{"06", "Friuli-Venezia Giulia", "Friuli Venezia Giulia"} {
+		p := famiglia
+		p.Residenza = r
+		if !ids(p)["carta-famiglia-fvg"] {
+			t.Errorf("residenza %q: Carta Famiglia FVG mancante", r)
+		}
+	}
+
+	// Trento and Bolzano are separate autonomous provinces
+	for _, tc := range []struct {
+		p               models.UserProfile
+		trento, bolzano bool
+	}{
+		{models.UserProfile{Residenza: "022"}, true, false},
+		{models.UserProfile{Residenza: "Alto Adige"}, false, true},
+		{models.UserProfile{Comune: "Bolzano"}, false, true},
+		{models.UserProfile{Residenza: "Trentino-Alto Adige", Comune: "022205"}, true, false},
+	} {
+		p := famiglia
+		p.Residenza, p.Comune = tc.p.Residenza, tc.p.Comune
+		got := ids(p)
+		if got["assegno-unico-trento"] != tc.trento || got["familiengeld-bolzano"] != tc.bolzano {
+			t.Errorf("%q/%q: trento=%v bolzano=%v", p.Residenza, p.Comune, got["assegno-unico-trento"], got["familiengeld-bolzano"])
+		}
+	}
+}
+
+func TestMatchBonus_Nucleo(t *testing.T) {
+	p := models.UserProfile{Eta: 38, ISEE: 14000, Occupazione: "dipendente", Nucleo: []models.Componente{
+		{Relazione: "figlio", DataNascita: "2025-09-10"},
+		{Relazione: "figlio", DataNascita: "2015-04-02", Disabilita: "media"},
+		{Relazione: "coniuge", DataNascita: "1988-11-20", Occupazione: "autonomo"},
+		{Relazione: "genitore", DataNascita: "1950-06-30"},
+	}}
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// The members match like the same household typed as counters
+	counters := nucleo.Deriva(p, at)
+	counters.Nucleo = nil
+	ids := func(r models.MatchResult) string {
+		var out []string
+		for _, b := range r.Bonus {
+			out = append(out, b.ID)
+		}
+		sort.Strings(out)
+		return strings.Join(out, ",")
+	}
+	if a, b := ids(MatchBonusAt(p, at)), ids(MatchBonusAt(counters, at)); a != b || !strings.Contains(a, "bonus-nido") {
+		t.Errorf("nucleo: %s\ncounters: %s", a, b)
+	}
+}
+
+func TestMatchBonus_Cumulo(t *testing.T) {
+	// Disoccupato with a minor child: ADI and SFL both match but exclude
+	// each other; the three home deductions exclude one another too.
+	p := models.UserProfile{Eta: 40, ISEE: 5000, Occupazione: "disoccupato", NumeroFigli: 1,
+		FigliMinorenni: 1, RistrutturazCasa: true, Residenza: "Lombardia"}
+	result := MatchBonus(p)
+
+	kept := make(map[string]bool)
+	var totale float64
+	for _, b := range result.Bonus {
+		kept[b.ID] = true
+		if v := b.ValoreStimato; v != nil && !b.Scaduto {
+			totale += v.Annuo()
+		}
+	}
+	if !kept["adi"] || kept["sfl"] {
+		t.Errorf("want adi kept and sfl as alternative, kept %v", kept)
+	}
+	casa := 0
+	for _, id := range []string{"bonus-ristrutturazione", "ecobonus", "sismabonus"} {
+		if kept[id] {
+			casa++
+		}
+	}
+	if casa != 1 {
+		t.Errorf("want one home deduction kept, got %d", casa)
+	}
+
+	alt := make(map[string]models.Alternativa)
+	for _, a := range result.Alternative {
+		if kept[a.Bonus.ID] {
+			t.Errorf("%s both kept and alternative", a.Bonus.ID)
+		}
+		if len(a.Scelti) == 0 || a.Motivo == "" || a.Codice != "alternativa.non_cumulabile" {
+			t.Errorf("alternative %s: %+v", a.Bonus.ID, a)
+		}
+		alt[a.Bonus.ID] = a
+	}
+	if a, ok := alt["sfl"]; !ok || a.Scelti[0] != "adi" || !strings.Contains(fmt.Sprint(a.Parametri["scelti"]), "Assegno di Inclusione") {
+		t.Errorf("sfl alternative: %+v", a)
+	}
+	if len(result.Alternative) != 3 {
+		t.Errorf("want 3 alternatives, got %d", len(result.Alternative))
+	}
+	// Totals count the kept combination only
+	if math.Abs(result.RisparmioStimatoEuro-totale) > 0.01 {
+		t.Errorf("risparmio %.2f, kept bonuses add up to %.2f", result.RisparmioStimatoEuro, totale)
+	}
+
+	// Without conflicts nothing is left out
+	if r := MatchBonus(models.UserProfile{Eta: 30, ISEE: 20000, NumeroFigli: 1, FigliMinorenni: 1}); len(r.Alternative) != 0 {
+		t.Errorf("unexpected alternatives: %+v", r.Alternative)
+	}
+}
+
+func TestMatchBonus_QuasiIdonei(t *testing.T) {
+	p := models.UserProfile{Eta: 36, ISEE: 9600, RedditoAnnuo: 30000, Occupazione: "dipendente",
+		NumeroFigli: 1, FigliMinorenni: 1, PrimaAbitazione: true, Residenza: "Lazio"}
+	result := MatchBonus(p)
+
+	quasi := make(map[string]models.QuasiIdoneo)
+	for _, q := range result.QuasiIdonei {
+		quasi[q.Bonus.ID] = q
+	}
+	for _, b := range result.Bonus {
+		if _, ok := quasi[b.ID]; ok {
+			t.Errorf("%s both matched and near miss", b.ID)
+		}
+	}
+	for _, tc := range []struct {
+		id, campo string
+		scarto    float64
+		oltre     bool
+	}{
+		{"prima-casa-under36", "eta", 1, true},
+		{"adi", "isee", 240, true},
+		{"bonus-mamma", "numero_figli", 1, false},
+	} {
+		q, ok := quasi[tc.id]
+		if !ok {
+			t.Errorf("%s: not a near miss", tc.id)
+			continue
+		}
+		if q.Requisito.Campo != tc.campo || q.Parametri["campo"] != tc.campo || q.Scarto != tc.scarto || q.Oltre != tc.oltre || q.Codice == "" {
+			t.Errorf("%s: %+v", tc.id, q)
+		}
+	}
+
+	// Wider gaps are plain misses
+	p.Eta, p.ISEE = 45, 14000
+	for _, q := range MatchBonus(p).QuasiIdonei {
+		if q.Bonus.ID == "prima-casa-under36" || q.Bonus.ID == "adi" {
+			t.Errorf("%s should not be a near miss: %+v", q.Bonus.ID, q.Requisito)
+		}
+	}
+}
+
+func TestProssimeDomande(t *testing.T) {
+	p := models.UserProfile{Eta: 30, NumeroFigli: 1, FigliMinorenni: 1}
+	risposte := map[string]bool{"eta": true, "numero_figli": true, "figli_minorenni": true}
+	domande := ProssimeDomande(p, risposte, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	byCampo := make(map[string]models.Domanda)
+	for i, d := range domande {
+		if risposte[d.Campo] {
+			t.Errorf("answered field %s asked again", d.Campo)
+		}
+		if d.Bonus == 0 || d.Bonus != len(d.BonusID) || d.Etichetta == "" {
+			t.Errorf("%s: %+v", d.Campo, d)
+		}
+		if i > 0 && (d.Bonus > domande[i-1].Bonus || d.Bonus == domande[i-1].Bonus && d.Euro > domande[i-1].Euro) {
+			t.Errorf("%s ranked after %s", d.Campo, domande[i-1].Campo)
+		}
+		byCampo[d.Campo] = d
+	}
+	// The ISEE decides ADI for a family with a minor child; the region
+	// decides the regional bonuses
+	if d, ok := byCampo["isee"]; !ok || !strings.Contains(strings.Join(d.BonusID, ","), "adi") || d.Euro == 0 {
+		t.Errorf("isee: %+v", d)
+	}
+	if d, ok := byCampo["residenza"]; !ok || !strings.Contains(strings.Join(d.BonusID, ","), "dote-scuola-lombardia") {
+		t.Errorf("residenza: %+v", d)
+	}
+
+	// Household members answer the children counters
+	p.Nucleo = []models.Componente{{Relazione: "figlio", DataNascita: "2020-05-01"}}
+	for _, d := range ProssimeDomande(p, map[string]bool{"eta": true}, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		if d.Campo == "figli_under3" || d.Campo == "numero_figli" {
+			t.Errorf("counter %s asked with a nucleo", d.Campo)
+		}
+	}
+}
+
+func TestMatchBonus_Spiegazione(t *testing.T) {
+	p := models.UserProfile{Eta: 30, NumeroFigli: 1, FigliMinorenni: 1, Residenza: "Lombardia"}
+	result := MatchBonus(p)
+	found := false
+	for _, b := range result.Bonus {
+		for _, e := range b.Spiegazione {
+			if e.Obbligatorio && e.Esito == models.EsitoNonSoddisfatto {
+				t.Errorf("%s: requisito obbligatorio non soddisfatto ma bonus proposto: %s", b.ID, e.Descrizione)
+			}
+		}
+		if b.ID == "assegno-unico" {
+			found = true
+			last := b.Spiegazione[len(b.Spiegazione)-1]
+			if last.Campo != "isee" || last.Esito != models.EsitoNonNoto {
+				t.Errorf("assegno-unico senza ISEE: atteso esito non_noto su isee, got %+v", last)
+			}
+		}
+	}
+	if !found {
+		t.Error("Assegno Unico mancante")
+	}
+}
+
+func TestMatchBonus_ValoreTipizzato(t *testing.T) {
+	p := models.UserProfile{Eta: 35, NumeroFigli: 1, FigliMinorenni: 1, FigliUnder3: 1, ISEE: 20000}
+	result := MatchBonus(p)
+	somma := 0.0
+	for _, b := range result.Bonus {
+		if b.ValoreStimato == nil {
+			t.Fatalf("%s: valore_stimato mancante", b.ID)
+		}
+		if len(b.ValoreStimato.Fasce) > 0 || b.ValoreStimato.Calcolo != "" {
+			t.Errorf("%s: valore_stimato non risolto: %+v", b.ID, b.ValoreStimato)
+		}
+		if !b.Scaduto {
+			somma += b.ValoreStimato.Annuo()
+		}
+		switch b.ID {
+		case "bonus-nido":
+			if b.ValoreStimato.Atteso != 3600 {
+				t.Errorf("bonus-nido con ISEE 20.000: atteso €3.600/anno, ottenuto %.2f", b.ValoreStimato.Atteso)
+			}
+		case "assegno-unico":
+			if math.Abs(b.ValoreStimato.Atteso-calcAssegnoUnicoMensile(p)) > 0.01 {
+				t.Errorf("assegno-unico: atteso %.2f, ottenuto %.2f", calcAssegnoUnicoMensile(p), b.ValoreStimato.Atteso)
+			}
+		}
+	}
+	if math.Abs(result.RisparmioStimatoEuro-somma) > 0.05 {
+		t.Errorf("risparmio_stimato_euro %.2f diverso dalla somma dei valori annui %.2f", result.RisparmioStimatoEuro, somma)
+	}
+}
+
+func TestMatchBonus_SpeseDetraibili(t *testing.T) {
+	p := models.UserProfile{
+		Eta: 45, Occupazione: "dipendente", RedditoAnnuo: 30000,
+		PrimaAbitazione: true, RistrutturazCasa: true,
+		SpeseMediche: 1329.11, InteressiMutuo: 5000, SpeseRistrutturazione: 20000,
+	}
+	attesi := map[string]float64{
+		"detrazione-spese-mediche": 228,  // 19% di (1.329,11 - 129,11)
+		"detrazione-mutuo":         760,  // 19% di 4.000 (tetto)
+		"bonus-ristrutturazione":   1000, // 50% di 20.000 in 10 rate
+	}
+	for _, b := range MatchBonus(p).Bonus {
+		atteso, ok := attesi[b.ID]
+		if !ok {
+			continue
+		}
+		delete(attesi, b.ID)
+		if b.ValoreStimato == nil || math.Abs(b.ValoreStimato.Atteso-atteso) > 0.01 {
+			t.Errorf("%s: atteso %.2f, ottenuto %+v", b.ID, atteso, b.ValoreStimato)
+		}
+	}
+	for id := range attesi {
+		t.Errorf("%s: bonus non trovato", id)
+	}
+}
+
+func TestMatchBonusAt_DataValutazione(t *testing.T) {
+	p := models.UserProfile{Eta: 40, ISEE: 20000, Occupazione: "dipendente", RistrutturazCasa: true}
+	scaduto := func(asOf time.Time) bool {
+		for _, b := range MatchBonusAt(p, asOf).Bonus {
+			if b.ID == "bonus-mobili" {
+				return b.Scaduto
+			}
+		}
+		t.Fatal("Bonus mobili mancante")
+		return false
+	}
+
+	prima := time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC)
+	dopo := time.Date(2027, 1, 15, 12, 0, 0, 0, time.UTC)
+	if scaduto(prima) {
+		t.Error("Il 31 dicembre 2026 il bonus mobili non dovrebbe essere scaduto")
+	}
+	if !scaduto(dopo) {
+		t.Error("Il 15 gennaio 2027 il bonus mobili dovrebbe essere scaduto")
+	}
+	if got := MatchBonusAt(p, dopo).DataValutazione; got != "2027-01-15" {
+		t.Errorf("data_valutazione = %q, atteso 2027-01-15", got)
+	}
+
+	// Nothing is lost yet in January
+	if perso := calcPersoFinora(1200, dopo); perso != 0 {
+		t.Errorf("A gennaio perso finora atteso 0, ottenuto %.2f", perso)
+	}
+	if perso := calcPersoFinora(1200, prima); perso <= 0 {
+		t.Errorf("A dicembre perso finora atteso > 0, ottenuto %.2f", perso)
+	}
+}
+
+func TestMatchBonusAt_Finestra(t *testing.T) {
+	giorno := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d.Add(12 * time.Hour)
+	}
+	trova := func(p models.UserProfile, asOf, id string) models.Bonus {
+		for _, b := range MatchBonusAt(p, giorno(asOf)).Bonus {
+			if b.ID == id {
+				return b
+			}
+		}
+		t.Fatalf("%s mancante", id)
+		return models.Bonus{}
+	}
+
+	// Entro 60 giorni dalla nascita: calcolata sulla data del figlio
+	p := models.UserProfile{Eta: 32, ISEE: 20000, NumeroFigli: 1, FigliMinorenni: 1, FigliUnder3: 1, FigliUnder1: 1,
+		NuovoNato2026: true, DataNascitaFiglio: "2026-09-01"}
+	if b := trova(p, "2026-10-18", "bonus-nascita"); b.Scaduto || b.Finestra.Chiusura != "2026-10-31" {
+		t.Errorf("Bonus nascita: atteso aperto fino al 2026-10-31, ottenuto %+v (scaduto %v)", b.Finestra, b.Scaduto)
+	}
+	if b := trova(p, "2026-11-01", "bonus-nascita"); !b.Scaduto {
+		t.Errorf("Bonus nascita: atteso scaduto il 2026-11-01, ottenuto %+v", b.Finestra)
+	}
+	p.DataNascitaFiglio = ""
+	if b := trova(p, "2026-11-01", "bonus-nascita"); b.Scaduto || b.Finestra.Stato != models.FinestraDaDefinire {
+		t.Errorf("Senza data di nascita la scadenza non e calcolabile, ottenuto %+v", b.Finestra)
+	}
+
+	// Bando luglio-settembre: ricorre ogni anno
+	s := models.UserProfile{Eta: 20, ISEE: 15000, Studente: true}
+	if b := trova(s, "2026-10-18", "borsa-studio"); b.Scaduto || b.Finestra.Stato != models.FinestraInApertura || b.Finestra.Apertura != "2027-07-01" {
+		t.Errorf("Borsa di studio: attesa prossima apertura 2027-07-01, ottenuto %+v", b.Finestra)
+	}
+	if b := trova(s, "2026-08-10", "borsa-studio"); b.Finestra.Stato != models.FinestraAperta || b.Finestra.Chiusura != "2026-09-30" {
+		t.Errorf("Borsa di studio: attesa aperta fino al 2026-09-30, ottenuto %+v", b.Finestra)
+	}
+}
+
 // ═══════════════════════════════════════════════════════
 // Assegno Unico 2026 — Test dettagliati
 // Valori da Circolare INPS n. 7 del 30 gennaio 2026
@@ -256,37 +664,38 @@ func TestAssegnoUnico2026(t *testing.T) {
 		}
 	})
 
-	t.Run("calcScore_ISEE_basso", func(t *testing.T) {
+	au, _ := catalog.Lookup("assegno-unico")
+	t.Run("score_ISEE_basso", func(t *testing.T) {
 		p := models.UserProfile{Eta: 35, NumeroFigli: 2, FigliMinorenni: 2, ISEE: 15000}
-		score := calcScore("assegno-unico", p)
+		score := eligibility.Score(au.Idoneita, p)
 		if score != 98 {
 			t.Errorf("Score con ISEE ≤17.468,51 dovrebbe essere 98, ottenuto %d", score)
 		}
 	})
 
-	t.Run("calcScore_ISEE_sopra_soglia", func(t *testing.T) {
+	t.Run("score_ISEE_sopra_soglia", func(t *testing.T) {
 		p := models.UserProfile{Eta: 35, NumeroFigli: 1, FigliMinorenni: 1, ISEE: 30000}
-		score := calcScore("assegno-unico", p)
+		score := eligibility.Score(au.Idoneita, p)
 		if score != 85 {
 			t.Errorf("Score con ISEE >17.468,51 dovrebbe essere 85, ottenuto %d", score)
 		}
 	})
 
-	t.Run("calcImportoReale_contiene_mese_anno", func(t *testing.T) {
+	t.Run("importoReale_contiene_mese_anno", func(t *testing.T) {
 		p := models.UserProfile{Eta: 35, NumeroFigli: 2, FigliMinorenni: 2, ISEE: 15000}
-		importo := calcImportoReale("assegno-unico", p.ISEE, p)
+		importo := calcImportoRealeAt("assegno-unico", p.ISEE, p, clock.Now())
 		if !strings.Contains(importo, "/mese") || !strings.Contains(importo, "/anno") {
 			t.Errorf("ImportoReale dovrebbe contenere /mese e /anno, ottenuto: %s", importo)
 		}
 	})
 
-	t.Run("estimateSaving_coerente_con_mensile", func(t *testing.T) {
+	t.Run("valore_coerente_con_mensile", func(t *testing.T) {
 		p := models.UserProfile{Eta: 35, NumeroFigli: 2, FigliMinorenni: 2, ISEE: 15000}
-		saving := estimateSaving("assegno-unico", p)
+		saving := math.Round(eligibility.Valore(au.Valore, p, clock.Now()).Annuo()*100) / 100
 		monthly := calcAssegnoUnicoMensile(p)
 		expectedAnnual := math.Round(monthly*12*100) / 100
 		if math.Abs(saving-expectedAnnual) > 0.10 {
-			t.Errorf("estimateSaving (€%.2f) dovrebbe essere coerente con mensile×12 (€%.2f)", saving, expectedAnnual)
+			t.Errorf("Valore annuo (€%.2f) dovrebbe essere coerente con mensile×12 (€%.2f)", saving, expectedAnnual)
 		}
 	})
 
@@ -302,3 +711,51 @@ func TestAssegnoUnico2026(t *testing.T) {
 		}
 	})
 }
+
+func TestSweepISEE_Nucleo(t *testing.T) {
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	p := models.UserProfile{Eta: 32, Residenza: "Lombardia", Nucleo: []models.Componente{
+		{Relazione: "figlio", DataNascita: "2026-02-01"},
+	}}
+	counters := nucleo.Deriva(p, at)
+	counters.Nucleo = nil
+
+	soglie := func(p models.UserProfile) map[string]models.SogliaSweep {
+		out := map[string]models.SogliaSweep{}
+		for _, s := range SweepISEE(p, GetAllBonusWithRegional(), at, 35000, 45000, 1500).Soglie {
+			out[s.BonusID] = s
+		}
+		return out
+	}
+	got, want := soglie(p), soglie(counters)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nucleo: %+v\ncounters: %+v", got, want)
+	}
+	if s := got["bonus-nascita"]; s.Evento != models.SweepScompare || s.ISEE != 40000.01 {
+		t.Errorf("Expected bonus-nascita to disappear at 40000.01, got %+v", s)
+	}
+}
+
+func TestSweepISEE_Cumulo(t *testing.T) {
+	// B matches all along but is left out while A is chosen: it appears
+	// where A disappears, not at the start of the step.
+	fino := func(isee float64) *models.Idoneita {
+		return &models.Idoneita{Punteggi: []models.Fascia{{Punteggio: 50}}, Requisiti: []models.Condizione{{Campo: "isee", Max: &isee}}}
+	}
+	a, b := fino(10000), fino(20000)
+	a.Punteggi[0].Punteggio = 90
+	bonus := []models.Bonus{
+		{ID: "a", Idoneita: a, Incompatibili: []models.Relazione{{Bonus: "b", Motivo: "stessa spesa"}}},
+		{ID: "b", Idoneita: b},
+	}
+	soglie := map[string]models.SogliaSweep{}
+	for _, s := range SweepISEE(models.UserProfile{Eta: 40}, bonus, clock.Now(), 4000, 16000, 4000).Soglie {
+		soglie[s.BonusID] = s
+	}
+	if s := soglie["a"]; s.Evento != models.SweepScompare || s.ISEE != 10000.01 {
+		t.Errorf("Expected a to disappear at 10000.01, got %+v", s)
+	}
+	if s := soglie["b"]; s.Evento != models.SweepAppare || s.ISEE != 10000.01 {
+		t.Errorf("Expected b to appear at 10000.01, got %+v", s)
+	}
+}
@@ -0,0 +1,48 @@
+package matcher
+
+import (
+	"bonusperme/internal/eligibility"
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/models"
+	"sort"
+	"time"
+)
+
+// quasiIdoneo reports whether b, which the profile does not qualify for,
+// is a near miss worth showing: one numeric requirement missed by a small
+// margin (see eligibility.Quasi) on a bonus whose window is not closed.
+func quasiIdoneo(b models.Bonus, p models.UserProfile, asOf time.Time) (models.QuasiIdoneo, bool) {
+	m, ok := eligibility.Quasi(b.Idoneita, p)
+	if !ok {
+		return models.QuasiIdoneo{}, false
+	}
+	setFinestra(&b, p, asOf)
+	if b.Finestra.Stato == models.FinestraChiusa {
+		return models.QuasiIdoneo{}, false
+	}
+	b.ValoreStimato = eligibility.Valore(b.Valore, p, asOf)
+
+	code := "quasi.sotto"
+	if m.Oltre {
+		code = "quasi.oltre"
+	}
+	// campo is the profile field: the handlers put its label in the
+	// language of the request in place of the Italian one
+	msg := i18n.M(code, "campo", m.Requisito.Campo, "etichetta", eligibility.Fields[m.Requisito.Campo].Label,
+		"valore", m.Requisito.Valore, "soglia", m.SogliaTesto, "scarto", m.ScartoTesto)
+	return models.QuasiIdoneo{
+		Bonus:     b,
+		Requisito: m.Requisito,
+		Soglia:    m.Soglia,
+		Scarto:    m.Scarto,
+		Oltre:     m.Oltre,
+		Messaggio: msg.Text(i18n.Default),
+		Codice:    msg.Code,
+		Parametri: msg.Params,
+	}, true
+}
+
+// sortQuasi puts the most valuable near misses first.
+func sortQuasi(q []models.QuasiIdoneo) {
+	sort.SliceStable(q, func(i, j int) bool { return annuo(q[i].Bonus) > annuo(q[j].Bonus) })
+}
@@ -0,0 +1,130 @@
+package matcher
+
+import (
+	"bonusperme/internal/models"
+	"bonusperme/internal/nucleo"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ContatoreNucleoError reports an override of a household counter on a
+// profile that lists its members: nucleo.Deriva would replace the value,
+// so the members have to be changed instead.
+type ContatoreNucleoError struct {
+	Campo string
+}
+
+func (e *ContatoreNucleoError) Error() string {
+	return fmt.Sprintf("%s is derived from nucleo: change the members instead", e.Campo)
+}
+
+// ApplyOverrides returns a copy of the profile with the fields in
+// modifiche (keyed by their JSON name) replaced. Unknown fields and values
+// of the wrong type are rejected, and so are the nucleo.Contatori when
+// the resulting profile lists its household members.
+func ApplyOverrides(p models.UserProfile, modifiche map[string]interface{}) (models.UserProfile, error) {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return p, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return p, err
+	}
+	for k, v := range modifiche {
+		if !profileFields[k] {
+			return p, fmt.Errorf("unknown profile field %q", k)
+		}
+		fields[k] = v
+	}
+
+	raw, err = json.Marshal(fields)
+	if err != nil {
+		return p, err
+	}
+	var out models.UserProfile
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return p, err
+	}
+	if len(out.Nucleo) > 0 {
+		for _, k := range nucleo.Contatori {
+			if _, ok := modifiche[k]; ok {
+				return p, &ContatoreNucleoError{Campo: k}
+			}
+		}
+	}
+	return out, nil
+}
+
+// profileFields are the JSON names of the UserProfile fields.
+var profileFields = func() map[string]bool {
+	m := map[string]bool{}
+	t := reflect.TypeOf(models.UserProfile{})
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			m[name] = true
+		}
+	}
+	return m
+}()
+
+// DiffResults compares the active bonuses of two match results: bonuses
+// gained and lost, changed annual amounts and the savings delta.
+func DiffResults(base, sim models.MatchResult) models.DiffScenario {
+	prima, dopo := attiviPerID(base), attiviPerID(sim)
+	d := models.DiffScenario{
+		BonusAttivi:          sim.BonusAttivi,
+		RisparmioStimatoEuro: sim.RisparmioStimatoEuro,
+		DeltaRisparmioEuro:   math.Round((sim.RisparmioStimatoEuro-base.RisparmioStimatoEuro)*100) / 100,
+		DeltaTotaleEuro:      math.Round((sim.RisparmioTotaleEuro-base.RisparmioTotaleEuro)*100) / 100,
+	}
+	for id, b := range dopo {
+		a, ok := prima[id]
+		switch {
+		case !ok:
+			d.Guadagnati = append(d.Guadagnati, bonusDiff(id, b.Nome, 0, annuo(b)))
+		case math.Abs(annuo(a)-annuo(b)) >= 0.01:
+			d.Variati = append(d.Variati, bonusDiff(id, b.Nome, annuo(a), annuo(b)))
+		}
+	}
+	for id, a := range prima {
+		if _, ok := dopo[id]; !ok {
+			d.Persi = append(d.Persi, bonusDiff(id, a.Nome, annuo(a), 0))
+		}
+	}
+	for _, l := range [][]models.BonusDiff{d.Guadagnati, d.Persi, d.Variati} {
+		sort.Slice(l, func(i, j int) bool { return l[i].BonusID < l[j].BonusID })
+	}
+	return d
+}
+
+func attiviPerID(r models.MatchResult) map[string]models.Bonus {
+	m := map[string]models.Bonus{}
+	for _, b := range r.Bonus {
+		if !b.Scaduto {
+			m[b.ID] = b
+		}
+	}
+	return m
+}
+
+func annuo(b models.Bonus) float64 {
+	if b.ValoreStimato == nil {
+		return 0
+	}
+	return b.ValoreStimato.Annuo()
+}
+
+func bonusDiff(id, nome string, prima, dopo float64) models.BonusDiff {
+	return models.BonusDiff{
+		BonusID:    id,
+		Nome:       nome,
+		AnnuoPrima: math.Round(prima*100) / 100,
+		AnnuoDopo:  math.Round(dopo*100) / 100,
+		DeltaEuro:  math.Round((dopo-prima)*100) / 100,
+	}
+}
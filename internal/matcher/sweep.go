@@ -0,0 +1,113 @@
+package matcher
+
+import (
+	"bonusperme/internal/eligibility"
+	"bonusperme/internal/models"
+	"bonusperme/internal/nucleo"
+	"math"
+	"sort"
+	"time"
+)
+
+// SweepISEE runs MatchBonusAt for the profile at every ISEE step from da to
+// a and locates, by bisection between two steps, the exact ISEE where each
+// bonus appears or disappears. ISEE 0 means "not presented", so a range
+// starting at 0 is sampled from the first step.
+func SweepISEE(profile models.UserProfile, bonuses []models.Bonus, asOf time.Time, da, a, passo float64) models.SweepISEE {
+	// Household counters at asOf: soglia evaluates the rules on the profile
+	profile = nucleo.Deriva(profile, asOf)
+	res := models.SweepISEE{Da: da, A: a, Passo: passo}
+	byID := map[string]models.Bonus{}
+
+	var prev map[string]bool
+	prevISEE := 0.0
+	for i := 0; da+float64(i)*passo <= a+0.005; i++ {
+		x := da + float64(i)*passo
+		if x <= 0 {
+			continue
+		}
+		p := profile
+		p.ISEE = math.Round(x*100) / 100
+		m := MatchBonusAt(p, asOf, bonuses)
+
+		attivi := map[string]bool{}
+		for _, b := range m.Bonus {
+			if !b.Scaduto {
+				attivi[b.ID] = true
+				byID[b.ID] = b
+			}
+		}
+		res.Punti = append(res.Punti, models.PuntoSweep{
+			ISEE:                 p.ISEE,
+			BonusAttivi:          m.BonusAttivi,
+			RisparmioStimatoEuro: m.RisparmioStimatoEuro,
+		})
+
+		if prev != nil {
+			for id := range unione(prev, attivi) {
+				if prev[id] != attivi[id] {
+					res.Soglie = append(res.Soglie, soglia(byID[id], profile, bonuses, asOf, prevISEE, p.ISEE, attivi[id]))
+				}
+			}
+		}
+		prev, prevISEE = attivi, p.ISEE
+	}
+	sort.Slice(res.Soglie, func(i, j int) bool {
+		if res.Soglie[i].ISEE != res.Soglie[j].ISEE {
+			return res.Soglie[i].ISEE < res.Soglie[j].ISEE
+		}
+		return res.Soglie[i].BonusID < res.Soglie[j].BonusID
+	})
+	return res
+}
+
+// soglia finds, to the cent, the first ISEE in (lo, hi] where the bonus
+// is in state presente, assuming a single change in the interval. The
+// state is the one of the sweep steps, membership in the active bonuses
+// of MatchBonusAt, so a bonus left out by the non-cumulable choice
+// changes state where the choice does, not where its rules do.
+func soglia(b models.Bonus, profile models.UserProfile, bonuses []models.Bonus, asOf time.Time, lo, hi float64, presente bool) models.SogliaSweep {
+	idoneo := func(isee float64) bool {
+		p := profile
+		p.ISEE = isee
+		for _, m := range MatchBonusAt(p, asOf, bonuses).Bonus {
+			if m.ID == b.ID {
+				return !m.Scaduto
+			}
+		}
+		return false
+	}
+	loC, hiC := int64(math.Round(lo*100)), int64(math.Round(hi*100))
+	for hiC-loC > 1 {
+		mid := (loC + hiC) / 2
+		if idoneo(float64(mid)/100) == presente {
+			hiC = mid
+		} else {
+			loC = mid
+		}
+	}
+
+	s := models.SogliaSweep{ISEE: float64(hiC) / 100, BonusID: b.ID, Nome: b.Nome, Evento: models.SweepScompare}
+	valoreA := float64(loC) / 100
+	if presente {
+		s.Evento = models.SweepAppare
+		valoreA = s.ISEE
+	}
+	p := profile
+	p.ISEE = valoreA
+	if v := eligibility.Valore(b.Valore, p, asOf); v != nil {
+		s.ValoreAnnuo = math.Round(v.Annuo()*100) / 100
+	}
+	return s
+}
+
+func unione(a, b map[string]bool) map[string]bool {
+	u := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		u[k] = true
+	}
+	for k := range b {
+		u[k] = true
+	}
+	return u
+}
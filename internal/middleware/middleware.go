@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"bonusperme/internal/i18n"
 	"compress/gzip"
 	"io"
 	"log"
@@ -28,7 +29,7 @@ func Recovery(next http.Handler) http.Handler {
 					hub.RecoverWithContext(r.Context(), err)
 				})
 				hub.Flush(2 * time.Second)
-				http.Error(w, "Errore interno del server", http.StatusInternalServerError)
+				i18n.Error(w, r, i18n.M("server.errore"), http.StatusInternalServerError)
 			}
 		}()
 		next.ServeHTTP(w, r)
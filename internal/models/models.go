@@ -4,7 +4,7 @@ import "time"
 
 type UserProfile struct {
 	Eta              int     `json:"eta"`
-	Residenza        string  `json:"residenza"`
+	Residenza        string  `json:"residenza"` // ISTAT code of the region or autonomous province; names are accepted
 	Comune           string  `json:"comune"`
 	StatoCivile      string  `json:"stato_civile"`
 	Occupazione      string  `json:"occupazione"`
@@ -27,6 +27,26 @@ type UserProfile struct {
 	DisabilitaFigli            string `json:"disabilita_figli"`
 	FigliDisabili              int    `json:"figli_disabili"`
 	MadreUnder21               bool   `json:"madre_under21"`
+	// Birth date (AAAA-MM-GG) of the newborn or expected child, for
+	// deadlines counted from the birth
+	DataNascitaFiglio string `json:"data_nascita_figlio,omitempty"`
+	// Deductible expenses of the last tax return (CU/730 import)
+	SpeseMediche          float64 `json:"spese_mediche,omitempty"`
+	InteressiMutuo        float64 `json:"interessi_mutuo,omitempty"`
+	SpeseRistrutturazione float64 `json:"spese_ristrutturazione,omitempty"`
+	// Household members besides the applicant. When present, the children
+	// and over 65 counters above are derived from them for the evaluation
+	// date (package nucleo) instead of being read from the request
+	Nucleo []Componente `json:"nucleo,omitempty"`
+}
+
+// Componente is a member of the household besides the applicant.
+type Componente struct {
+	Relazione   string  `json:"relazione"`             // figlio, coniuge, genitore, altro
+	DataNascita string  `json:"data_nascita"`          // AAAA-MM-GG; a future date is an expected child
+	Disabilita  string  `json:"disabilita,omitempty"`  // media, grave, non_autosufficienza
+	Occupazione string  `json:"occupazione,omitempty"` // same values as UserProfile.Occupazione
+	Reddito     float64 `json:"reddito,omitempty"`     // yearly income, for the children aged 18-20
 }
 
 type FAQ struct {
@@ -34,11 +54,200 @@ type FAQ struct {
 	Risposta string `json:"risposta"`
 }
 
+// BonusTrad is the translation of the user-facing text of a bonus into one
+// language. Empty fields fall back to the Italian text. Motivi translates
+// the motivo of the incompatibile and cumulabile relations, by the ID of
+// the related bonus.
 type BonusTrad struct {
-	Descrizione     string   `json:"descrizione"`
-	Requisiti       []string `json:"requisiti,omitempty"`
-	ComeRichiederlo []string `json:"come_richiederlo,omitempty"`
-	FAQ             []FAQ    `json:"faq,omitempty"`
+	Nome            string            `json:"nome,omitempty"`
+	Descrizione     string            `json:"descrizione"`
+	Importo         string            `json:"importo,omitempty"`
+	Scadenza        string            `json:"scadenza,omitempty"`
+	Requisiti       []string          `json:"requisiti,omitempty"`
+	ComeRichiederlo []string          `json:"come_richiederlo,omitempty"`
+	Documenti       []string          `json:"documenti,omitempty"`
+	FAQ             []FAQ             `json:"faq,omitempty"`
+	Motivi          map[string]string `json:"motivi,omitempty"`
+}
+
+// Idoneita declares who a bonus is for. Every condition in Requisiti must
+// hold for the bonus to match; the compatibility score is then taken from
+// the first entry in Punteggi whose conditions hold.
+type Idoneita struct {
+	Requisiti []Condizione `json:"requisiti,omitempty"`
+	Punteggi  []Fascia     `json:"punteggi"`
+}
+
+// Fascia is one scoring tier of an Idoneita.
+type Fascia struct {
+	Se        []Condizione `json:"se,omitempty"`
+	Punteggio int          `json:"punteggio"`
+}
+
+// Condizione is a test on one UserProfile field (by its JSON name), or a
+// combination of nested conditions. All bounds set on a condition must hold.
+type Condizione struct {
+	Campo string   `json:"campo,omitempty"`
+	Min   *float64 `json:"min,omitempty"`   // valore >= min
+	Max   *float64 `json:"max,omitempty"`   // valore <= max
+	Oltre *float64 `json:"oltre,omitempty"` // valore > oltre
+	Sotto *float64 `json:"sotto,omitempty"` // valore < sotto
+	In    []string `json:"in,omitempty"`    // campi testuali
+	Vero  *bool    `json:"vero,omitempty"`  // campi booleani
+
+	UnaTra []Condizione `json:"una_tra,omitempty"` // almeno una vera
+	Tutte  []Condizione `json:"tutte,omitempty"`   // tutte vere
+}
+
+// Periodicita di un Beneficio.
+const (
+	PeriodicitaUnaTantum = "una_tantum"
+	PeriodicitaMensile   = "mensile"
+	PeriodicitaAnnuale   = "annuale"
+)
+
+// Tipi di Beneficio.
+const (
+	TipoTrasferimento       = "trasferimento"        // somma erogata (assegno, contributo, voucher)
+	TipoDetrazione          = "detrazione"           // detrazione o credito d'imposta
+	TipoScontoBolletta      = "sconto_bolletta"      // sconto automatico sulle utenze
+	TipoGaranzia            = "garanzia"             // garanzia pubblica su un finanziamento
+	TipoEsoneroContributivo = "esonero_contributivo" // contributi non versati, in busta paga
+	TipoSconto              = "sconto"               // riduzione di prezzo su beni o servizi
+)
+
+// Beneficio is the typed monetary value of a bonus. Min, Max and Atteso
+// are amounts per period (per month for "mensile"); Durata is the number
+// of periods the benefit lasts (months or years), 0 if open-ended.
+//
+// In the catalog Fasce adjust the value to the profile (first matching
+// tier wins) and Calcolo names a registered formula that computes Atteso;
+// both are resolved by eligibility.Valore and absent from the result.
+type Beneficio struct {
+	Tipo        string         `json:"tipo"`
+	Periodicita string         `json:"periodicita"`
+	Min         float64        `json:"min,omitempty"`
+	Max         float64        `json:"max,omitempty"`
+	Atteso      float64        `json:"atteso"`
+	Durata      int            `json:"durata,omitempty"`
+	Calcolo     string         `json:"calcolo,omitempty"`
+	Fasce       []FasciaValore `json:"fasce,omitempty"`
+}
+
+// FasciaValore overrides the non-zero fields of a Beneficio when all its
+// conditions hold.
+type FasciaValore struct {
+	Se          []Condizione `json:"se"`
+	Tipo        string       `json:"tipo,omitempty"`
+	Periodicita string       `json:"periodicita,omitempty"`
+	Min         float64      `json:"min,omitempty"`
+	Max         float64      `json:"max,omitempty"`
+	Atteso      float64      `json:"atteso,omitempty"`
+	Durata      int          `json:"durata,omitempty"`
+}
+
+// Annuo returns the expected value over one year: monthly amounts count
+// for the months paid (at most 12), one-off and yearly amounts once.
+func (v Beneficio) Annuo() float64 {
+	if v.Periodicita == PeriodicitaMensile {
+		mesi := 12
+		if v.Durata > 0 && v.Durata < 12 {
+			mesi = v.Durata
+		}
+		return v.Atteso * float64(mesi)
+	}
+	return v.Atteso
+}
+
+// Totale returns the expected value over the whole duration; open-ended
+// benefits count one year.
+func (v Beneficio) Totale() float64 {
+	switch v.Periodicita {
+	case PeriodicitaMensile:
+		if v.Durata > 0 {
+			return v.Atteso * float64(v.Durata)
+		}
+		return v.Atteso * 12
+	case PeriodicitaAnnuale:
+		if v.Durata > 1 {
+			return v.Atteso * float64(v.Durata)
+		}
+	}
+	return v.Atteso
+}
+
+// Tipi di Termine.
+const (
+	TerminePermanente       = "permanente"        // sempre aperto
+	TermineEsaurimentoFondi = "esaurimento_fondi" // aperto finche ci sono fondi
+	TermineBandoAnnuale     = "bando_annuale"     // bando rinnovato ogni anno, date non note
+	TermineDataFissa        = "data_fissa"        // domanda entro Chiusura
+	TermineFinestra         = "finestra"          // domanda da Apertura a Chiusura
+	TermineAnnuale          = "annuale"           // finestra Apertura-Chiusura (MM-GG) ogni anno
+	TermineClickDay         = "click_day"         // domande da Apertura alle Ora, fino a esaurimento o Chiusura
+	TermineRelativo         = "relativo"          // entro un termine da un evento del profilo
+	TermineChiuso           = "chiuso"            // misura scaduta o non rinnovata
+)
+
+// Eventi del profilo per i Termine relativi.
+const (
+	EventoNascita     = "nascita"      // nascita del figlio (data_nascita_figlio)
+	EventoMaggioreEta = "maggiore_eta" // compimento dei 18 anni (stimato da eta)
+)
+
+// Termine is the structured deadline of a bonus. Apertura and Chiusura are
+// dates (AAAA-MM-GG), or days of the year (MM-GG) for "annuale" and for a
+// "relativo" closing on a fixed day AnniDopo years after the event. A
+// "relativo" deadline closes Giorni days after the Evento otherwise.
+// Catalog files may declare it; when absent it is derived from Scadenza.
+type Termine struct {
+	Tipo     string `json:"tipo"`
+	Apertura string `json:"apertura,omitempty"`
+	Chiusura string `json:"chiusura,omitempty"`
+	Ora      string `json:"ora,omitempty"` // HH:MM, click day
+	Evento   string `json:"evento,omitempty"`
+	Giorni   int    `json:"giorni,omitempty"`
+	AnniDopo int    `json:"anni_dopo,omitempty"`
+}
+
+// Stati di una Finestra.
+const (
+	FinestraAperta     = "aperta"
+	FinestraInApertura = "in_apertura" // non ancora aperta: Apertura e la prossima data utile
+	FinestraChiusa     = "chiusa"
+	FinestraDaDefinire = "da_definire" // relativa a un evento che il profilo non indica
+)
+
+// Finestra is a Termine computed for one profile at an evaluation date:
+// the current or next application window. Dates are AAAA-MM-GG and
+// Chiusura is the last day applications are accepted. Nota is the i18n
+// message code of a remark on the window (what the profile lacks to compute
+// it, or that it is estimated).
+type Finestra struct {
+	Stato      string `json:"stato"`
+	Apertura   string `json:"apertura,omitempty"`
+	Chiusura   string `json:"chiusura,omitempty"`
+	Ora        string `json:"ora,omitempty"`
+	Ricorrente bool   `json:"ricorrente,omitempty"` // si ripete ogni anno
+	Nota       string `json:"nota,omitempty"`
+}
+
+// Esiti possibili di un requisito nella spiegazione di un match.
+const (
+	EsitoSoddisfatto    = "soddisfatto"
+	EsitoNonSoddisfatto = "non_soddisfatto"
+	EsitoNonNoto        = "non_noto"
+)
+
+// EsitoRequisito explains how one eligibility condition was evaluated
+// against the profile. Campo is the UserProfile field checked (empty for
+// composite conditions) and Valore the profile value as shown to the user.
+type EsitoRequisito struct {
+	Descrizione  string `json:"descrizione"`
+	Campo        string `json:"campo,omitempty"`
+	Valore       string `json:"valore,omitempty"`
+	Esito        string `json:"esito"`
+	Obbligatorio bool   `json:"obbligatorio"`
 }
 
 type Bonus struct {
@@ -48,7 +257,11 @@ type Bonus struct {
 	Descrizione          string               `json:"descrizione"`
 	Importo              string               `json:"importo"`
 	ImportoReale         string               `json:"importo_reale,omitempty"`
+	Valore               *Beneficio           `json:"valore,omitempty"`
+	ValoreStimato        *Beneficio           `json:"valore_stimato,omitempty"`
 	Scadenza             string               `json:"scadenza"`
+	Termine              *Termine             `json:"termine,omitempty"`
+	Finestra             *Finestra            `json:"finestra,omitempty"`
 	Scaduto              bool                 `json:"scaduto"`
 	Requisiti            []string             `json:"requisiti"`
 	ComeRichiederlo      []string             `json:"come_richiederlo"`
@@ -57,13 +270,26 @@ type Bonus struct {
 	LinkUfficiale        string               `json:"link_ufficiale"`
 	Ente                 string               `json:"ente"`
 	Compatibilita        int                  `json:"compatibilita"`
+	Spiegazione          []EsitoRequisito     `json:"spiegazione,omitempty"`
 	UltimoAggiornamento  string               `json:"ultimo_aggiornamento,omitempty"`
 	Fonte                string               `json:"fonte,omitempty"`
 	Stato                string               `json:"stato,omitempty"`
 	FonteURL             string               `json:"fonte_url,omitempty"`
 	FonteNome            string               `json:"fonte_nome,omitempty"`
 	RiferimentiNormativi []string             `json:"riferimenti_normativi,omitempty"`
+	Idoneita             *Idoneita            `json:"idoneita,omitempty"`
+	// ISTAT codes of the regions ("12") or autonomous provinces ("022")
+	// a regional bonus is open to
 	RegioniApplicabili        []string             `json:"regioni,omitempty"`
+	// Local bonuses (data/catalog/comunali): sigle of the provinces and
+	// ISTAT codes of the comuni they are open to
+	Province []string `json:"province,omitempty"`
+	Comuni   []string `json:"comuni,omitempty"`
+	// Relations with other bonuses of the catalog, declared on either side:
+	// incompatible bonuses are never counted together, cumulable ones are
+	// confirmed to stack
+	Incompatibili []Relazione `json:"incompatibile,omitempty"`
+	Cumulabili    []Relazione `json:"cumulabile,omitempty"`
 	SogliaISEE                float64              `json:"soglia_isee,omitempty"`
 	LinkRicerca               string               `json:"link_ricerca,omitempty"`
 	LinkVerificato            bool                  `json:"link_verificato"`
@@ -78,6 +304,8 @@ type Bonus struct {
 	StatoValidita             string               `json:"stato_validita,omitempty"`
 	MotivoStato               string               `json:"motivo_stato,omitempty"`
 	Traduzioni                map[string]BonusTrad `json:"traduzioni,omitempty"`
+	// Lingua is set when the text fields were replaced by a translation.
+	Lingua                    string               `json:"lingua,omitempty"`
 	LinkOriginale             string               `json:"link_originale,omitempty"`
 	ConfidenceScore           float64              `json:"confidence_score,omitempty"`
 	SourcesCount              int                  `json:"sources_count,omitempty"`
@@ -91,20 +319,131 @@ type Bonus struct {
 	UltimaVerificaSito        *time.Time           `json:"ultima_verifica_sito,omitempty"`
 }
 
+// Localized returns the bonus with its text in lang, field by field, falling
+// back to Italian where the translation is missing. Traduzioni is dropped:
+// clients get one language per response.
+func (b Bonus) Localized(lang string) Bonus {
+	t, ok := b.Traduzioni[lang]
+	b.Traduzioni = nil
+	if !ok {
+		return b
+	}
+	b.Lingua = lang
+	if t.Nome != "" {
+		b.Nome = t.Nome
+	}
+	if t.Descrizione != "" {
+		b.Descrizione = t.Descrizione
+	}
+	if t.Importo != "" {
+		b.Importo = t.Importo
+	}
+	if t.Scadenza != "" {
+		b.Scadenza = t.Scadenza
+	}
+	if len(t.Requisiti) > 0 {
+		b.Requisiti = t.Requisiti
+	}
+	if len(t.ComeRichiederlo) > 0 {
+		b.ComeRichiederlo = t.ComeRichiederlo
+	}
+	if len(t.Documenti) > 0 {
+		b.Documenti = t.Documenti
+	}
+	if len(t.FAQ) > 0 {
+		b.FAQ = t.FAQ
+	}
+	if len(t.Motivi) > 0 {
+		b.Incompatibili = localizedMotivi(b.Incompatibili, t.Motivi)
+		b.Cumulabili = localizedMotivi(b.Cumulabili, t.Motivi)
+	}
+	return b
+}
+
+// localizedMotivi returns a copy of rels with the translated reasons.
+func localizedMotivi(rels []Relazione, motivi map[string]string) []Relazione {
+	if len(rels) == 0 {
+		return rels
+	}
+	out := make([]Relazione, len(rels))
+	for i, r := range rels {
+		if m := motivi[r.Bonus]; m != "" {
+			r.Motivo = m
+		}
+		out[i] = r
+	}
+	return out
+}
+
 type MatchResult struct {
 	BonusTrovati     int     `json:"bonus_trovati"`
 	BonusAttivi      int     `json:"bonus_attivi"`
 	BonusScaduti     int     `json:"bonus_scaduti"`
 	RisparmioStimato string  `json:"risparmio_stimato"`
 	PersoFinora      string  `json:"perso_finora,omitempty"`
+	// Totali numerici (in euro) calcolati da ValoreStimato dei bonus attivi.
+	RisparmioStimatoEuro float64            `json:"risparmio_stimato_euro"`
+	RisparmioTotaleEuro  float64            `json:"risparmio_totale_euro"`
+	RisparmioPerTipo     map[string]float64 `json:"risparmio_per_tipo,omitempty"`
+	PersoFinoraEuro      float64            `json:"perso_finora_euro,omitempty"`
 	Bonus            []Bonus   `json:"bonus"`
+	// Bonuses the user qualifies for but that are not cumulable with the
+	// best combination in Bonus; not counted in the totals.
+	Alternative      []Alternativa `json:"alternative,omitempty"`
+	// Bonuses missed on a single numeric requirement by a small margin
+	// (ISEE just above the threshold, one year too old, one child short).
+	QuasiIdonei []QuasiIdoneo `json:"quasi_idonei,omitempty"`
 	Avvisi           []Avviso  `json:"avvisi,omitempty"`
+	// Data (AAAA-MM-GG) a cui sono valutate scadenze e importi.
+	DataValutazione string `json:"data_valutazione"`
+	// Lingua dei testi dei bonus (it se non richiesta).
+	Lingua string `json:"lingua,omitempty"`
+}
+
+// Relazione links a bonus to another bonus of the catalog, with the reason
+// shown to the user.
+type Relazione struct {
+	Bonus  string `json:"bonus"`
+	Motivo string `json:"motivo"`
+}
+
+// Alternativa is a matched bonus left out of the totals because it cannot
+// be combined with bonuses the user also qualifies for that are worth more
+// together. Scelti are the IDs of those bonuses; Motivo is the catalog
+// reason and Messaggio/Codice/Parametri explain the choice like an Avviso.
+type Alternativa struct {
+	Bonus     Bonus                  `json:"bonus"`
+	Scelti    []string               `json:"scelti"`
+	Motivo    string                 `json:"motivo,omitempty"`
+	Messaggio string                 `json:"messaggio"`
+	Codice    string                 `json:"codice"`
+	Parametri map[string]interface{} `json:"parametri,omitempty"`
+}
+
+// QuasiIdoneo is a bonus the user narrowly misses. Requisito is the failed
+// requirement with the user's value; Soglia is the bound to reach and
+// Scarto how far the value is from it, above a maximum when Oltre is true,
+// below a minimum otherwise. Messaggio/Codice/Parametri summarise the gap
+// like an Avviso.
+type QuasiIdoneo struct {
+	Bonus     Bonus                  `json:"bonus"`
+	Requisito EsitoRequisito         `json:"requisito"`
+	Soglia    float64                `json:"soglia"`
+	Scarto    float64                `json:"scarto"`
+	Oltre     bool                   `json:"oltre"`
+	Messaggio string                 `json:"messaggio"`
+	Codice    string                 `json:"codice"`
+	Parametri map[string]interface{} `json:"parametri,omitempty"`
 }
 
 type Avviso struct {
 	BonusID   string `json:"bonus_id"`
 	Tipo      string `json:"tipo"`
 	Messaggio string `json:"messaggio"`
+	// Codice e parametri del messaggio (vedi i18n.Message): il testo si
+	// ricostruisce in qualunque lingua.
+	Codice    string                 `json:"codice,omitempty"`
+	Parametri map[string]interface{} `json:"parametri,omitempty"`
 }
 
 type SimulateResult struct {
@@ -112,4 +451,79 @@ type SimulateResult struct {
 	Simulato       MatchResult `json:"simulato"`
 	BonusExtra     int         `json:"bonus_extra"`
 	RisparmioExtra string      `json:"risparmio_extra"`
+	RisparmioExtraEuro float64 `json:"risparmio_extra_euro"`
+	Sweep              *SweepISEE `json:"sweep,omitempty"`
+	Scenari            []Scenario `json:"scenari,omitempty"`
+}
+
+// Scenario is a what-if simulation: the profile fields changed (by JSON
+// name) and the diff of the result against the real profile.
+type Scenario struct {
+	Nome      string                 `json:"nome"`
+	Modifiche map[string]interface{} `json:"modifiche"`
+	Diff      DiffScenario           `json:"diff"`
+}
+
+// Domanda is a profile field not answered yet, with what its answer can
+// change in the match: the bonuses gained, lost or changed (Bonus of them,
+// listed in BonusID) and the largest change of the yearly estimate.
+type Domanda struct {
+	Campo     string   `json:"campo"`
+	Etichetta string   `json:"etichetta"`
+	Bonus     int      `json:"bonus"`
+	BonusID   []string `json:"bonus_id"`
+	Euro      float64  `json:"euro"`
+}
+
+// DiffScenario compares the active bonuses of a scenario with the baseline.
+type DiffScenario struct {
+	BonusAttivi          int         `json:"bonus_attivi"`
+	RisparmioStimatoEuro float64     `json:"risparmio_stimato_euro"`
+	DeltaRisparmioEuro   float64     `json:"delta_risparmio_euro"`
+	DeltaTotaleEuro      float64     `json:"delta_totale_euro"`
+	Guadagnati           []BonusDiff `json:"guadagnati"`
+	Persi                []BonusDiff `json:"persi"`
+	Variati              []BonusDiff `json:"variati"`
+}
+
+// BonusDiff is the annual value of a bonus before and after a scenario.
+type BonusDiff struct {
+	BonusID    string  `json:"bonus_id"`
+	Nome       string  `json:"nome"`
+	AnnuoPrima float64 `json:"annuo_prima"`
+	AnnuoDopo  float64 `json:"annuo_dopo"`
+	DeltaEuro  float64 `json:"delta_euro"`
+}
+
+// Sweep evento values.
+const (
+	SweepAppare   = "appare"
+	SweepScompare = "scompare"
+)
+
+// SweepISEE is the simulator curve over an ISEE range: the totals at
+// every step and the exact ISEE values where a bonus appears or
+// disappears.
+type SweepISEE struct {
+	Da     float64       `json:"da"`
+	A      float64       `json:"a"`
+	Passo  float64       `json:"passo"`
+	Punti  []PuntoSweep  `json:"punti"`
+	Soglie []SogliaSweep `json:"soglie"`
+}
+
+type PuntoSweep struct {
+	ISEE                 float64 `json:"isee"`
+	BonusAttivi          int     `json:"bonus_attivi"`
+	RisparmioStimatoEuro float64 `json:"risparmio_stimato_euro"`
+}
+
+// SogliaSweep is a breakpoint: ISEE is the first value (to the cent) with
+// the new state, ValoreAnnuo the bonus value where it applies.
+type SogliaSweep struct {
+	ISEE        float64 `json:"isee"`
+	BonusID     string  `json:"bonus_id"`
+	Nome        string  `json:"nome"`
+	Evento      string  `json:"evento"`
+	ValoreAnnuo float64 `json:"valore_annuo"`
 }
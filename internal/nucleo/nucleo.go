@@ -0,0 +1,130 @@
+// Package nucleo derives the household counters of a profile (children by
+// age bucket, over 65 members, newborns) from the list of household
+// members, so that they follow the evaluation date instead of being typed
+// in by hand and going stale.
+package nucleo
+
+import (
+	"bonusperme/internal/models"
+	"time"
+)
+
+// Relationships of a member to the applicant.
+const (
+	Figlio   = "figlio"
+	Coniuge  = "coniuge"
+	Genitore = "genitore"
+	Altro    = "altro"
+)
+
+// Relazioni lists the accepted relationships.
+var Relazioni = []string{Figlio, Coniuge, Genitore, Altro}
+
+// MaxComponenti is the largest household accepted besides the applicant.
+const MaxComponenti = 20
+
+// Contatori are the JSON names of the profile counters Deriva replaces when
+// the profile lists its members.
+var Contatori = []string{
+	"numero_figli", "figli_minorenni", "figli_maggiorenni", "figli_under3",
+	"figli_under1", "figli_disabili", "disabilita_figli", "over65",
+}
+
+// disabilita orders the disability levels, so that the most severe one of
+// the children is the one reported in DisabilitaFigli.
+var disabilita = map[string]int{"media": 1, "grave": 2, "non_autosufficienza": 3}
+
+// RedditoMaggiorenneMax is the yearly income up to which a child aged
+// 18-20 still counts for the Assegno Unico.
+const RedditoMaggiorenneMax = 8000
+
+// Nascita parses the birth date of a member.
+func Nascita(c models.Componente) (time.Time, error) {
+	return time.Parse("2006-01-02", c.DataNascita)
+}
+
+// Eta returns the age in completed years at the date at of someone born
+// on nascita; it is negative for a child not born yet.
+func Eta(nascita, at time.Time) int {
+	if at.Before(nascita) {
+		return -1
+	}
+	anni := at.Year() - nascita.Year()
+	if at.Month() < nascita.Month() || (at.Month() == nascita.Month() && at.Day() < nascita.Day()) {
+		anni--
+	}
+	return anni
+}
+
+// Deriva returns p with the household counters computed from p.Nucleo as
+// of at. Without members p is returned unchanged, so profiles that only
+// send the counters keep working. With members the counters are replaced:
+// children by age (under 1, under 3, minors, 18-20 earning up to
+// RedditoMaggiorenneMax), children with a disability and their most
+// severe level, over 65 members besides the children. Flags and dates only
+// ever gain information: a child born in the year of at sets
+// NuovoNato2026, the youngest child fills DataNascitaFiglio when
+// it is empty, and a spouse with a known occupation decides whether both
+// parents work. Members whose birth date does not parse are ignored.
+func Deriva(p models.UserProfile, at time.Time) models.UserProfile {
+	if len(p.Nucleo) == 0 {
+		return p
+	}
+	p.NumeroFigli, p.FigliMinorenni, p.FigliMaggiorenni = 0, 0, 0
+	p.FigliUnder3, p.FigliUnder1, p.FigliDisabili = 0, 0, 0
+	p.Over65, p.DisabilitaFigli = 0, ""
+	livello := 0
+	var ultimo time.Time
+	for _, c := range p.Nucleo {
+		nato, err := Nascita(c)
+		if err != nil {
+			continue
+		}
+		eta := Eta(nato, at)
+		if c.Relazione != Figlio {
+			if eta >= 65 {
+				p.Over65++
+			}
+			if c.Relazione == Coniuge && c.Occupazione != "" {
+				p.EntrambiGenitoriLavoratori = lavora(p.Occupazione) && lavora(c.Occupazione)
+			}
+			continue
+		}
+		if nato.Year() == at.Year() {
+			p.NuovoNato2026 = true
+		}
+		if nato.After(ultimo) {
+			ultimo = nato
+		}
+		if eta < 0 {
+			continue // expected child: only dates and flags
+		}
+		p.NumeroFigli++
+		switch {
+		case eta < 18:
+			p.FigliMinorenni++
+		case eta < 21 && c.Reddito <= RedditoMaggiorenneMax:
+			p.FigliMaggiorenni++
+		}
+		if eta < 3 {
+			p.FigliUnder3++
+		}
+		if eta < 1 {
+			p.FigliUnder1++
+		}
+		if l := disabilita[c.Disabilita]; l > 0 {
+			p.FigliDisabili++
+			if l > livello {
+				livello, p.DisabilitaFigli = l, c.Disabilita
+			}
+		}
+	}
+	if p.DataNascitaFiglio == "" && !ultimo.IsZero() {
+		p.DataNascitaFiglio = ultimo.Format("2006-01-02")
+	}
+	return p
+}
+
+func lavora(occupazione string) bool {
+	return occupazione == "dipendente" || occupazione == "autonomo"
+}
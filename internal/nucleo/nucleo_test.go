@@ -0,0 +1,113 @@
+package nucleo
+
+import (
+	"bonusperme/internal/models"
+	"testing"
+	"time"
+)
+
+func TestEta(t *testing.T) {
+	nascita := time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at   time.Time
+		want int
+	}{
+		{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), -1},
+		{nascita, 0},
+		{time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC), 2},
+		{time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC), 3},
+		{time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), 3},
+	}
+	for _, tt := range tests {
+		if got := Eta(nascita, tt.at); got != tt.want {
+			t.Errorf("Eta at %s = %d, want %d", tt.at.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestDeriva(t *testing.T) {
+	p := models.UserProfile{Eta: 38, Occupazione: "dipendente", Nucleo: []models.Componente{
+		{Relazione: Figlio, DataNascita: "2025-09-10"},
+		{Relazione: Figlio, DataNascita: "2015-04-02", Disabilita: "media"},
+		{Relazione: Coniuge, DataNascita: "1988-11-20", Occupazione: "autonomo"},
+		{Relazione: Genitore, DataNascita: "1950-06-30"},
+		{Relazione: Altro, DataNascita: "30/06/1950"},
+	}}
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got := Deriva(p, at)
+	if got.NumeroFigli != 2 || got.FigliMinorenni != 2 || got.FigliUnder3 != 1 || got.FigliUnder1 != 1 ||
+		got.FigliDisabili != 1 || got.DisabilitaFigli != "media" || got.Over65 != 1 ||
+		!got.EntrambiGenitoriLavoratori || got.DataNascitaFiglio != "2025-09-10" || got.NuovoNato2026 {
+		t.Errorf("counters at %s: %+v", at.Format("2006-01-02"), got)
+	}
+
+	// A year later the youngest is no longer under 1
+	if later := Deriva(p, at.AddDate(1, 0, 0)); later.FigliUnder1 != 0 || later.FigliUnder3 != 1 {
+		t.Errorf("counters a year later: under1=%d under3=%d", later.FigliUnder1, later.FigliUnder3)
+	}
+
+	// Age buckets on the birthday and the day before
+	tests := []struct {
+		nascita                      string
+		under1, under3, minori, magg int
+	}{
+		{"2025-03-01", 0, 1, 1, 0},
+		{"2025-03-02", 1, 1, 1, 0},
+		{"2023-03-01", 0, 0, 1, 0},
+		{"2023-03-02", 0, 1, 1, 0},
+		{"2008-03-01", 0, 0, 0, 1},
+		{"2008-03-02", 0, 0, 1, 0},
+		{"2005-03-01", 0, 0, 0, 0},
+		{"2005-03-02", 0, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		got := Deriva(models.UserProfile{Nucleo: []models.Componente{{Relazione: Figlio, DataNascita: tt.nascita}}}, at)
+		if got.FigliUnder1 != tt.under1 || got.FigliUnder3 != tt.under3 || got.FigliMinorenni != tt.minori || got.FigliMaggiorenni != tt.magg {
+			t.Errorf("born %s: under1=%d under3=%d minori=%d maggiorenni=%d", tt.nascita,
+				got.FigliUnder1, got.FigliUnder3, got.FigliMinorenni, got.FigliMaggiorenni)
+		}
+	}
+
+	// Without members the counters are kept as sent
+	solo := models.UserProfile{NumeroFigli: 2, FigliUnder3: 1}
+	if got := Deriva(solo, at); got.NumeroFigli != 2 || got.FigliUnder3 != 1 {
+		t.Errorf("counters without nucleo changed: %+v", got)
+	}
+
+	// A spouse without occupation leaves the flag as sent
+	p = models.UserProfile{Occupazione: "disoccupato", EntrambiGenitoriLavoratori: true,
+		Nucleo: []models.Componente{{Relazione: Coniuge, DataNascita: "1988-11-20"}}}
+	if got := Deriva(p, at); !got.EntrambiGenitoriLavoratori {
+		t.Errorf("EntrambiGenitoriLavoratori cleared without the spouse's occupation")
+	}
+}
+
+func TestDeriva_NuovoNato(t *testing.T) {
+	p := models.UserProfile{Nucleo: []models.Componente{{Relazione: Figlio, DataNascita: "2027-01-15"}}}
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), false}, // expected next year
+		{time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := Deriva(p, tt.at).NuovoNato2026; got != tt.want {
+			t.Errorf("at %s: NuovoNato2026 = %v, want %v", tt.at.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestDeriva_RedditoMaggiorenni(t *testing.T) {
+	at := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	p := models.UserProfile{Nucleo: []models.Componente{
+		{Relazione: Figlio, DataNascita: "2006-01-10"},
+		{Relazione: Figlio, DataNascita: "2007-02-20", Reddito: RedditoMaggiorenneMax},
+		{Relazione: Figlio, DataNascita: "2007-03-30", Reddito: 15000},
+	}}
+	got := Deriva(p, at)
+	if got.NumeroFigli != 3 || got.FigliMaggiorenni != 2 {
+		t.Errorf("figli %d, maggiorenni %d; want 3 and 2", got.NumeroFigli, got.FigliMaggiorenni)
+	}
+}
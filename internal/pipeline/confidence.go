@@ -6,8 +6,9 @@ import (
 )
 
 // CalculateConfidence computes a [0,1] confidence score for a bonus based on
-// pipeline verification status and data completeness.
-func CalculateConfidence(bonus *models.Bonus) float64 {
+// pipeline verification status and data completeness, with the staleness
+// penalties computed as of now.
+func CalculateConfidence(bonus *models.Bonus, now time.Time) float64 {
 	score := 0.0
 
 	// Base score: hardcoded data quality (0.15 – 0.40)
@@ -53,7 +54,6 @@ func CalculateConfidence(bonus *models.Bonus) float64 {
 	}
 
 	// Penalties
-	now := time.Now()
 
 	// Staleness penalty
 	if !bonus.UltimaVerifica.IsZero() {
@@ -28,6 +28,23 @@ type GUWatcher struct {
 
 // NewGUWatcher creates a watcher that matches GU items against the given bonuses.
 func NewGUWatcher(bonuses []models.Bonus) *GUWatcher {
+	return &GUWatcher{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		bonusRefs: buildBonusRefs(bonuses),
+		seen:      make(map[string]bool),
+	}
+}
+
+// SetBonuses replaces the norm refs to watch, keeping the dedup state so
+// GU items already handled are not reported again after a catalog reload.
+func (w *GUWatcher) SetBonuses(bonuses []models.Bonus) {
+	refs := buildBonusRefs(bonuses)
+	w.mu.Lock()
+	w.bonusRefs = refs
+	w.mu.Unlock()
+}
+
+func buildBonusRefs(bonuses []models.Bonus) map[string][]string {
 	refs := make(map[string][]string)
 	for _, b := range bonuses {
 		if len(b.RiferimentiNormativi) == 0 {
@@ -44,12 +61,7 @@ func NewGUWatcher(bonuses []models.Bonus) *GUWatcher {
 			refs[b.ID] = normalized
 		}
 	}
-
-	return &GUWatcher{
-		client: &http.Client{Timeout: 30 * time.Second},
-		bonusRefs: refs,
-		seen:      make(map[string]bool),
-	}
+	return refs
 }
 
 // Check fetches the GU RSS feed and returns events matching known bonus norm refs.
@@ -60,6 +72,10 @@ func (w *GUWatcher) Check() []GUEvent {
 		return nil
 	}
 
+	w.mu.Lock()
+	bonusRefs := w.bonusRefs
+	w.mu.Unlock()
+
 	var events []GUEvent
 	for _, item := range items {
 		// Dedup
@@ -72,7 +88,7 @@ func (w *GUWatcher) Check() []GUEvent {
 		w.mu.Unlock()
 
 		// Match against each bonus
-		for bonusID, refs := range w.bonusRefs {
+		for bonusID, refs := range bonusRefs {
 			matched, ref := MatchNormRef(item.Title, refs)
 			if !matched {
 				continue
@@ -39,6 +39,20 @@ func NewInstitutionalScraper(bonuses []models.Bonus) *InstitutionalScraper {
 	return s
 }
 
+// SetBonuses rebuilds the scrape targets after a catalog reload. Results
+// already collected are kept for bonuses that still exist.
+func (s *InstitutionalScraper) SetBonuses(bonuses []models.Bonus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.targets = make(map[string][]ScrapeURL)
+	s.buildTargets(bonuses)
+	for id := range s.results {
+		if _, ok := s.targets[id]; !ok {
+			delete(s.results, id)
+		}
+	}
+}
+
 func (s *InstitutionalScraper) buildTargets(bonuses []models.Bonus) {
 	for _, b := range bonuses {
 		var urls []ScrapeURL
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"bonusperme/internal/clock"
 	"bonusperme/internal/config"
 	"bonusperme/internal/logger"
 	"bonusperme/internal/models"
@@ -69,6 +70,40 @@ func NewOrchestrator(bonuses []models.Bonus) *Orchestrator {
 	return o
 }
 
+// Reload swaps in a new bonus set after a catalog reload. Verification
+// state gathered so far (GU/RSS/site checks, links, confidence) is carried over
+// for bonuses that are still in the catalog.
+func (o *Orchestrator) Reload(bonuses []models.Bonus) {
+	bonusCopy := make([]models.Bonus, len(bonuses))
+	copy(bonusCopy, bonuses)
+
+	o.mu.Lock()
+	byID := make(map[string]*models.Bonus)
+	for i := range bonusCopy {
+		b := &bonusCopy[i]
+		if old, ok := o.bonusByID[b.ID]; ok {
+			b.UltimaVerificaGU = old.UltimaVerificaGU
+			b.UltimaVerificaRSS = old.UltimaVerificaRSS
+			b.UltimaVerificaSito = old.UltimaVerificaSito
+			b.LinkVerificato = old.LinkVerificato
+			b.LinkVerificatoAl = old.LinkVerificatoAl
+			b.FontiCorroborate = old.FontiCorroborate
+			b.ImportoConfermato = old.ImportoConfermato
+			b.ISEEConfermata = old.ISEEConfermata
+			b.ConfidenceScore = old.ConfidenceScore
+		}
+		byID[b.ID] = b
+	}
+	o.bonuses = bonusCopy
+	o.bonusByID = byID
+	o.mu.Unlock()
+
+	o.guWatcher.SetBonuses(bonuses)
+	o.institutional.SetBonuses(bonuses)
+
+	logger.Info("pipeline: bonus set reloaded", map[string]interface{}{"bonuses": len(bonusCopy)})
+}
+
 // Start launches the pipeline tickers and event loop.
 func (o *Orchestrator) Start() {
 	logger.Info("pipeline: starting orchestrator", map[string]interface{}{
@@ -224,7 +259,7 @@ func (o *Orchestrator) runL4Single(bonusID string) {
 			b.LinkVerificato = true
 			b.LinkVerificatoAl = now.Format("2006-01-02")
 		}
-		b.ConfidenceScore = CalculateConfidence(b)
+		b.ConfidenceScore = CalculateConfidence(b, clock.Now())
 	})
 }
 
@@ -280,7 +315,7 @@ func (o *Orchestrator) handleGUEvent(evt GUEvent) {
 	case GUProroga, GURifinanziamento:
 		o.updateBonusField(evt.BonusID, func(b *models.Bonus) {
 			b.AnnoConferma = now.Year()
-			b.ConfidenceScore = CalculateConfidence(b)
+			b.ConfidenceScore = CalculateConfidence(b, clock.Now())
 		})
 		validity.AddAlert(validity.Alert{
 			BonusID:   evt.BonusID,
@@ -322,7 +357,7 @@ func (o *Orchestrator) handleRSSResult(result CorroborationResult) {
 		o.updateBonusField(result.BonusID, func(b *models.Bonus) {
 			b.FontiCorroborate = len(result.Sources)
 			b.UltimaVerificaRSS = &now
-			b.ConfidenceScore = CalculateConfidence(b)
+			b.ConfidenceScore = CalculateConfidence(b, clock.Now())
 		})
 
 	case ActionMarkExpired:
@@ -405,7 +440,7 @@ func (o *Orchestrator) handleL2Trigger(bonusID string) {
 		}
 
 		o.updateBonusField(bonusID, func(b *models.Bonus) {
-			b.ConfidenceScore = CalculateConfidence(b)
+			b.ConfidenceScore = CalculateConfidence(b, clock.Now())
 		})
 	}
 
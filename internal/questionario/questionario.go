@@ -0,0 +1,191 @@
+// Package questionario defines the questionnaire once: the profile fields
+// the wizard asks, their type, allowed values, when they are shown and how
+// they are validated. validateProfile checks profiles against it and
+// /api/questionnaire serves it, translated, to the frontend, so a new
+// profile field is added here and nowhere else.
+package questionario
+
+import (
+	"bonusperme/internal/models"
+)
+
+// Tipo is the kind of answer a question takes.
+type Tipo string
+
+const (
+	Intero  Tipo = "intero"  // whole number (age, counts)
+	Importo Tipo = "importo" // amount in euros
+	SiNo    Tipo = "si_no"
+	Scelta  Tipo = "scelta"  // one of Opzioni, "" when not answered
+	Data    Tipo = "data"    // AAAA-MM-GG
+	Regione Tipo = "regione" // ISTAT code of a region or autonomous province
+	Comune  Tipo = "comune"  // ISTAT code or name of a comune
+)
+
+// Opzione is an allowed value of a Scelta question; Etichetta is the
+// i18n key of its label.
+type Opzione struct {
+	Valore    string
+	Etichetta string
+}
+
+// Passo is a step of the wizard; Titolo is an i18n key.
+type Passo struct {
+	ID     string
+	Titolo string
+}
+
+// Domanda is a question: Campo is the JSON name of the UserProfile field
+// it fills and Etichetta the i18n key of its label. Min and Max bound
+// numbers and, for dates, are years from today. Se lists the conditions
+// on other answers for the question to be shown, in the catalog's
+// idoneita syntax. Errore is the message code returned when the answer is
+// not valid, with the bounds as min and max. Obbligatoria questions must
+// be answered in the wizard; the API also takes profiles without them.
+// Nascosta questions are not asked but filled from uploaded documents
+// (the 730 or the Certificazione Unica).
+type Domanda struct {
+	Campo        string
+	Passo        string
+	Tipo         Tipo
+	Etichetta    string
+	Obbligatoria bool
+	Nascosta     bool
+	Min, Max     *float64
+	Opzioni      []Opzione
+	Se           []models.Condizione
+	Errore       string
+}
+
+// Regola is a check across answers: the sum of the Somma fields cannot
+// exceed the Max field.
+type Regola struct {
+	Somma  []string
+	Max    string
+	Errore string
+}
+
+// MaxComune bounds the length of a comune name, as the wizard field does.
+const MaxComune = 80
+
+func num(v float64) *float64 { return &v }
+
+func vero() *bool { v := true; return &v }
+
+// Passi are the wizard steps, in order.
+var Passi = []Passo{
+	{ID: "personale", Titolo: "step1.title"},
+	{ID: "famiglia", Titolo: "step2.title"},
+	{ID: "casa", Titolo: "step4.title"},
+	{ID: "economia", Titolo: "step3.title"},
+}
+
+// Domande are the questions, in the order they are asked and checked.
+var Domande = []Domanda{
+	{Campo: "eta", Passo: "personale", Tipo: Intero, Etichetta: "label.eta", Obbligatoria: true,
+		Min: num(18), Max: num(120), Errore: "profilo.eta"},
+	{Campo: "stato_civile", Passo: "personale", Tipo: Scelta, Etichetta: "label.stato_civile", Obbligatoria: true,
+		Opzioni: []Opzione{
+			{"celibe/nubile", "opt.single"},
+			{"coniugato/a", "opt.married"},
+			{"convivente", "opt.cohabiting"},
+			{"unione civile", "opt.civil_union"},
+			{"separato/a", "opt.separated"},
+			{"divorziato/a", "opt.divorced"},
+			{"vedovo/a", "opt.widowed"},
+		}, Errore: "profilo.stato_civile"},
+	{Campo: "occupazione", Passo: "personale", Tipo: Scelta, Etichetta: "label.occupazione", Obbligatoria: true,
+		Opzioni: []Opzione{
+			{"dipendente", "opt.employee"},
+			{"autonomo", "opt.selfemployed"},
+			{"pensionato", "opt.retired"},
+			{"disoccupato", "opt.unemployed"},
+			{"inoccupato", "opt.jobseeker"},
+			{"casalinga", "opt.inactive"},
+			{"studente", "opt.student"},
+		}, Errore: "profilo.occupazione"},
+	{Campo: "studente", Passo: "personale", Tipo: SiNo, Etichetta: "label.studente"},
+
+	{Campo: "numero_figli", Passo: "famiglia", Tipo: Intero, Etichetta: "label.numero_figli", Obbligatoria: true,
+		Min: num(0), Max: num(20), Errore: "profilo.numero_figli"},
+	{Campo: "figli_minorenni", Passo: "famiglia", Tipo: Intero, Etichetta: "label.figli_minorenni",
+		Min: num(0), Max: num(20), Errore: "profilo.figli_minorenni"},
+	{Campo: "figli_under3", Passo: "famiglia", Tipo: Intero, Etichetta: "label.figli_under3",
+		Min: num(0), Max: num(20), Errore: "profilo.figli_under3"},
+	{Campo: "figli_under1", Passo: "famiglia", Tipo: Intero, Etichetta: "label.figli_under1",
+		Min: num(0), Max: num(20), Errore: "profilo.figli_under1"},
+	{Campo: "figli_maggiorenni", Passo: "famiglia", Tipo: Intero, Etichetta: "label.figli_maggiorenni",
+		Min: num(0), Max: num(20), Errore: "profilo.figli_maggiorenni"},
+	{Campo: "over65", Passo: "famiglia", Tipo: Intero, Etichetta: "label.over65",
+		Min: num(0), Max: num(10), Errore: "profilo.over65"},
+	{Campo: "figli_disabili", Passo: "famiglia", Tipo: Intero, Etichetta: "label.figli_disabili",
+		Min: num(0), Max: num(20), Errore: "profilo.figli_disabili"},
+	{Campo: "disabilita_figli", Passo: "famiglia", Tipo: Scelta, Etichetta: "label.disabilita_figli",
+		Opzioni: []Opzione{
+			{"", "opt.disab_none"},
+			{"media", "opt.disab_media"},
+			{"grave", "opt.disab_grave"},
+			{"non_autosufficienza", "opt.disab_non_autosufficienza"},
+		},
+		Se:     []models.Condizione{{Campo: "figli_disabili", Min: num(1)}},
+		Errore: "profilo.disabilita_figli"},
+	{Campo: "entrambi_genitori_lavoratori", Passo: "famiglia", Tipo: SiNo, Etichetta: "label.entrambi_genitori"},
+	{Campo: "disabilita", Passo: "famiglia", Tipo: SiNo, Etichetta: "label.disabilita"},
+	{Campo: "madre_under21", Passo: "famiglia", Tipo: SiNo, Etichetta: "label.madre_under21"},
+	{Campo: "nuovo_nato_2026", Passo: "famiglia", Tipo: SiNo, Etichetta: "label.nuovo_nato"},
+	{Campo: "data_nascita_figlio", Passo: "famiglia", Tipo: Data, Etichetta: "label.data_nascita_figlio",
+		Min: num(-30), Max: num(1),
+		Se:     []models.Condizione{{Campo: "nuovo_nato_2026", Vero: vero()}},
+		Errore: "profilo.data_nascita_figlio"},
+
+	{Campo: "residenza", Passo: "casa", Tipo: Regione, Etichetta: "label.regione", Obbligatoria: true,
+		Errore: "profilo.regione"},
+	{Campo: "comune", Passo: "casa", Tipo: Comune, Etichetta: "label.comune", Errore: "profilo.comune"},
+	{Campo: "affittuario", Passo: "casa", Tipo: SiNo, Etichetta: "label.affittuario"},
+	{Campo: "prima_abitazione", Passo: "casa", Tipo: SiNo, Etichetta: "label.prima_casa"},
+	{Campo: "ristrutturaz_casa", Passo: "casa", Tipo: SiNo, Etichetta: "label.ristrutturazione"},
+
+	{Campo: "isee", Passo: "economia", Tipo: Importo, Etichetta: "label.isee",
+		Min: num(0), Max: num(500000), Errore: "profilo.isee"},
+	{Campo: "reddito_annuo", Passo: "economia", Tipo: Importo, Etichetta: "label.reddito",
+		Min: num(0), Max: num(1000000), Errore: "profilo.reddito"},
+	{Campo: "spese_mediche", Passo: "economia", Tipo: Importo, Etichetta: "label.spese_mediche", Nascosta: true,
+		Min: num(0), Max: num(1000000), Errore: "profilo.spese"},
+	{Campo: "interessi_mutuo", Passo: "economia", Tipo: Importo, Etichetta: "label.interessi_mutuo", Nascosta: true,
+		Min: num(0), Max: num(1000000), Errore: "profilo.spese"},
+	{Campo: "spese_ristrutturazione", Passo: "economia", Tipo: Importo, Etichetta: "label.spese_ristrutturazione", Nascosta: true,
+		Min: num(0), Max: num(1000000), Errore: "profilo.spese"},
+}
+
+// Regole are the checks across answers, made after every question holds.
+var Regole = []Regola{
+	{Somma: []string{"figli_under1"}, Max: "figli_under3", Errore: "profilo.under1_oltre_under3"},
+	{Somma: []string{"figli_under3"}, Max: "figli_minorenni", Errore: "profilo.under3_oltre_minorenni"},
+	{Somma: []string{"figli_minorenni", "figli_maggiorenni"}, Max: "numero_figli", Errore: "profilo.figli_oltre_totale"},
+	{Somma: []string{"figli_disabili"}, Max: "numero_figli", Errore: "profilo.disabili_oltre_totale"},
+}
+
+// Trova returns the question filling campo.
+func Trova(campo string) (Domanda, bool) {
+	for _, d := range Domande {
+		if d.Campo == campo {
+			return d, true
+		}
+	}
+	return Domanda{}, false
+}
+
+// Ammesso reports whether v is an allowed answer of the Scelta question
+// campo; the empty answer always is.
+func Ammesso(campo, v string) bool {
+	if v == "" {
+		return true
+	}
+	d, _ := Trova(campo)
+	for _, o := range d.Opzioni {
+		if o.Valore == v {
+			return true
+		}
+	}
+	return false
+}
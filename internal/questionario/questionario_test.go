@@ -0,0 +1,148 @@
+package questionario
+
+import (
+	"bonusperme/internal/clock"
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	if err := i18n.Load("../../data/i18n"); err != nil {
+		panic(err)
+	}
+}
+
+func TestDomande(t *testing.T) {
+	campi := map[string]bool{}
+	tp := reflect.TypeOf(models.UserProfile{})
+	for i := 0; i < tp.NumField(); i++ {
+		name, _, _ := strings.Cut(tp.Field(i).Tag.Get("json"), ",")
+		campi[name] = true
+	}
+	passi := map[string]bool{}
+	for _, p := range Passi {
+		passi[p.ID] = true
+	}
+	it := i18n.T[i18n.Default]
+	for _, d := range Domande {
+		if !campi[d.Campo] {
+			t.Errorf("%s: not a profile field", d.Campo)
+		}
+		if !passi[d.Passo] {
+			t.Errorf("%s: unknown passo %q", d.Campo, d.Passo)
+		}
+		if it[d.Etichetta] == "" {
+			t.Errorf("%s: no Italian text for %s", d.Campo, d.Etichetta)
+		}
+		if d.Errore != "" && it["msg."+d.Errore] == "" {
+			t.Errorf("%s: no Italian text for msg.%s", d.Campo, d.Errore)
+		}
+		for _, o := range d.Opzioni {
+			if it[o.Etichetta] == "" {
+				t.Errorf("%s: no Italian text for %s", d.Campo, o.Etichetta)
+			}
+		}
+	}
+	for _, r := range Regole {
+		for _, c := range append([]string{r.Max}, r.Somma...) {
+			if _, ok := Trova(c); !ok {
+				t.Errorf("%s: unknown field %s", r.Errore, c)
+			}
+		}
+	}
+}
+
+func TestValida(t *testing.T) {
+	defer clock.Set(clock.Fixed(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)))()
+	for _, tc := range []struct {
+		name   string
+		p      models.UserProfile
+		codice string
+	}{
+		{"minimo", models.UserProfile{Eta: 30}, ""},
+		{"completo", models.UserProfile{Eta: 30, StatoCivile: "coniugato/a", Occupazione: "dipendente", NumeroFigli: 2,
+			FigliMinorenni: 2, FigliUnder3: 1, FigliUnder1: 1, Residenza: "Lazio", Comune: "058091", ISEE: 15000,
+			NuovoNato2026: true, DataNascitaFiglio: "2026-02-10"}, ""},
+		{"eta", models.UserProfile{Eta: 17}, "profilo.eta"},
+		{"eta mancante", models.UserProfile{}, "profilo.eta"},
+		{"scelta", models.UserProfile{Eta: 30, Occupazione: "astronauta"}, "profilo.occupazione"},
+		{"regione", models.UserProfile{Eta: 30, Residenza: "Atlantide"}, "profilo.regione"},
+		{"provincia autonoma", models.UserProfile{Eta: 30, Residenza: "021"}, ""},
+		{"comune lungo", models.UserProfile{Eta: 30, Comune: strings.Repeat("x", MaxComune+1)}, "profilo.comune"},
+		{"data", models.UserProfile{Eta: 30, DataNascitaFiglio: "10/02/2026"}, "profilo.data_nascita_figlio"},
+		{"data oltre un anno", models.UserProfile{Eta: 30, DataNascitaFiglio: "2027-07-01"}, "profilo.data_nascita_figlio"},
+		{"importo", models.UserProfile{Eta: 30, SpeseMediche: -1}, "profilo.spese"},
+		{"under1 oltre under3", models.UserProfile{Eta: 30, NumeroFigli: 2, FigliMinorenni: 2, FigliUnder1: 1}, "profilo.under1_oltre_under3"},
+		{"figli oltre totale", models.UserProfile{Eta: 30, NumeroFigli: 1, FigliMinorenni: 1, FigliMaggiorenni: 1}, "profilo.figli_oltre_totale"},
+	} {
+		msg, ok := Valida(tc.p)
+		if ok != (tc.codice == "") || msg.Code != tc.codice {
+			t.Errorf("%s: got %q, want %q", tc.name, msg.Code, tc.codice)
+		}
+	}
+
+	msg, _ := Valida(models.UserProfile{Eta: 150})
+	if got := msg.Text("en"); got != "Invalid age (18-120)" {
+		t.Errorf("message with bounds: %q", got)
+	}
+}
+
+func TestAmmesso(t *testing.T) {
+	for _, tc := range []struct {
+		campo, v string
+		want     bool
+	}{
+		{"stato_civile", "vedovo/a", true},
+		{"stato_civile", "vedovo", false},
+		{"disabilita_figli", "grave", true},
+		{"disabilita_figli", "", true},
+		{"campo_inesistente", "x", false},
+	} {
+		if got := Ammesso(tc.campo, tc.v); got != tc.want {
+			t.Errorf("Ammesso(%q, %q) = %v, want %v", tc.campo, tc.v, got, tc.want)
+		}
+	}
+}
+
+func TestEtichetta(t *testing.T) {
+	if got := Etichetta("eta", "it"); got != "Età" {
+		t.Errorf("Etichetta(eta, it) = %q", got)
+	}
+	if got := Etichetta("eta", "en"); got != "Age" {
+		t.Errorf("Etichetta(eta, en) = %q", got)
+	}
+	if got := Etichetta("nucleo", "it"); got != "" {
+		t.Errorf("field not asked: %q", got)
+	}
+}
+
+func TestLocalizzato(t *testing.T) {
+	s := Localizzato("en")
+	if len(s.Passi) != len(Passi) || len(s.Domande) != len(Domande) || len(s.Regole) != len(Regole) {
+		t.Fatalf("schema sizes: %d passi, %d domande, %d regole", len(s.Passi), len(s.Domande), len(s.Regole))
+	}
+	for _, d := range s.Domande {
+		switch d.Campo {
+		case "eta":
+			if d.Etichetta != "Age" || d.Messaggio != "Invalid age (18-120)" || d.Mancante != "Age: required field" {
+				t.Errorf("eta: %+v", d)
+			}
+		case "residenza":
+			var valori []string
+			for _, o := range d.Opzioni {
+				valori = append(valori, o.Valore)
+			}
+			if len(valori) != 21 || strings.Contains(strings.Join(valori, ","), "04") {
+				t.Errorf("regioni: %v", valori)
+			}
+		case "comune":
+			if d.Ricerca != "/api/comuni" {
+				t.Errorf("comune: %+v", d)
+			}
+		}
+	}
+}
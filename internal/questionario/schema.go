@@ -0,0 +1,138 @@
+package questionario
+
+import (
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/istat"
+	"bonusperme/internal/models"
+	"sort"
+)
+
+// Schema is the questionnaire as served by /api/questionnaire: labels and
+// error messages are in Lingua, and regions are listed as options.
+type Schema struct {
+	Lingua  string          `json:"lingua"`
+	Passi   []SchemaPasso   `json:"passi"`
+	Domande []SchemaDomanda `json:"domande"`
+	Regole  []SchemaRegola  `json:"regole"`
+}
+
+type SchemaPasso struct {
+	ID     string `json:"id"`
+	Titolo string `json:"titolo"`
+}
+
+// SchemaDomanda is a Domanda with its texts. Ricerca is the endpoint that
+// suggests answers when they are too many to list (the comuni); Mancante
+// is the message shown when an Obbligatoria question is left empty.
+type SchemaDomanda struct {
+	Campo        string              `json:"campo"`
+	Passo        string              `json:"passo"`
+	Tipo         Tipo                `json:"tipo"`
+	Etichetta    string              `json:"etichetta"`
+	Obbligatoria bool                `json:"obbligatoria"`
+	Nascosta     bool                `json:"nascosta,omitempty"`
+	Min          *float64            `json:"min,omitempty"`
+	Max          *float64            `json:"max,omitempty"`
+	Opzioni      []SchemaOpzione     `json:"opzioni,omitempty"`
+	Se           []models.Condizione `json:"se,omitempty"`
+	Ricerca      string              `json:"ricerca,omitempty"`
+	Errore       string              `json:"errore,omitempty"`
+	Messaggio    string              `json:"messaggio,omitempty"`
+	Mancante     string              `json:"mancante,omitempty"`
+}
+
+type SchemaOpzione struct {
+	Valore    string `json:"valore"`
+	Etichetta string `json:"etichetta"`
+}
+
+type SchemaRegola struct {
+	Somma     []string `json:"somma"`
+	Max       string   `json:"max"`
+	Errore    string   `json:"errore"`
+	Messaggio string   `json:"messaggio"`
+}
+
+// Localizzato returns the questionnaire with its texts in lang; texts
+// missing in lang are in Italian.
+func Localizzato(lang string) Schema {
+	s := Schema{Lingua: lang}
+	for _, p := range Passi {
+		s.Passi = append(s.Passi, SchemaPasso{ID: p.ID, Titolo: Testo(p.Titolo, lang)})
+	}
+	for _, d := range Domande {
+		sd := SchemaDomanda{
+			Campo:        d.Campo,
+			Passo:        d.Passo,
+			Tipo:         d.Tipo,
+			Etichetta:    Testo(d.Etichetta, lang),
+			Obbligatoria: d.Obbligatoria,
+			Nascosta:     d.Nascosta,
+			Min:          d.Min,
+			Max:          d.Max,
+			Se:           d.Se,
+		}
+		if d.Errore != "" {
+			sd.Errore = d.Errore
+			sd.Messaggio = d.Messaggio().Text(lang)
+		}
+		if d.Obbligatoria {
+			sd.Mancante = i18n.M("profilo.obbligatorio", "campo", sd.Etichetta).Text(lang)
+		}
+		for _, o := range d.Opzioni {
+			sd.Opzioni = append(sd.Opzioni, SchemaOpzione{Valore: o.Valore, Etichetta: Testo(o.Etichetta, lang)})
+		}
+		switch d.Tipo {
+		case Regione:
+			sd.Opzioni = regioni()
+		case Comune:
+			sd.Ricerca = "/api/comuni"
+		}
+		s.Domande = append(s.Domande, sd)
+	}
+	for _, r := range Regole {
+		s.Regole = append(s.Regole, SchemaRegola{
+			Somma:     r.Somma,
+			Max:       r.Max,
+			Errore:    r.Errore,
+			Messaggio: i18n.M(r.Errore).Text(lang),
+		})
+	}
+	return s
+}
+
+// Etichetta returns the label of the question filling campo in lang, or
+// "" when campo is not asked.
+func Etichetta(campo, lang string) string {
+	d, ok := Trova(campo)
+	if !ok {
+		return ""
+	}
+	return Testo(d.Etichetta, lang)
+}
+
+// Testo looks an interface text up in lang, then in Italian.
+func Testo(key, lang string) string {
+	if s := i18n.T[lang][key]; s != "" {
+		return s
+	}
+	return i18n.T[i18n.Default][key]
+}
+
+// regioni are the answers of a Regione question: the regions and the
+// autonomous provinces, without Trentino-Alto Adige which is split in two.
+// Names are not translated and sorted as Italian reads them.
+func regioni() []SchemaOpzione {
+	padri := make(map[string]bool)
+	for _, r := range istat.Regioni() {
+		padri[r.Padre] = true
+	}
+	var out []SchemaOpzione
+	for _, r := range istat.Regioni() {
+		if !padri[r.Codice] {
+			out = append(out, SchemaOpzione{Valore: r.Codice, Etichetta: r.Nome})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Etichetta < out[j].Etichetta })
+	return out
+}
@@ -0,0 +1,93 @@
+package questionario
+
+import (
+	"bonusperme/internal/clock"
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/istat"
+	"bonusperme/internal/models"
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// Valida checks the answers of a profile against the questions, in order,
+// then the rules across answers, and returns the message of the first
+// failure. Unanswered questions (zero values) pass unless their bounds
+// exclude zero, like the age. Hidden questions are checked too.
+func Valida(p models.UserProfile) (i18n.Message, bool) {
+	risposte := Risposte(p)
+	for _, d := range Domande {
+		if !d.valida(risposte[d.Campo]) {
+			return d.Messaggio(), false
+		}
+	}
+	for _, r := range Regole {
+		somma := 0.0
+		for _, c := range r.Somma {
+			somma += numero(risposte[c])
+		}
+		if somma > numero(risposte[r.Max]) {
+			return i18n.M(r.Errore), false
+		}
+	}
+	return i18n.Message{}, true
+}
+
+// Messaggio is the error of the question, with its bounds.
+func (d Domanda) Messaggio() i18n.Message {
+	if d.Tipo == Data || d.Min == nil || d.Max == nil {
+		return i18n.M(d.Errore)
+	}
+	return i18n.M(d.Errore, "min", intero(*d.Min), "max", intero(*d.Max))
+}
+
+func (d Domanda) valida(v interface{}) bool {
+	switch d.Tipo {
+	case Intero, Importo:
+		x := numero(v)
+		return (d.Min == nil || x >= *d.Min) && (d.Max == nil || x <= *d.Max)
+	case Scelta:
+		s, _ := v.(string)
+		return Ammesso(d.Campo, s)
+	case Data:
+		s, _ := v.(string)
+		if s == "" {
+			return true
+		}
+		t, err := time.Parse("2006-01-02", s)
+		oggi := clock.Now()
+		return err == nil && (d.Min == nil || !t.Before(oggi.AddDate(int(*d.Min), 0, 0))) &&
+			(d.Max == nil || !t.After(oggi.AddDate(int(*d.Max), 0, 0)))
+	case Regione:
+		s, _ := v.(string)
+		return s == "" || istat.CodiceRegione(s) != ""
+	case Comune:
+		// The embedded list is not complete yet: a comune it does not know
+		// is kept and only gets no comune-level bonuses
+		s, _ := v.(string)
+		return len(s) <= MaxComune
+	}
+	return true
+}
+
+// Risposte returns the profile fields by JSON name, as decoded from JSON
+// (numbers are float64).
+func Risposte(p models.UserProfile) map[string]interface{} {
+	raw, _ := json.Marshal(p)
+	var m map[string]interface{}
+	json.Unmarshal(raw, &m)
+	return m
+}
+
+func numero(v interface{}) float64 {
+	x, _ := v.(float64)
+	return x
+}
+
+// intero shows whole bounds without decimals or exponent in messages.
+func intero(x float64) interface{} {
+	if x == math.Trunc(x) {
+		return int64(x)
+	}
+	return x
+}
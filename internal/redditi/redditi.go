@@ -0,0 +1,151 @@
+// Package redditi reads income, employment type and deductible expenses
+// from the text layer of a Certificazione Unica or 730 PDF. Only figures
+// are extracted: personal data printed on the form is never returned.
+package redditi
+
+import (
+	"bonusperme/internal/dsu"
+	"bonusperme/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Modelli.
+const (
+	ModelloCU  = "cu"
+	Modello730 = "730"
+)
+
+// Dichiarazione are the values read from a CU or 730. Zero values mean the
+// field was not found.
+type Dichiarazione struct {
+	Modello               string  `json:"modello,omitempty"`
+	AnnoImposta           int     `json:"anno_imposta,omitempty"`
+	RedditoLordo          float64 `json:"reddito_lordo,omitempty"`
+	RedditoImponibile     float64 `json:"reddito_imponibile,omitempty"`
+	Occupazione           string  `json:"occupazione,omitempty"`
+	SpeseMediche          float64 `json:"spese_mediche,omitempty"`
+	InteressiMutuo        float64 `json:"interessi_mutuo,omitempty"`
+	SpeseRistrutturazione float64 `json:"spese_ristrutturazione,omitempty"`
+}
+
+// importo matches Italian amounts: 25.000,00 or 25000,00.
+const importo = `(\d{1,3}(?:\.\d{3})+,\d{2}|\d+,\d{2})`
+
+// etichetta builds the pattern of a labelled amount. The gap may hold
+// references such as "(punto 1)" or "rigo E1", but not another label.
+func etichetta(label string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)(?:` + label + `)(.{0,60}?)` + importo)
+}
+
+var (
+	reCU          = regexp.MustCompile(`(?i)certificazione unica`)
+	re730         = regexp.MustCompile(`(?i)modello 730|730-3|prospetto di liquidazione`)
+	reAnno        = regexp.MustCompile(`(?i)(?:periodo|anno) d.imposta:? ?(\d{4})`)
+	reAnnoCU      = regexp.MustCompile(`(?i)certificazione unica (\d{4})`)
+	reAnno730     = regexp.MustCompile(`(?i)modello 730[/ -]?(\d{4})`)
+	reAltro       = regexp.MustCompile(`(?i)reddit[oi]|spese|interessi|imponibile|ammontare|recupero del patrimonio`)
+	reDip         = etichetta(`redditi di lavoro dipendente(?: e assimilati)?`)
+	rePensione    = etichetta(`redditi di pensione`)
+	reAutonomo    = etichetta(`ammontare lordo corrisposto`)
+	reComplesso   = etichetta(`reddito complessivo`)
+	reImponibile  = etichetta(`reddito imponibile|imponibile (?:ai fini )?irpef`)
+	reSanitarie   = etichetta(`spese sanitarie`)
+	reMutuo       = etichetta(`interessi (?:passivi )?(?:per|su) mutu[oi]`)
+	reRistruttura = etichetta(`recupero del patrimonio edilizio|ristrutturazione edilizia`)
+)
+
+// Parse extracts the values from the text of a CU or 730.
+func Parse(text string) Dichiarazione {
+	text = strings.Join(strings.Fields(text), " ")
+	var d Dichiarazione
+
+	switch {
+	case re730.MatchString(text):
+		d.Modello = Modello730
+	case reCU.MatchString(text):
+		d.Modello = ModelloCU
+	}
+
+	if m := reAnno.FindStringSubmatch(text); m != nil {
+		d.AnnoImposta, _ = strconv.Atoi(m[1])
+	} else if m := reAnno730.FindStringSubmatch(text); m != nil && d.Modello == Modello730 {
+		// the 730 of year N declares the income of year N-1
+		a, _ := strconv.Atoi(m[1])
+		d.AnnoImposta = a - 1
+	} else if m := reAnnoCU.FindStringSubmatch(text); m != nil {
+		a, _ := strconv.Atoi(m[1])
+		d.AnnoImposta = a - 1
+	}
+
+	dip := primo(reDip, text)
+	pensione := primo(rePensione, text)
+	autonomo := primo(reAutonomo, text)
+	switch {
+	case pensione > 0 && pensione >= dip:
+		d.Occupazione = "pensionato"
+	case dip > 0:
+		d.Occupazione = "dipendente"
+	case autonomo > 0:
+		d.Occupazione = "autonomo"
+	}
+
+	// The 730 states the total income; the CU only the single items
+	d.RedditoLordo = primo(reComplesso, text)
+	if d.RedditoLordo == 0 {
+		d.RedditoLordo = dip + pensione + autonomo
+	}
+	d.RedditoImponibile = primo(reImponibile, text)
+
+	d.SpeseMediche = primo(reSanitarie, text)
+	d.InteressiMutuo = primo(reMutuo, text)
+	d.SpeseRistrutturazione = primo(reRistruttura, text)
+	return d
+}
+
+// Reddito returns the income to use as RedditoAnnuo: the taxable income
+// when stated, otherwise the gross income.
+func (d Dichiarazione) Reddito() float64 {
+	if d.RedditoImponibile > 0 {
+		return d.RedditoImponibile
+	}
+	return d.RedditoLordo
+}
+
+// Trovato reports whether any value was read.
+func (d Dichiarazione) Trovato() bool {
+	return d.Reddito() > 0 || d.Occupazione != "" || d.SpeseMediche > 0 ||
+		d.InteressiMutuo > 0 || d.SpeseRistrutturazione > 0
+}
+
+// Applica fills the profile with the values found, leaving the others
+// untouched.
+func (d Dichiarazione) Applica(p *models.UserProfile) {
+	if r := d.Reddito(); r > 0 {
+		p.RedditoAnnuo = r
+	}
+	if d.Occupazione != "" {
+		p.Occupazione = d.Occupazione
+	}
+	if d.SpeseMediche > 0 {
+		p.SpeseMediche = d.SpeseMediche
+	}
+	if d.InteressiMutuo > 0 {
+		p.InteressiMutuo = d.InteressiMutuo
+	}
+	if d.SpeseRistrutturazione > 0 {
+		p.SpeseRistrutturazione = d.SpeseRistrutturazione
+	}
+}
+
+// primo returns the first amount of a label whose gap doesn't contain
+// another label, 0 if none.
+func primo(re *regexp.Regexp, text string) float64 {
+	for _, m := range re.FindAllStringSubmatch(text, -1) {
+		if !reAltro.MatchString(m[1]) {
+			return dsu.ParseImporto(m[2])
+		}
+	}
+	return 0
+}
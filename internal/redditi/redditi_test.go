@@ -0,0 +1,58 @@
+package redditi
+
+import (
+	"bonusperme/internal/models"
+	"testing"
+)
+
+func TestParse_730(t *testing.T) {
+	d := Parse(`MODELLO 730-3 - Prospetto di liquidazione - Periodo d'imposta 2025
+Redditi di lavoro dipendente e assimilati (rigo C1) 28.500,00
+Reddito complessivo (rigo 11) 29.200,00
+Reddito imponibile 27.900,00
+E1 Spese sanitarie 1.329,11
+E7 Interessi passivi per mutui ipotecari 3.000,00
+E41 Spese per recupero del patrimonio edilizio 20.000,00`)
+	want := Dichiarazione{
+		Modello: Modello730, AnnoImposta: 2025, RedditoLordo: 29200, RedditoImponibile: 27900,
+		Occupazione: "dipendente", SpeseMediche: 1329.11, InteressiMutuo: 3000, SpeseRistrutturazione: 20000,
+	}
+	if d != want {
+		t.Errorf("Parse:\n got %+v\nwant %+v", d, want)
+	}
+	if d.Reddito() != 27900 || !d.Trovato() {
+		t.Errorf("Reddito = %v, Trovato = %v", d.Reddito(), d.Trovato())
+	}
+}
+
+func TestParse_CU(t *testing.T) {
+	// No total income on the CU: the items are added up; the year of the
+	// form is the one after the income
+	d := Parse(`CERTIFICAZIONE UNICA 2026
+Redditi di lavoro dipendente (punto 1) 12.000,00
+Redditi di pensione (punto 3) 14.500,00`)
+	if d.Modello != ModelloCU || d.AnnoImposta != 2025 || d.Occupazione != "pensionato" || d.RedditoLordo != 26500 {
+		t.Errorf("CU: %+v", d)
+	}
+	if d.Reddito() != 26500 {
+		t.Errorf("Reddito = %v, want the gross income", d.Reddito())
+	}
+
+	// A label followed by another label takes no amount
+	d = Parse("Certificazione Unica - Redditi di lavoro dipendente, reddito imponibile 18.000,00")
+	if d.Occupazione != "" || d.RedditoImponibile != 18000 {
+		t.Errorf("gap with another label: %+v", d)
+	}
+
+	if d := Parse("documento senza importi"); d.Trovato() {
+		t.Errorf("empty document: %+v", d)
+	}
+}
+
+func TestApplica(t *testing.T) {
+	p := models.UserProfile{RedditoAnnuo: 10000, Occupazione: "autonomo", InteressiMutuo: 500}
+	Dichiarazione{RedditoLordo: 22000, Occupazione: "dipendente", SpeseMediche: 300}.Applica(&p)
+	if p.RedditoAnnuo != 22000 || p.Occupazione != "dipendente" || p.SpeseMediche != 300 || p.InteressiMutuo != 500 {
+		t.Errorf("Applica: %+v", p)
+	}
+}
@@ -2,6 +2,7 @@ package scraper
 
 import (
 	"bonusperme/internal/config"
+	"bonusperme/internal/istat"
 	"bonusperme/internal/logger"
 	"bonusperme/internal/matcher"
 	"bonusperme/internal/models"
@@ -9,7 +10,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"time"
 )
 
@@ -131,14 +131,16 @@ func parseRegionalGeneric(body string, regione string) ([]models.Bonus, error) {
 	return nil, fmt.Errorf("parser regionale non ancora implementato per %s", regione)
 }
 
-// GetHardcodedRegionalForRegione returns hardcoded regionals for a specific region.
+// GetHardcodedRegionalForRegione returns hardcoded regionals for a specific
+// region, given by ISTAT code or name; a region includes the bonuses of its
+// autonomous provinces.
 func GetHardcodedRegionalForRegione(regione string) []models.Bonus {
 	all := matcher.GetRegionalBonus()
 	var result []models.Bonus
-	regioneLower := strings.ToLower(regione)
+	codice := istat.CodiceRegione(regione)
 	for _, b := range all {
 		for _, r := range b.RegioniApplicabili {
-			if strings.ToLower(r) == regioneLower {
+			if r = istat.CodiceRegione(r); istat.Copre(r, codice) || istat.Copre(codice, r) {
 				result = append(result, b)
 				break
 			}
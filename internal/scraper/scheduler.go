@@ -24,6 +24,7 @@ type SourceStatus struct {
 type BonusCache struct {
 	mu            sync.RWMutex
 	bonus         []models.Bonus
+	scraped       []models.Bonus // raw scraped data, re-applied on catalog reload
 	lastUpdate    time.Time
 	updateCount   int
 	sourcesStatus map[string]SourceStatus
@@ -315,6 +316,7 @@ func RunScrape() {
 
 	cache.mu.Lock()
 	cache.bonus = enriched
+	cache.scraped = allScraped
 	cache.lastUpdate = time.Now()
 	cache.updateCount++
 	cache.mu.Unlock()
@@ -326,8 +328,28 @@ func RunScrape() {
 	}
 }
 
+// RefreshCatalog re-applies the last scraped data on top of the current
+// catalog, so a catalog reload is served immediately instead of after the
+// next scrape cycle. Called from catalog.OnReload.
+func RefreshCatalog() {
+	cache.mu.RLock()
+	scraped := cache.scraped
+	populated := len(cache.bonus) > 0
+	cache.mu.RUnlock()
+	if !populated {
+		return // GetCachedBonus already reads the catalog directly
+	}
+
+	enriched := EnrichBonusData(scraped, matcher.GetAllBonus())
+
+	cache.mu.Lock()
+	cache.bonus = enriched
+	cache.mu.Unlock()
+	logger.Info("scraper: cache refreshed after catalog reload", map[string]interface{}{"total": len(enriched)})
+}
+
 // GetCachedBonus returns the cached list of bonuses.
-// Falls back to hardcoded if cache is empty.
+// Falls back to the catalog if cache is empty.
 func GetCachedBonus() []models.Bonus {
 	cache.mu.RLock()
 	defer cache.mu.RUnlock()
@@ -0,0 +1,252 @@
+package translate
+
+import (
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/i18n"
+	"bonusperme/internal/models"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Result summarises an import.
+type Result struct {
+	Applied   int // translations written
+	Unchanged int // already in the data files
+	Skipped   int // empty, fuzzy or needing review
+	// Outdated lists keys translated from an Italian text that has since
+	// changed: they are imported, and Parity reports them as stale.
+	Outdated []string
+	// Rejected lists the units that could not be imported, with the reason.
+	Rejected []string
+}
+
+// Import writes the translated units of lang into the UI catalog and the
+// bonus translation files. Units that fail a check are rejected one by
+// one; the rest are imported. Bonus lists (requisiti, FAQ, ...) are
+// written only once every item is translated, as the catalog falls back to
+// Italian for a whole list.
+func Import(d Dirs, lang string, units []Unit) (Result, error) {
+	var res Result
+	if !i18n.Supported(lang) || lang == i18n.Default {
+		return res, fmt.Errorf("translate: %q is not a target language", lang)
+	}
+	byBonus := make(map[string][]Unit)
+	var ui []Unit
+	for _, u := range units {
+		if id, _, ok := splitBonusKey(u.Key); ok {
+			byBonus[id] = append(byBonus[id], u)
+		} else {
+			ui = append(ui, u)
+		}
+	}
+	if err := importUI(d.I18n, lang, ui, &res); err != nil {
+		return res, err
+	}
+	if len(byBonus) == 0 {
+		return res, nil
+	}
+
+	snap, err := catalog.Read(d.Catalog)
+	if err != nil {
+		return res, err
+	}
+	ids := make([]string, 0, len(byBonus))
+	for id := range byBonus {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		b, ok := lookup(snap, id)
+		if !ok {
+			for _, u := range byBonus[id] {
+				res.Rejected = append(res.Rejected, u.Key+": unknown bonus")
+			}
+			continue
+		}
+		if err := importBonus(d.Catalog, lang, b, byBonus[id], &res); err != nil {
+			return res, err
+		}
+	}
+	// The catalog must still load: the watch would otherwise keep the
+	// previous snapshot in service.
+	_, err = catalog.Read(d.Catalog)
+	return res, err
+}
+
+// accept checks a unit against its current Italian source and records
+// why it is not imported. It returns the fingerprint to store as origine.
+func accept(u Unit, source string, known bool, res *Result) (string, bool) {
+	switch {
+	case !known:
+		res.Rejected = append(res.Rejected, u.Key+": unknown key")
+		return "", false
+	case u.Target == "" || u.Stale:
+		res.Skipped++
+		return "", false
+	}
+	if err := checkPlaceholders(u.Key, source, u.Target); err != nil {
+		res.Rejected = append(res.Rejected, u.Key+": "+err.Error())
+		return "", false
+	}
+	if u.Source != source {
+		res.Outdated = append(res.Outdated, u.Key)
+	}
+	return i18n.Fingerprint(u.Source), true
+}
+
+func importUI(dir, lang string, units []Unit, res *Result) error {
+	if len(units) == 0 {
+		return nil
+	}
+	it, err := i18n.ReadFile(dir, i18n.Default)
+	if err != nil {
+		return err
+	}
+	f, err := i18n.ReadFile(dir, lang)
+	if err != nil {
+		return err
+	}
+	sources := make(map[string]string)
+	for _, s := range uiSources(it, lang) {
+		sources[s.key] = s.text
+	}
+	if f.Origine == nil {
+		f.Origine = make(map[string]string)
+	}
+	changed := false
+	for _, u := range units {
+		source, known := sources[u.Key]
+		origin, ok := accept(u, source, known, res)
+		if !ok {
+			continue
+		}
+		if f.Testi[u.Key] == u.Target && f.Origine[u.Key] == origin {
+			res.Unchanged++
+			continue
+		}
+		f.Testi[u.Key], f.Origine[u.Key] = u.Target, origin
+		res.Applied++
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return i18n.WriteFile(dir, f)
+}
+
+func importBonus(root, lang string, b models.Bonus, units []Unit, res *Result) error {
+	t, err := catalog.ReadTranslation(root, lang, b.ID)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err != nil {
+		t = catalog.TranslationFile{Schema: catalog.SchemaVersion, Bonus: b.ID}
+	}
+	sources := fieldMap(italian(b))
+	texts := fieldMap(t.BonusTrad)
+	origins := make(map[string]string)
+	for k, v := range t.Origine {
+		origins[k] = v
+	}
+
+	applied := 0
+	for _, u := range units {
+		_, key, _ := splitBonusKey(u.Key)
+		source, known := sources[key]
+		origin, ok := accept(u, source, known, res)
+		if !ok {
+			continue
+		}
+		if texts[key] == u.Target && origins[key] == origin {
+			res.Unchanged++
+			continue
+		}
+		texts[key], origins[key] = u.Target, origin
+		applied++
+	}
+	if applied == 0 {
+		return nil
+	}
+
+	it := italian(b)
+	out := models.BonusTrad{
+		Nome: texts["nome"], Descrizione: texts["descrizione"],
+		Importo: texts["importo"], Scadenza: texts["scadenza"],
+	}
+	var incomplete []string
+	complete := func(name string, keys []string) bool {
+		n := 0
+		for _, k := range keys {
+			if texts[k] != "" {
+				n++
+			}
+		}
+		if n > 0 && n < len(keys) {
+			incomplete = append(incomplete, name)
+		}
+		return n > 0 && n == len(keys)
+	}
+	list := func(name string, n int) []string {
+		keys := make([]string, n)
+		for i := range keys {
+			keys[i] = name + "." + strconv.Itoa(i)
+		}
+		if !complete(name, keys) {
+			return nil
+		}
+		items := make([]string, n)
+		for i, k := range keys {
+			items[i] = texts[k]
+		}
+		return items
+	}
+	out.Requisiti = list("requisiti", len(it.Requisiti))
+	out.ComeRichiederlo = list("come_richiederlo", len(it.ComeRichiederlo))
+	out.Documenti = list("documenti", len(it.Documenti))
+	var faqKeys []string
+	for i := range it.FAQ {
+		p := "faq." + strconv.Itoa(i)
+		faqKeys = append(faqKeys, p+".domanda", p+".risposta")
+	}
+	if complete("faq", faqKeys) {
+		for i := 0; i < len(faqKeys); i += 2 {
+			out.FAQ = append(out.FAQ, models.FAQ{Domanda: texts[faqKeys[i]], Risposta: texts[faqKeys[i+1]]})
+		}
+	}
+	for id := range it.Motivi {
+		if s := texts["motivi."+id]; s != "" {
+			if out.Motivi == nil {
+				out.Motivi = make(map[string]string)
+			}
+			out.Motivi[id] = s
+		}
+	}
+	if out.Descrizione == "" {
+		res.Rejected = append(res.Rejected, bonusPrefix+b.ID+": descrizione is required to create the translation")
+		return nil
+	}
+	for _, name := range incomplete {
+		res.Rejected = append(res.Rejected, fmt.Sprintf("%s%s:%s: not every item is translated, the list stays in Italian", bonusPrefix, b.ID, name))
+	}
+
+	t.BonusTrad = out
+	t.Origine = make(map[string]string)
+	for _, f := range fields(out) {
+		if o := origins[f.key]; o != "" {
+			t.Origine[f.key] = o
+		}
+	}
+	res.Applied += applied
+	return catalog.WriteTranslation(root, lang, b.Nome, t)
+}
+
+func lookup(snap *catalog.Snapshot, id string) (models.Bonus, bool) {
+	for _, b := range snap.Bonuses() {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return models.Bonus{}, false
+}
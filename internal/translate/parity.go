@@ -0,0 +1,146 @@
+package translate
+
+import (
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/i18n"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Issue is a key out of parity in one language.
+type Issue struct {
+	Lingua   string `json:"lingua"`
+	Chiave   string `json:"chiave"`
+	Problema string `json:"problema"` // mancante, extra or obsoleta
+}
+
+// Problems reported by Parity.
+const (
+	Missing = "mancante"
+	Extra   = "extra"
+	Stale   = "obsoleta"
+)
+
+// i18nAttrRe finds the keys the static pages look up in the UI catalog
+// (data-i18n-html boxes are filled by the page script itself).
+var i18nAttrRe = regexp.MustCompile(`data-i18n="([^"]+)"`)
+
+// Parity checks every language against Italian. For the UI catalog a key
+// is missing, extra or stale (its Italian text changed after it was
+// translated); Italian itself must define every key the pages under
+// staticDir use, if staticDir is not empty. For bonuses only translated
+// ones are checked, field by field: untranslated bonuses are a matter of
+// coverage (catalog.Snapshot.TranslationCoverage), and name, amount and
+// deadline may stay in Italian.
+func Parity(d Dirs, staticDir string) ([]Issue, error) {
+	it, err := i18n.ReadFile(d.I18n, i18n.Default)
+	if err != nil {
+		return nil, err
+	}
+	var issues []Issue
+	if staticDir != "" {
+		used, err := pageKeys(staticDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range used {
+			if _, ok := it.Testi[k]; !ok {
+				issues = append(issues, Issue{i18n.Default, k, Missing})
+			}
+		}
+	}
+
+	snap, err := catalog.Read(d.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	for _, lang := range i18n.Languages {
+		if lang == i18n.Default {
+			continue
+		}
+		f, err := i18n.ReadFile(d.I18n, lang)
+		if err != nil {
+			return nil, err
+		}
+		want := make(map[string]bool)
+		for _, s := range uiSources(it, lang) {
+			want[s.key] = true
+			switch t, ok := f.Testi[s.key]; {
+			case !ok || t == "":
+				issues = append(issues, Issue{lang, s.key, Missing})
+			case stale(f.Origine[s.key], s.text):
+				issues = append(issues, Issue{lang, s.key, Stale})
+			}
+		}
+		for k := range f.Testi {
+			if !want[k] {
+				issues = append(issues, Issue{lang, k, Extra})
+			}
+		}
+
+		for _, b := range bonuses(snap) {
+			if _, ok := b.Traduzioni[lang]; !ok {
+				continue
+			}
+			t, err := catalog.ReadTranslation(d.Catalog, lang, b.ID)
+			if err != nil {
+				return nil, err
+			}
+			targets := fieldMap(t.BonusTrad)
+			sources := fields(italian(b))
+			known := make(map[string]bool)
+			for _, s := range sources {
+				known[s.key] = true
+				key := bonusPrefix + b.ID + ":" + s.key
+				switch {
+				case targets[s.key] == "":
+					if s.key != "nome" && s.key != "importo" && s.key != "scadenza" {
+						issues = append(issues, Issue{lang, key, Missing})
+					}
+				case stale(t.Origine[s.key], s.text):
+					issues = append(issues, Issue{lang, key, Stale})
+				}
+			}
+			for k := range targets {
+				if !known[k] {
+					issues = append(issues, Issue{lang, bonusPrefix + b.ID + ":" + k, Extra})
+				}
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Lingua != issues[j].Lingua {
+			return issues[i].Lingua < issues[j].Lingua
+		}
+		return issues[i].Chiave < issues[j].Chiave
+	})
+	return issues, nil
+}
+
+// pageKeys returns the data-i18n keys of the HTML files in dir.
+func pageKeys(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var keys []string
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range i18nAttrRe.FindAllStringSubmatch(string(data), -1) {
+			if k := strings.TrimSpace(m[1]); !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,127 @@
+package translate
+
+import (
+	"bonusperme/internal/i18n"
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WritePO writes units as a gettext PO file: msgctxt is the key, msgid the
+// Italian text and msgstr the translation.
+func WritePO(w io.Writer, lang string, units []Unit) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# BonusPerMe — %s\n", i18n.Names[lang])
+	fmt.Fprintf(bw, "msgid \"\"\nmsgstr \"\"\n")
+	fmt.Fprintf(bw, "\"Project-Id-Version: bonusperme\\n\"\n")
+	fmt.Fprintf(bw, "\"Language: %s\\n\"\n", lang)
+	fmt.Fprintf(bw, "\"MIME-Version: 1.0\\n\"\n")
+	fmt.Fprintf(bw, "\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	fmt.Fprintf(bw, "\"Content-Transfer-Encoding: 8bit\\n\"\n")
+	for _, u := range units {
+		bw.WriteString("\n")
+		if u.Note != "" {
+			fmt.Fprintf(bw, "#. %s\n", u.Note)
+		}
+		if u.Stale {
+			bw.WriteString("#, fuzzy\n")
+		}
+		fmt.Fprintf(bw, "msgctxt %s\nmsgid %s\nmsgstr %s\n", poQuote(u.Key), poQuote(u.Source), poQuote(u.Target))
+	}
+	return bw.Flush()
+}
+
+func poQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(s) + `"`
+}
+
+// ReadPO reads a PO file written by WritePO and edited by a translator. It
+// returns the language of its header and the entries; fuzzy entries come
+// back as Stale.
+func ReadPO(r io.Reader) (lang string, units []Unit, err error) {
+	var (
+		cur    Unit
+		field  *string
+		hasStr bool // the current entry has its msgstr: what follows starts the next one
+	)
+	flush := func() {
+		if hasStr {
+			if cur.Key == "" && cur.Source == "" {
+				for _, line := range strings.Split(cur.Target, "\n") {
+					if v, ok := strings.CutPrefix(line, "Language:"); ok {
+						lang = strings.TrimSpace(v)
+					}
+				}
+			} else {
+				units = append(units, cur)
+			}
+		}
+		cur, field, hasStr = Unit{}, nil, false
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if hasStr {
+				flush()
+			}
+			if strings.HasPrefix(line, "#,") && strings.Contains(line, "fuzzy") {
+				cur.Stale = true
+			}
+			continue
+		}
+		if strings.HasPrefix(line, `"`) {
+			s, err := poUnquote(line)
+			if err != nil || field == nil {
+				return "", nil, fmt.Errorf("po: line %d: unexpected string", n)
+			}
+			*field += s
+			continue
+		}
+
+		kw, rest, _ := strings.Cut(line, " ")
+		s, err := poUnquote(strings.TrimSpace(rest))
+		if err != nil {
+			return "", nil, fmt.Errorf("po: line %d: %w", n, err)
+		}
+		switch kw {
+		case "msgctxt", "msgid":
+			if hasStr {
+				flush()
+			}
+			field = &cur.Key
+			if kw == "msgid" {
+				field = &cur.Source
+			}
+		case "msgstr":
+			field, hasStr = &cur.Target, true
+		default:
+			return "", nil, fmt.Errorf("po: line %d: unsupported keyword %q", n, kw)
+		}
+		*field = s
+	}
+	if err := sc.Err(); err != nil {
+		return "", nil, err
+	}
+	flush()
+	for _, u := range units {
+		if u.Key == "" {
+			return "", nil, fmt.Errorf("po: entry %q has no msgctxt", u.Source)
+		}
+	}
+	return lang, units, nil
+}
+
+func poUnquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("expected a quoted string, got %q", s)
+	}
+	return strconv.Unquote(s)
+}
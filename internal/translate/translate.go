@@ -0,0 +1,243 @@
+// Package translate moves the translatable texts of BonusPerMe in and out
+// of the formats translators work with. Export writes, for one language,
+// every UI key of data/i18n and every text field of the bonus catalog as a
+// PO or XLIFF file; Import writes the translated file back into the same
+// data files; Parity reports keys missing, extra or stale in any language.
+//
+// A unit is identified by its key: the UI key itself ("hero.cta",
+// "msg.profilo.eta#one") or "bonus:<id>:<field>" for a bonus field, with
+// list items and FAQ numbered from 0 ("bonus:assegno-unico:requisiti.2",
+// "bonus:assegno-unico:faq.0.risposta") and relation reasons by the ID of
+// the related bonus ("bonus:sfl:motivi.adi"). A translation is stale when the
+// Italian text has changed since it was translated: the data files keep
+// the i18n.Fingerprint of the source of every imported text.
+package translate
+
+import (
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/i18n"
+	_ "bonusperme/internal/matcher" // registers the calcoli the catalog validates against
+	"bonusperme/internal/models"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unit is one translatable text.
+type Unit struct {
+	Key    string
+	Source string // Italian text
+	Target string // translation, "" when missing
+	// Stale marks a translation made from a different Source. Exported
+	// as fuzzy (PO) or needs-review-translation (XLIFF); Import skips it.
+	Stale bool
+	Note  string // context for the translator
+}
+
+const bonusPrefix = "bonus:"
+
+// Dirs are the data files a translation is read from and written to.
+type Dirs struct {
+	I18n    string // data/i18n
+	Catalog string // data/catalog
+}
+
+// Units collects the texts of lang with their current translation.
+func Units(d Dirs, lang string) ([]Unit, error) {
+	if !i18n.Supported(lang) || lang == i18n.Default {
+		return nil, fmt.Errorf("translate: %q is not a target language", lang)
+	}
+	it, err := i18n.ReadFile(d.I18n, i18n.Default)
+	if err != nil {
+		return nil, err
+	}
+	tr, err := i18n.ReadFile(d.I18n, lang)
+	if err != nil {
+		return nil, err
+	}
+	var units []Unit
+	for _, s := range uiSources(it, lang) {
+		u := Unit{Key: s.key, Source: s.text, Target: tr.Testi[s.key], Note: s.note}
+		u.Stale = u.Target != "" && stale(tr.Origine[s.key], s.text)
+		units = append(units, u)
+	}
+
+	snap, err := catalog.Read(d.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bonuses(snap) {
+		t, err := catalog.ReadTranslation(d.Catalog, lang, b.ID)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		targets := fieldMap(t.BonusTrad)
+		for _, f := range fields(italian(b)) {
+			u := Unit{Key: bonusPrefix + b.ID + ":" + f.key, Source: f.text, Target: targets[f.key], Note: b.Nome}
+			u.Stale = u.Target != "" && stale(t.Origine[f.key], f.text)
+			units = append(units, u)
+		}
+	}
+	return units, nil
+}
+
+// stale reports whether a translation recorded as made from origin no
+// longer matches source. Texts without origin (written by hand) are
+// trusted.
+func stale(origin, source string) bool {
+	return origin != "" && origin != i18n.Fingerprint(source)
+}
+
+type source struct {
+	key, text, note string
+}
+
+// uiSources lists the UI keys lang must translate. Plural messages need
+// the forms of lang, which are not those of Italian: each is translated
+// from the Italian form of the same category, else from "other".
+func uiSources(it i18n.File, lang string) []source {
+	var out []source
+	plurals := make(map[string]bool)
+	for key, text := range it.Testi {
+		base, _, plural := strings.Cut(key, "#")
+		if !plural {
+			out = append(out, source{key: key, text: text, note: placeholderNote(text)})
+			continue
+		}
+		if plurals[base] {
+			continue
+		}
+		plurals[base] = true
+		for _, cat := range i18n.PluralCategories(lang) {
+			text, ok := it.Testi[base+"#"+cat]
+			if !ok {
+				text = it.Testi[base+"#other"]
+			}
+			note := fmt.Sprintf("Plural form %q: {n} is the count.", cat)
+			out = append(out, source{key: base + "#" + cat, text: text, note: note})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key < out[j].key })
+	return out
+}
+
+var placeholderRe = regexp.MustCompile(`\{[a-z_]+\}`)
+
+func placeholderNote(text string) string {
+	if p := placeholderRe.FindAllString(text, -1); len(p) > 0 {
+		return "Keep the placeholders: " + strings.Join(p, ", ")
+	}
+	return ""
+}
+
+// checkPlaceholders rejects a translation that drops or invents a {name}
+// of its source. Plural forms may leave out {n} ("one day").
+func checkPlaceholders(key, source, target string) error {
+	want := make(map[string]bool)
+	for _, p := range placeholderRe.FindAllString(source, -1) {
+		want[p] = true
+	}
+	got := make(map[string]bool)
+	for _, p := range placeholderRe.FindAllString(target, -1) {
+		if !want[p] {
+			return fmt.Errorf("unknown placeholder %s", p)
+		}
+		got[p] = true
+	}
+	for p := range want {
+		if !got[p] && !(p == "{n}" && strings.Contains(key, "#")) {
+			return fmt.Errorf("missing placeholder %s", p)
+		}
+	}
+	return nil
+}
+
+// bonuses returns the catalog bonuses sorted by ID.
+func bonuses(snap *catalog.Snapshot) []models.Bonus {
+	all := snap.Bonuses()
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all
+}
+
+// italian returns the translatable fields of a catalog entry.
+func italian(b models.Bonus) models.BonusTrad {
+	return models.BonusTrad{
+		Nome: b.Nome, Descrizione: b.Descrizione, Importo: b.Importo, Scadenza: b.Scadenza,
+		Requisiti: b.Requisiti, ComeRichiederlo: b.ComeRichiederlo, Documenti: b.Documenti, FAQ: b.FAQ,
+		Motivi: motivi(b),
+	}
+}
+
+// motivi returns the reasons of the relations of a bonus by related ID.
+func motivi(b models.Bonus) map[string]string {
+	var out map[string]string
+	for _, r := range append(append([]models.Relazione{}, b.Incompatibili...), b.Cumulabili...) {
+		if r.Motivo == "" {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		out[r.Bonus] = r.Motivo
+	}
+	return out
+}
+
+type field struct {
+	key, text string
+}
+
+// fields flattens the non-empty texts of a bonus, in file order.
+func fields(t models.BonusTrad) []field {
+	var out []field
+	add := func(key, text string) {
+		if text != "" {
+			out = append(out, field{key, text})
+		}
+	}
+	add("nome", t.Nome)
+	add("descrizione", t.Descrizione)
+	add("importo", t.Importo)
+	add("scadenza", t.Scadenza)
+	for _, l := range []struct {
+		name  string
+		items []string
+	}{{"requisiti", t.Requisiti}, {"come_richiederlo", t.ComeRichiederlo}, {"documenti", t.Documenti}} {
+		for i, s := range l.items {
+			add(l.name+"."+strconv.Itoa(i), s)
+		}
+	}
+	for i, q := range t.FAQ {
+		add("faq."+strconv.Itoa(i)+".domanda", q.Domanda)
+		add("faq."+strconv.Itoa(i)+".risposta", q.Risposta)
+	}
+	ids := make([]string, 0, len(t.Motivi))
+	for id := range t.Motivi {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		add("motivi."+id, t.Motivi[id])
+	}
+	return out
+}
+
+func fieldMap(t models.BonusTrad) map[string]string {
+	m := make(map[string]string)
+	for _, f := range fields(t) {
+		m[f.key] = f.text
+	}
+	return m
+}
+
+// splitBonusKey splits "bonus:<id>:<field>".
+func splitBonusKey(key string) (id, field string, ok bool) {
+	rest, ok := strings.CutPrefix(key, bonusPrefix)
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
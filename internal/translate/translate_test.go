@@ -0,0 +1,119 @@
+package translate
+
+import (
+	"bonusperme/internal/catalog"
+	"bonusperme/internal/i18n"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTranslationParity(t *testing.T) {
+	issues, err := Parity(Dirs{I18n: "../../data/i18n", Catalog: "../../data/catalog"}, "../../static")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, is := range issues {
+		t.Errorf("%s: %s %s", is.Lingua, is.Problema, is.Chiave)
+	}
+}
+
+func TestTranslationRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	d := Dirs{I18n: filepath.Join(dir, "i18n"), Catalog: filepath.Join(dir, "catalog")}
+	if err := os.CopyFS(d.I18n, os.DirFS("../../data/i18n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.CopyFS(d.Catalog, os.DirFS("../../data/catalog")); err != nil {
+		t.Fatal(err)
+	}
+
+	units, err := Units(d, "fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, u := range units {
+		switch u.Key {
+		case "btn.next":
+			units[i].Target = "Continuer"
+		case "btn.prev":
+			units[i].Target, units[i].Stale = "Retour", true
+		case "msg.avviso.scade_tra#one":
+			units[i].Target = "Expire dans {giorni} jour"
+		case "bonus:assegno-unico:importo":
+			units[i].Target = "de 58,30 € à 203,80 € par mois et par enfant"
+		}
+	}
+	var po bytes.Buffer
+	if err := WritePO(&po, "fr", units); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(po.String(), "#, fuzzy\nmsgctxt \"btn.prev\"") {
+		t.Errorf("stale unit should be exported as fuzzy")
+	}
+	lang, read, err := ReadPO(&po)
+	if err != nil || lang != "fr" || len(read) != len(units) {
+		t.Fatalf("ReadPO: lang %q, %d of %d units, %v", lang, len(read), len(units), err)
+	}
+	res, err := Import(d, lang, read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Applied != 2 || len(res.Rejected) != 1 || !strings.Contains(res.Rejected[0], "{giorni}") {
+		t.Errorf("unexpected import result: %+v", res)
+	}
+
+	fr, err := i18n.ReadFile(d.I18n, "fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fr.Testi["btn.next"] != "Continuer" || fr.Testi["btn.prev"] == "Retour" {
+		t.Errorf("UI import: btn.next %q, btn.prev %q", fr.Testi["btn.next"], fr.Testi["btn.prev"])
+	}
+	tr, err := catalog.ReadTranslation(d.Catalog, "fr", "assegno-unico")
+	if err != nil || tr.Importo != "de 58,30 € à 203,80 € par mois et par enfant" || tr.Origine["importo"] == "" {
+		t.Errorf("bonus import: %q %v %v", tr.Importo, tr.Origine, err)
+	}
+
+	// Changing the Italian text makes every translation of it stale
+	it, err := i18n.ReadFile(d.I18n, "it")
+	if err != nil {
+		t.Fatal(err)
+	}
+	it.Testi["btn.next"] = "Continua"
+	if err := i18n.WriteFile(d.I18n, it); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := Parity(d, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != len(i18n.Languages)-1 {
+		t.Errorf("expected btn.next stale in every language, got %+v", issues)
+	}
+	for _, is := range issues {
+		if is.Chiave != "btn.next" || is.Problema != Stale {
+			t.Errorf("unexpected issue %+v", is)
+		}
+	}
+
+	units, err = Units(d, "fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var xlf bytes.Buffer
+	if err := WriteXLIFF(&xlf, "fr", units); err != nil {
+		t.Fatal(err)
+	}
+	_, read, err = ReadXLIFF(&xlf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range read {
+		if u.Key == "btn.next" && (!u.Stale || u.Source != "Continua") {
+			t.Errorf("btn.next should come back for review, got %+v", u)
+		}
+	}
+}
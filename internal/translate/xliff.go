@@ -0,0 +1,89 @@
+package translate
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xliffDoc is XLIFF 1.2, the version most CAT tools read and write.
+type xliffDoc struct {
+	XMLName xml.Name    `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID     string       `xml:"id,attr"`
+	Source string       `xml:"source"`
+	Target *xliffTarget `xml:"target"`
+	Note   string       `xml:"note,omitempty"`
+}
+
+type xliffTarget struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// WriteXLIFF writes units as an XLIFF 1.2 document; the trans-unit id is
+// the key. Missing translations have state "new", stale ones
+// "needs-review-translation".
+func WriteXLIFF(w io.Writer, lang string, units []Unit) error {
+	f := xliffFile{Original: "bonusperme", SourceLanguage: "it", TargetLanguage: lang, Datatype: "plaintext"}
+	for _, u := range units {
+		t := &xliffTarget{State: "translated", Text: u.Target}
+		switch {
+		case u.Target == "":
+			t.State = "new"
+		case u.Stale:
+			t.State = "needs-review-translation"
+		}
+		f.Units = append(f.Units, xliffUnit{ID: u.Key, Source: u.Source, Target: t, Note: u.Note})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(xliffDoc{Version: "1.2", Files: []xliffFile{f}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ReadXLIFF reads an XLIFF 1.2 document. Units whose state still asks for
+// work ("new", "needs-...") come back as Stale.
+func ReadXLIFF(r io.Reader) (lang string, units []Unit, err error) {
+	var doc xliffDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return "", nil, fmt.Errorf("xliff: %w", err)
+	}
+	if doc.Version != "1.2" {
+		return "", nil, fmt.Errorf("xliff: unsupported version %q (expected 1.2)", doc.Version)
+	}
+	for _, f := range doc.Files {
+		if lang != "" && f.TargetLanguage != lang {
+			return "", nil, fmt.Errorf("xliff: files for %s and %s in one document", lang, f.TargetLanguage)
+		}
+		lang = f.TargetLanguage
+		for _, x := range f.Units {
+			u := Unit{Key: x.ID, Source: x.Source, Note: x.Note}
+			if x.Target != nil {
+				u.Target = x.Target.Text
+				u.Stale = x.Target.State == "new" || strings.HasPrefix(x.Target.State, "needs-")
+			}
+			units = append(units, u)
+		}
+	}
+	return lang, units, nil
+}
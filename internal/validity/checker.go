@@ -1,17 +1,16 @@
 package validity
 
 import (
+	"bonusperme/internal/clock"
+	"bonusperme/internal/deadline"
 	"bonusperme/internal/logger"
 	"bonusperme/internal/models"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 )
 
 // RunCheck evaluates all bonuses and stores results in statusCache.
 func RunCheck(bonuses []models.Bonus) {
-	now := time.Now()
+	now := clock.Now()
 	currentYear := now.Year()
 	checked := 0
 
@@ -32,7 +31,7 @@ func evaluate(b models.Bonus, now time.Time, currentYear int) (string, string) {
 
 	// Rule 0: UltimoAggiornamento parseable and > 90 days old → da_verificare
 	if b.UltimoAggiornamento != "" {
-		if aggiornamento, ok := parseItalianDate(b.UltimoAggiornamento); ok {
+		if aggiornamento, ok := deadline.ParseData(b.UltimoAggiornamento); ok {
 			daysSince := int(now.Sub(aggiornamento).Hours() / 24)
 			if daysSince > 90 {
 				return "da_verificare", "Dati non aggiornati da " + itoa(daysSince) + " giorni"
@@ -45,19 +44,29 @@ func evaluate(b models.Bonus, now time.Time, currentYear int) (string, string) {
 		return "attivo", "Bonus permanente confermato per " + itoa(b.AnnoConferma)
 	}
 
-	// Rule 2: data_fissa and past → scaduto
-	if tipo == "data_fissa" && !b.ScadenzaDomanda.IsZero() && now.After(b.ScadenzaDomanda) {
-		return "scaduto", "Scadenza superata: " + b.ScadenzaDomanda.Format("02/01/2006")
+	// Rule 2: application window closed → scaduto
+	f := finestra(b, now)
+	chiusura := deadline.Chiusura(f)
+	if f.Stato == models.FinestraChiusa {
+		if chiusura.IsZero() {
+			return "scaduto", "Misura non piu disponibile"
+		}
+		return "scaduto", "Scadenza superata: " + chiusura.Format("02/01/2006")
 	}
 
-	// Rule 3: data_fissa within 30 days → in_scadenza
-	if tipo == "data_fissa" && !b.ScadenzaDomanda.IsZero() {
-		daysLeft := int(b.ScadenzaDomanda.Sub(now).Hours() / 24)
+	// Rule 3: window closing within 30 days → in_scadenza
+	if f.Stato == models.FinestraAperta && !chiusura.IsZero() {
+		daysLeft := int(chiusura.Sub(now).Hours() / 24)
 		if daysLeft <= 30 && daysLeft >= 0 {
 			return "in_scadenza", "Scade tra " + itoa(daysLeft) + " giorni"
 		}
 	}
 
+	// Rule 3b: window not open yet → in_apertura
+	if f.Stato == models.FinestraInApertura {
+		return "in_apertura", "Domande dal " + deadline.Apertura(f).Format("02/01/2006")
+	}
+
 	// Rule 4: AnnoConferma < current year → da_verificare
 	if b.AnnoConferma > 0 && b.AnnoConferma < currentYear {
 		return "da_verificare", "Ultima conferma: " + itoa(b.AnnoConferma)
@@ -98,33 +107,11 @@ func itoa(n int) string {
 	return s
 }
 
-var italianMonths = map[string]time.Month{
-	"gennaio": time.January, "febbraio": time.February, "marzo": time.March,
-	"aprile": time.April, "maggio": time.May, "giugno": time.June,
-	"luglio": time.July, "agosto": time.August, "settembre": time.September,
-	"ottobre": time.October, "novembre": time.November, "dicembre": time.December,
-}
-
-var itDatePattern = regexp.MustCompile(`(\d{1,2})\s+(gennaio|febbraio|marzo|aprile|maggio|giugno|luglio|agosto|settembre|ottobre|novembre|dicembre)\s+(\d{4})`)
-
-// parseItalianDate parses dates like "15 febbraio 2026".
-func parseItalianDate(s string) (time.Time, bool) {
-	lower := strings.ToLower(strings.TrimSpace(s))
-	m := itDatePattern.FindStringSubmatch(lower)
-	if len(m) != 4 {
-		return time.Time{}, false
-	}
-	day, err := strconv.Atoi(m[1])
-	if err != nil {
-		return time.Time{}, false
-	}
-	month, ok := italianMonths[m[2]]
-	if !ok {
-		return time.Time{}, false
-	}
-	year, err := strconv.Atoi(m[3])
-	if err != nil {
-		return time.Time{}, false
+// finestra returns the deadline window computed by the matcher, or the
+// one of a profile with no data when the bonus has none.
+func finestra(b models.Bonus, now time.Time) models.Finestra {
+	if b.Finestra != nil {
+		return *b.Finestra
 	}
-	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), true
+	return deadline.Calcola(deadline.DelBonus(b), models.UserProfile{}, now)
 }
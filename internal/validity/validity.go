@@ -1,8 +1,9 @@
 package validity
 
 import (
+	"bonusperme/internal/deadline"
+	"bonusperme/internal/i18n"
 	"bonusperme/internal/models"
-	"fmt"
 	"math"
 	"sync"
 	"time"
@@ -32,48 +33,87 @@ func ApplyStatus(bonuses []models.Bonus) {
 	}
 }
 
-// GenerateAvvisi builds warnings for non-"attivo" bonuses.
-func GenerateAvvisi(bonuses []models.Bonus) []models.Avviso {
+// ApplyStatusAt sets StatoValidita and MotivoStato as evaluated on the
+// given date instead of reading the cache, which only holds today's
+// statuses. Also syncs the Scaduto bool like ApplyStatus.
+func ApplyStatusAt(bonuses []models.Bonus, asOf time.Time) {
+	for i := range bonuses {
+		stato, motivo := evaluate(bonuses[i], asOf, asOf.Year())
+		bonuses[i].StatoValidita = stato
+		bonuses[i].MotivoStato = motivo
+		if stato == "scaduto" || stato == "potenzialmente_scaduto" {
+			bonuses[i].Scaduto = true
+		}
+	}
+}
+
+// GenerateAvvisi builds warnings for non-"attivo" bonuses as of now. The
+// texts are in Italian; Codice and Parametri render them in other languages.
+func GenerateAvvisi(bonuses []models.Bonus, now time.Time) []models.Avviso {
 	var avvisi []models.Avviso
 	for _, b := range bonuses {
 		if b.Scaduto {
 			continue // already shown as expired
 		}
-		switch b.StatoValidita {
+		stato := b.StatoValidita
+		// Cached statuses are computed for a profile with no data: deadlines
+		// counted from the user's own events come from the bonus Finestra.
+		if f := b.Finestra; f != nil && (stato == "" || stato == "attivo") {
+			switch {
+			case f.Stato == models.FinestraInApertura:
+				stato = "in_apertura"
+			case f.Stato == models.FinestraAperta && !b.ScadenzaDomanda.IsZero() && b.ScadenzaDomanda.Sub(now) <= 30*24*time.Hour:
+				stato = "in_scadenza"
+			}
+		}
+		switch stato {
 		case "in_scadenza":
 			days := 0
 			if !b.ScadenzaDomanda.IsZero() {
-				days = int(math.Ceil(time.Until(b.ScadenzaDomanda).Hours() / 24))
+				days = int(math.Ceil(b.ScadenzaDomanda.Sub(now).Hours() / 24))
 				if days < 0 {
 					days = 0
 				}
 			}
-			msg := "Questo bonus scade a breve"
+			msg := i18n.M("avviso.scade_a_breve")
 			if days > 0 {
-				msg = fmt.Sprintf("Scade tra %d giorni — Fai domanda subito", days)
+				msg = i18n.M("avviso.scade_tra", "n", days)
 			}
-			avvisi = append(avvisi, models.Avviso{
-				BonusID:   b.ID,
-				Tipo:      "warning",
-				Messaggio: msg,
-			})
+			avvisi = append(avvisi, avviso(b.ID, "warning", msg))
+		case "in_apertura":
+			msg := i18n.M("avviso.non_ancora_aperte")
+			if f := b.Finestra; f != nil && f.Apertura != "" {
+				data := deadline.Apertura(*f).Format("02/01/2006")
+				msg = i18n.M("avviso.apertura", "data", data)
+				if f.Ora != "" {
+					msg = i18n.M("avviso.apertura_ora", "data", data, "ora", f.Ora)
+				}
+			}
+			avvisi = append(avvisi, avviso(b.ID, "info", msg))
 		case "da_verificare":
-			avvisi = append(avvisi, models.Avviso{
-				BonusID:   b.ID,
-				Tipo:      "info",
-				Messaggio: "Verifica disponibilità sul sito ufficiale",
-			})
+			avvisi = append(avvisi, avviso(b.ID, "info", i18n.M("avviso.da_verificare")))
 		case "potenzialmente_scaduto":
-			avvisi = append(avvisi, models.Avviso{
-				BonusID:   b.ID,
-				Tipo:      "danger",
-				Messaggio: "Questo bonus potrebbe non essere più disponibile",
-			})
+			avvisi = append(avvisi, avviso(b.ID, "danger", i18n.M("avviso.potenzialmente_scaduto")))
+		}
+	}
+	for _, b := range bonuses {
+		if f := b.Finestra; !b.Scaduto && f != nil && f.Stato == models.FinestraDaDefinire && f.Nota != "" {
+			avvisi = append(avvisi, avviso(b.ID, "info", i18n.M(f.Nota)))
 		}
 	}
 	return avvisi
 }
 
+func avviso(bonusID, tipo string, m i18n.Message) models.Avviso {
+	return models.Avviso{
+		BonusID:   bonusID,
+		Tipo:      tipo,
+		Messaggio: m.Text(i18n.Default),
+		Codice:    m.Code,
+		Parametri: m.Params,
+	}
+}
+
 // SetStatus stores a validity status in cache (used by checker and news).
 func SetStatus(bonusID, stato, motivo string) {
 	old := ""
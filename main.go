@@ -2,6 +2,7 @@ package main
 
 import (
 	"bonusperme/internal/blog"
+	"bonusperme/internal/catalog"
 	"bonusperme/internal/config"
 	"bonusperme/internal/handlers"
 	"bonusperme/internal/i18n"
@@ -30,6 +31,13 @@ func main() {
 	sentryutil.Init()
 	defer sentryutil.Flush()
 
+	// Load the bonus catalog; an invalid catalog at boot is fatal, later
+	// invalid edits are rejected and the last good catalog stays in service.
+	// The watcher starts once OnReload is set, below.
+	if err := catalog.Load(config.Cfg.CatalogDir); err != nil {
+		log.Fatal(err)
+	}
+
 	// Initialize persistent counter
 	handlers.InitCounter()
 
@@ -46,6 +54,11 @@ func main() {
 		log.Printf("blog: %v", err)
 	}
 
+	// UI translations and server messages, edited through cmd/i18n
+	if err := i18n.Load(config.Cfg.I18nDir); err != nil {
+		log.Fatal(err)
+	}
+
 	// Connect i18n translations to handler
 	handlers.SetTranslationLoader(i18n.GetAll)
 
@@ -64,8 +77,14 @@ func main() {
 	mux.HandleFunc("/api/stats", handlers.StatsHandler)
 	mux.HandleFunc("/api/health", handlers.HealthDetailedHandler)
 	mux.HandleFunc("/api/parse-isee", handlers.ParseISEEHandler)
+	mux.HandleFunc("/api/parse-redditi", handlers.ParseRedditiHandler)
 	mux.HandleFunc("/api/calendar", handlers.CalendarHandler)
+	mux.HandleFunc("/api/calendar/feed/", handlers.CalendarFeedHandler)
 	mux.HandleFunc("/api/simulate", handlers.SimulateHandler)
+	mux.HandleFunc("/api/questionnaire", handlers.QuestionnaireHandler)
+	mux.HandleFunc("/api/questionnaire/next", handlers.NextQuestionsHandler)
+	mux.HandleFunc("/api/calc/assegno-unico", handlers.AssegnoUnicoCalcHandler)
+	mux.HandleFunc("/api/calc/isee", handlers.StimaISEEHandler)
 	mux.HandleFunc("/api/report", handlers.ReportHandler)
 	mux.HandleFunc("/api/notify-signup", handlers.NotifySignupHandler)
 	mux.HandleFunc("/api/analytics", handlers.AnalyticsHandler)
@@ -79,12 +98,16 @@ func main() {
 	mux.HandleFunc("/api/decode-profile", handlers.DecodeProfileHandler)
 	mux.HandleFunc("/api/bonus", handlers.BonusListHandler)
 	mux.HandleFunc("/api/bonus/", handlers.BonusDetailHandler)
+	mux.HandleFunc("/api/comuni", handlers.ComuniHandler)
 
 	// Admin routes (protected by ADMIN_API_KEY)
 	mux.HandleFunc("/api/admin/alerts", validity.AdminAlertsHandler)
 	mux.HandleFunc("/api/admin/bonus-status", validity.AdminBonusStatusHandler)
 	mux.HandleFunc("/api/admin/links", linkcheck.AdminLinksHandler)
 	mux.HandleFunc("/api/admin/changes", scraper.AdminChangesHandler)
+	mux.HandleFunc("/api/admin/catalog", catalog.AdminStatusHandler)
+	mux.HandleFunc("/api/admin/catalog/reload", catalog.AdminReloadHandler)
+	mux.HandleFunc("/api/admin/catalog/traduzioni", catalog.AdminTranslationsHandler)
 
 	// Pages
 	mux.HandleFunc("/per-caf", handlers.PerCAFHandler)
@@ -209,14 +232,26 @@ func main() {
 	}
 
 	// Pipeline (5-level verification, respects PIPELINE_ENABLED config)
+	var orch *pipeline.Orchestrator
 	if config.Cfg.PipelineEnabled {
 		allBonusPipeline := matcher.GetAllBonusWithRegional()
-		orch := pipeline.NewOrchestrator(allBonusPipeline)
+		orch = pipeline.NewOrchestrator(allBonusPipeline)
 		orch.Start()
 		mux.HandleFunc("/api/admin/pipeline", orch.AdminStatusHandler)
 		logger.Info("pipeline: started", nil)
 	}
 
+	// Propagate catalog reloads to the caches built on top of it. The hook
+	// and orch are set before the watcher starts, so its goroutine never
+	// sees them change.
+	catalog.OnReload = func(*catalog.Snapshot) {
+		scraper.RefreshCatalog()
+		if orch != nil {
+			orch.Reload(matcher.GetAllBonusWithRegional())
+		}
+	}
+	catalog.Watch(config.Cfg.CatalogWatchInterval)
+
 	logger.Info("server starting", map[string]interface{}{"port": config.Cfg.Port})
 	fmt.Printf("BonusPerMe running on http://localhost:%s\n", config.Cfg.Port)
 	log.Fatal(http.ListenAndServe(":"+config.Cfg.Port, handler))